@@ -0,0 +1,248 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Akun jurnal bawaan, kodenya bisa diganti lewat accounting_accounts di config.json
+// agar sesuai bagan akun pembukuan pemilik
+var defaultAccounts = map[string]string{
+	"cash":          "Kas",
+	"card":          "Bank - Kartu",
+	"qris":          "Bank - QRIS",
+	"sales":         "Pendapatan Penjualan",
+	"discounts":     "Diskon Penjualan",
+	"tax":           "Hutang Pajak",
+	"table_charges": "Pendapatan Cover dan Minimum Belanja",
+	"refunds":       "Retur Penjualan",
+}
+
+// Struct untuk satu baris jurnal, hanya salah satu dari Debit atau Credit yang terisi
+type JournalLine struct {
+	Date        time.Time
+	Account     string
+	Description string
+	Debit       Money
+	Credit      Money
+}
+
+// Fungsi untuk mengambil nama akun jurnal sesuai konfigurasi
+func accountName(key string) string {
+	if name := currentConfig().AccountingAccounts[key]; name != "" {
+		return name
+	}
+	return defaultAccounts[key]
+}
+
+// Fungsi untuk menyusun jurnal harian dari pesanan yang dibayar dan refund dalam
+// periode. Penjualan dicatat pada tanggal bayar: kas dan bank di debit, pendapatan,
+// pajak dan biaya meja di kredit, diskon di debit sebagai pengurang pendapatan.
+// Setiap hari selalu seimbang antara debit dan kredit.
+func buildJournal(from, to time.Time) []JournalLine {
+	inPeriod := func(t time.Time) bool {
+		return (from.IsZero() || !t.Before(from)) && (to.IsZero() || t.Before(to))
+	}
+	type dayTotals struct {
+		sales, discounts, tax, tableCharges, refunds Money
+		payments                                     map[PaymentMethod]Money
+	}
+	days := map[time.Time]*dayTotals{}
+	day := func(t time.Time) *dayTotals {
+		local := t.Local()
+		key := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.Local)
+		if days[key] == nil {
+			days[key] = &dayTotals{payments: map[PaymentMethod]Money{}}
+		}
+		return days[key]
+	}
+
+	ordersMutex.Lock()
+	for _, order := range orders {
+		if !order.Paid || order.Voided || order.MergedInto != 0 || !inPeriod(order.PaidAt) {
+			continue
+		}
+		totals := day(order.PaidAt)
+		totals.sales += order.TotalPrice
+		totals.discounts += order.Discount
+		totals.tax += order.Tax()
+		totals.tableCharges += order.TableCharges()
+		// Pesanan tab dan split bill bisa dilunasi dengan beberapa metode, dicatat per pembayaran
+		for _, payment := range order.PaymentEntries() {
+			totals.payments[payment.Method] += payment.Amount
+		}
+	}
+	ordersMutex.Unlock()
+
+	returnsMutex.Lock()
+	ensureReturnsLoaded()
+	for _, record := range returns {
+		if record.Refunded && inPeriod(record.Time) {
+			day(record.Time).refunds += record.Amount
+		}
+	}
+	returnsMutex.Unlock()
+
+	dates := make([]time.Time, 0, len(days))
+	for date := range days {
+		dates = append(dates, date)
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	var journal []JournalLine
+	for _, date := range dates {
+		totals := days[date]
+		description := "Penjualan " + date.Format("2006-01-02")
+		add := func(key string, debit, credit Money) {
+			if debit == 0 && credit == 0 {
+				return
+			}
+			journal = append(journal, JournalLine{Date: date, Account: accountName(key), Description: description, Debit: debit, Credit: credit})
+		}
+
+		for _, method := range []PaymentMethod{PaymentCash, PaymentCard, PaymentQRIS} {
+			add(string(method), totals.payments[method], 0)
+		}
+		add("discounts", totals.discounts, 0)
+		add("sales", 0, totals.sales)
+		add("tax", 0, totals.tax)
+		add("table_charges", 0, totals.tableCharges)
+
+		// Refund dibayar dari laci kasir, sama seperti di rekonsiliasi kas
+		description = "Refund " + date.Format("2006-01-02")
+		add("refunds", totals.refunds, 0)
+		add("cash", 0, totals.refunds)
+	}
+	balanceJournal(journal)
+	return journal
+}
+
+// Fungsi untuk menyeimbangkan selisih pembulatan per hari pada baris pendapatan agar
+// jurnal tidak ditolak program akuntansi
+func balanceJournal(journal []JournalLine) {
+	sales := accountName("sales")
+	for start := 0; start < len(journal); {
+		end := start
+		var diff Money
+		for end < len(journal) && journal[end].Date.Equal(journal[start].Date) {
+			diff += journal[end].Debit - journal[end].Credit
+			end++
+		}
+		if diff != 0 {
+			for i := start; i < end; i++ {
+				if journal[i].Account == sales {
+					journal[i].Credit += diff
+					break
+				}
+			}
+		}
+		start = end
+	}
+}
+
+// Fungsi untuk menulis jurnal dalam format CSV umum dengan kolom debit dan kredit
+func writeJournalCSV(path string, journal []JournalLine) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	writer.Write([]string{"date", "journal_no", "account", "description", "debit", "credit"})
+	for _, line := range journal {
+		writer.Write([]string{
+			line.Date.Format("2006-01-02"),
+			"POS-" + line.Date.Format("20060102"),
+			line.Account,
+			line.Description,
+			formatMoneyPlain(line.Debit),
+			formatMoneyPlain(line.Credit),
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Fungsi untuk menulis jurnal dalam format satu kolom jumlah, debit positif dan kredit
+// negatif, seperti impor jurnal manual di banyak program akuntansi online
+func writeJournalSignedCSV(path string, journal []JournalLine) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	writer.Write([]string{"Narration", "Date", "Description", "AccountCode", "LineAmount"})
+	for _, line := range journal {
+		writer.Write([]string{
+			"POS " + line.Date.Format("2006-01-02"),
+			line.Date.Format("2006-01-02"),
+			line.Description,
+			line.Account,
+			formatMoneyPlain(line.Debit - line.Credit),
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Fungsi untuk mengekspor jurnal penjualan ke file CSV yang bisa diimpor pembukuan.
+// Tanpa tanggal awal, jurnal dimulai dari penutupan hari terakhir.
+func exportAccounting(reader *bufio.Reader) {
+	fmt.Print("Format (jurnal = debit/kredit, signed = satu kolom jumlah): ")
+	formatInput, _ := reader.ReadString('\n')
+	format := strings.ToLower(strings.TrimSpace(formatInput))
+	if format != "jurnal" && format != "signed" {
+		fmt.Println("Format harus jurnal atau signed.")
+		return
+	}
+
+	from, ok := readOptionalDate(reader, "Dari tanggal (YYYY-MM-DD, kosongkan untuk sejak tutup hari terakhir): ")
+	if !ok {
+		return
+	}
+	if from.IsZero() {
+		from = currentPeriodStart()
+	}
+	to, ok := readOptionalDate(reader, "Sampai tanggal (YYYY-MM-DD, kosongkan untuk sampai sekarang): ")
+	if !ok {
+		return
+	}
+	if !to.IsZero() {
+		// Tanggal akhir ikut dihitung sampai akhir hari
+		to = to.AddDate(0, 0, 1)
+	}
+
+	fmt.Printf("Nama file (default jurnal_%s.csv): ", format)
+	path, _ := reader.ReadString('\n')
+	path = strings.TrimSpace(path)
+	if path == "" {
+		path = "jurnal_" + format + ".csv"
+	}
+
+	journal := buildJournal(from, to)
+	if len(journal) == 0 {
+		fmt.Println("Tidak ada penjualan yang dibayar pada periode ini.")
+		return
+	}
+	var err error
+	if format == "signed" {
+		err = writeJournalSignedCSV(path, journal)
+	} else {
+		err = writeJournalCSV(path, journal)
+	}
+	if err != nil {
+		fmt.Println("Gagal mengekspor jurnal:", err)
+		return
+	}
+
+	logActivity(fmt.Sprintf("ekspor jurnal akuntansi %s (%d baris)", path, len(journal)))
+	fmt.Printf("%d baris jurnal diekspor ke %s.\n", len(journal), path)
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// Fungsi untuk menandai pesanan sudah dikonfirmasi dapur. Pendapatan pesanan baru
+// diakui setelah konfirmasi, nilai yang dikembalikan harus ditambahkan pemanggil ke
+// total lewat recognizeRevenue setelah melepas ordersMutex.
+// Pemanggil harus memegang ordersMutex.
+func acknowledgeOrder(order *Order, now time.Time) (Money, bool) {
+	if !order.PendingAck {
+		return 0, false
+	}
+	order.PendingAck = false
+	order.AcknowledgedAt = now
+	publishOrderEvent(OrderEvent{Type: EventOrderAcknowledged, OrderID: order.ID, PickupCode: order.PickupCode, Table: order.Table})
+	return orderRevenue(order), true
+}
+
+// Fungsi untuk menambahkan pendapatan yang baru diakui ke total semua pesanan
+func recognizeRevenue(amount Money) {
+	if amount == 0 {
+		return
+	}
+	totalMutex.Lock()
+	totalAllOrders += amount
+	totalMutex.Unlock()
+}
+
+// Fungsi untuk konfirmasi manual pesanan dari tampilan dapur
+func acknowledgeOrderByID(id int) {
+	ordersMutex.Lock()
+	order := followMergedOrder(findOrder(id))
+	if order == nil {
+		ordersMutex.Unlock()
+		fmt.Println("Pesanan tidak ditemukan.")
+		return
+	}
+	revenue, ok := acknowledgeOrder(order, time.Now())
+	id = order.ID
+	ordersMutex.Unlock()
+
+	if !ok {
+		fmt.Printf("Pesanan ID %d sudah dikonfirmasi dapur.\n", id)
+		return
+	}
+	recognizeRevenue(revenue)
+	logActivity(fmt.Sprintf("dapur konfirmasi pesanan %d", id))
+	fmt.Printf("Pesanan ID %d dikonfirmasi dapur.\n", id)
+}
+
+// Fungsi untuk mengonfirmasi otomatis pesanan yang belum dikonfirmasi dapur setelah
+// batas waktu di konfigurasi. Pesanan terjadwal dihitung sejak masuk dapur.
+func autoAcknowledgeOrders(now time.Time) {
+	settings := currentConfig()
+	timeout := time.Duration(settings.KitchenAckMinutes) * time.Minute
+	lead := time.Duration(settings.PreOrderLeadMinutes) * time.Minute
+
+	ordersMutex.Lock()
+	var revenue Money
+	var acknowledged []int
+	for _, order := range orders {
+		if !order.PendingAck {
+			continue
+		}
+		start := order.CreatedAt
+		if !order.PickupAt.IsZero() {
+			start = order.PickupAt.Add(-lead)
+		}
+		if now.Sub(start) < timeout {
+			continue
+		}
+		amount, _ := acknowledgeOrder(order, now)
+		revenue += amount
+		acknowledged = append(acknowledged, order.ID)
+	}
+	ordersMutex.Unlock()
+
+	recognizeRevenue(revenue)
+	for _, id := range acknowledged {
+		logActivity(fmt.Sprintf("konfirmasi otomatis pesanan %d", id))
+	}
+}
+
+// Fungsi untuk mengambil ID pesanan yang belum dikonfirmasi dapur
+func pendingAckOrders() []int {
+	ordersMutex.Lock()
+	defer ordersMutex.Unlock()
+
+	var ids []int
+	for _, order := range orders {
+		if order.PendingAck {
+			ids = append(ids, order.ID)
+		}
+	}
+	return ids
+}
+
+// Fungsi untuk menampilkan tanda pesanan yang belum dikonfirmasi dapur
+func describeAck(order *Order) string {
+	if order.PendingAck {
+		return " [BELUM DIKONFIRMASI]"
+	}
+	return ""
+}
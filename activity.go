@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Nama file log aktivitas di folder data, satu entri JSON per baris
+const activityLogFile = "activity.log"
+
+// Struct untuk satu entri log aktivitas
+type ActivityEntry struct {
+	Time    time.Time `json:"time"`
+	User    string    `json:"user"`
+	Command string    `json:"command"`
+}
+
+// Kasir yang sedang memakai terminal, awalnya nama pengguna sistem operasi
+var currentCashier = defaultCashier()
+var activityMutex sync.Mutex
+
+// Fungsi untuk menentukan nama kasir awal
+func defaultCashier() string {
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		return current.Username
+	}
+	return "kasir"
+}
+
+// Fungsi untuk mencatat opsi menu utama yang dipilih
+func logCommand(option string) {
+	index, err := strconv.Atoi(option)
+	if err != nil || index < 1 || index > len(mainMenuOptions) {
+		logActivity(fmt.Sprintf("opsi tidak valid %q", option))
+		return
+	}
+	logActivity(fmt.Sprintf("opsi %d: %s", index, mainMenuOptions[index-1]))
+}
+
+// Fungsi untuk menambahkan entri ke log aktivitas. Entri langsung ditulis ke file
+// agar tetap ada walaupun program berhenti mendadak.
+func logActivity(command string) {
+	activityMutex.Lock()
+	defer activityMutex.Unlock()
+
+	entry := ActivityEntry{Time: time.Now(), User: currentCashier, Command: command}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	dataDir := currentConfig().DataDir
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		fmt.Println("Gagal menulis log aktivitas:", err)
+		return
+	}
+	file, err := os.OpenFile(filepath.Join(dataDir, activityLogFile), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		fmt.Println("Gagal menulis log aktivitas:", err)
+		return
+	}
+	defer file.Close()
+
+	file.Write(append(data, '\n'))
+}
+
+// Fungsi untuk mengganti kasir yang sedang memakai terminal
+func switchCashier(reader *bufio.Reader) {
+	fmt.Print("Nama kasir: ")
+	name, _ := reader.ReadString('\n')
+	name = strings.TrimSpace(name)
+	if name == "" {
+		fmt.Println("Nama kasir wajib diisi.")
+		return
+	}
+
+	activityMutex.Lock()
+	previous := currentCashier
+	currentCashier = name
+	activityMutex.Unlock()
+
+	logActivity("ganti kasir dari " + previous)
+	closeShift(previous, "ganti kasir")
+	fmt.Printf("Kasir aktif: %s\n", name)
+}
+
+// Fungsi untuk membaca seluruh log aktivitas dari file
+func readActivityLog() ([]ActivityEntry, error) {
+	activityMutex.Lock()
+	defer activityMutex.Unlock()
+
+	file, err := os.Open(filepath.Join(currentConfig().DataDir, activityLogFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []ActivityEntry
+	scanner := bufio.NewScanner(file)
+	line := 0
+	for scanner.Scan() {
+		line++
+		var entry ActivityEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("%s baris %d: %w", activityLogFile, line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// Fungsi untuk mengekspor log aktivitas ke CSV, bisa disaring per kasir dan tanggal
+func exportActivityLog(reader *bufio.Reader) {
+	fmt.Print("Filter kasir (kosongkan untuk semua): ")
+	cashier, _ := reader.ReadString('\n')
+	cashier = strings.TrimSpace(cashier)
+
+	from, ok := readOptionalDate(reader, "Dari tanggal (YYYY-MM-DD, kosongkan untuk semua): ")
+	if !ok {
+		return
+	}
+	to, ok := readOptionalDate(reader, "Sampai tanggal (YYYY-MM-DD, kosongkan untuk semua): ")
+	if !ok {
+		return
+	}
+	if !to.IsZero() {
+		to = to.AddDate(0, 0, 1)
+	}
+
+	fmt.Print("Nama file (default log_aktivitas.csv): ")
+	path, _ := reader.ReadString('\n')
+	path = strings.TrimSpace(path)
+	if path == "" {
+		path = "log_aktivitas.csv"
+	}
+
+	entries, err := readActivityLog()
+	if err != nil {
+		fmt.Println("Gagal membaca log aktivitas:", err)
+		return
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		fmt.Println("Gagal mengekspor log aktivitas:", err)
+		return
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	writer.Write([]string{"time", "user", "command"})
+	count := 0
+	for _, entry := range entries {
+		if cashier != "" && !strings.EqualFold(entry.User, cashier) {
+			continue
+		}
+		if !from.IsZero() && entry.Time.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !entry.Time.Before(to) {
+			continue
+		}
+		writer.Write([]string{entry.Time.Format(time.RFC3339), entry.User, entry.Command})
+		count++
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		fmt.Println("Gagal mengekspor log aktivitas:", err)
+		return
+	}
+
+	fmt.Printf("%d entri log aktivitas diekspor ke %s.\n", count, path)
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bufio"
+	"crypto/subtle"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Nama file log percobaan PIN admin yang gagal, disimpan di folder data
+const adminLogFile = "admin.log"
+
+// Fungsi untuk meminta PIN admin sebelum aksi yang merusak data.
+// Jika PIN tidak dikonfigurasi aksi langsung diizinkan.
+func requireAdminPIN(reader *bufio.Reader, action string) bool {
+	adminPIN := currentConfig().AdminPIN
+	if adminPIN == "" {
+		return true
+	}
+
+	fmt.Print("Masukkan PIN admin: ")
+	input, _ := reader.ReadString('\n')
+	pin := strings.TrimSpace(input)
+	if subtle.ConstantTimeCompare([]byte(pin), []byte(adminPIN)) == 1 {
+		return true
+	}
+
+	logFailedAdminAttempt(action)
+	fmt.Println("PIN salah, aksi dibatalkan.")
+	return false
+}
+
+// Fungsi untuk mencatat percobaan PIN yang gagal ke file log
+func logFailedAdminAttempt(action string) {
+	dataDir := currentConfig().DataDir
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		fmt.Println("Gagal mencatat percobaan PIN:", err)
+		return
+	}
+
+	file, err := os.OpenFile(filepath.Join(dataDir, adminLogFile), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		fmt.Println("Gagal mencatat percobaan PIN:", err)
+		return
+	}
+	defer file.Close()
+
+	fmt.Fprintf(file, "%s PIN salah untuk aksi: %s\n", time.Now().Format(time.RFC3339), action)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"strings"
+)
+
+// Alias bawaan untuk opsi yang paling sering dipakai saat jam sibuk
+var builtinAliases = map[string]string{
+	"m": "1",
+	"o": "2",
+	"t": "3",
+	"q": "4",
+	"k": "5",
+	"f": "6",
+	"u": "7",
+	"b": "8",
+	"r": "9",
+	"s": "11",
+	// "report custom ..." menjalankan laporan kustom dengan filter,
+	// "report branches ..." membandingkan penjualan antar cabang
+	"report": "34",
+	// "menu reprice ..." mengubah harga banyak item sekaligus
+	"menu": "39",
+	// "86 nasi goreng" menandai item tidak tersedia, perintah yang sama membatalkannya
+	"86": "45",
+	// "recall 2" memanggil kembali pesanan yang diparkir di slot 2
+	"recall": "49",
+	// "bill meja 3" mencetak tagihan sementara meja 3 sebelum dibayar
+	"bill": "52",
+}
+
+// Fungsi untuk mengubah input kasir menjadi nomor opsi dan argumen tambahan.
+// Alias dari konfigurasi dicek lebih dulu sehingga bisa menimpa alias bawaan,
+// dan isinya boleh berupa perintah lengkap seperti "o 1x es teh".
+func resolveCommand(input string) (string, string) {
+	name, args := splitCommand(input)
+
+	if expansion, ok := lookupAlias(currentConfig().Aliases, name); ok {
+		expandedName, expandedArgs := splitCommand(expansion)
+		name = expandedName
+		args = strings.TrimSpace(expandedArgs + " " + args)
+	}
+	if option, ok := builtinAliases[strings.ToLower(name)]; ok {
+		name = option
+	}
+	return name, args
+}
+
+// Fungsi untuk memisahkan kata pertama input dari sisanya
+func splitCommand(input string) (string, string) {
+	name, args, _ := strings.Cut(strings.TrimSpace(input), " ")
+	return name, strings.TrimSpace(args)
+}
+
+// Fungsi untuk mencari alias tanpa membedakan huruf besar dan kecil
+func lookupAlias(aliases map[string]string, name string) (string, bool) {
+	for alias, expansion := range aliases {
+		if strings.EqualFold(alias, name) {
+			return expansion, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Isi pengganti untuk teks yang mungkin berisi data pribadi
+const redacted = "***"
+
+// Nomor telepon yang diawali 0 atau + (minimal 8 angka, boleh dipisah spasi atau tanda -) dan alamat email di
+// teks bebas seperti catatan pesanan
+var phonePattern = regexp.MustCompile(`(?:\+|\b0)\d[\d\s-]{5,}\d`)
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+
+// Struct untuk isi file ekspor anonim. Nilai uang, jumlah dan waktu tetap utuh.
+type anonymizedExport struct {
+	ExportedAt time.Time       `json:"exported_at"`
+	From       time.Time       `json:"from,omitzero"`
+	To         time.Time       `json:"to,omitzero"`
+	Menu       []MenuItem      `json:"menu"`
+	Orders     []Order         `json:"orders"`
+	Cash       []CashEntry     `json:"cash"`
+	Activity   []ActivityEntry `json:"activity"`
+}
+
+// Struct untuk mengganti nama orang dengan nama samaran yang tetap sama di seluruh
+// ekspor, sehingga laporan per kasir masih bisa dibuat tanpa mengetahui orangnya
+type anonymizer struct {
+	prefix string
+	names  map[string]string
+}
+
+// Fungsi untuk membuat pengganti nama dengan awalan seperti "kasir" atau "pelanggan"
+func newAnonymizer(prefix string) *anonymizer {
+	return &anonymizer{prefix: prefix, names: map[string]string{}}
+}
+
+// Fungsi untuk mengambil nama samaran, nama kosong tetap kosong
+func (a *anonymizer) alias(name string) string {
+	if name == "" {
+		return ""
+	}
+	key := strings.ToLower(name)
+	if alias, ok := a.names[key]; ok {
+		return alias
+	}
+	alias := fmt.Sprintf("%s-%d", a.prefix, len(a.names)+1)
+	a.names[key] = alias
+	return alias
+}
+
+// Fungsi untuk mengganti nama yang sudah dikenal di dalam teks bebas. Nama terpanjang
+// diganti lebih dulu agar "budi santoso" tidak tersisa sebagian.
+func (a *anonymizer) scrub(text string) string {
+	names := make([]string, 0, len(a.names))
+	for name := range a.names {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+	for _, name := range names {
+		text = replaceFold(text, name, a.names[name])
+	}
+	return text
+}
+
+// Fungsi untuk mengganti semua kemunculan kata tanpa membedakan huruf besar kecil
+func replaceFold(text, old, replacement string) string {
+	pattern := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(old) + `\b`)
+	return pattern.ReplaceAllLiteralString(text, replacement)
+}
+
+// Fungsi untuk menghapus nomor telepon dan email dari teks bebas
+func scrubContacts(text string) string {
+	text = emailPattern.ReplaceAllString(text, redacted)
+	return phonePattern.ReplaceAllString(text, redacted)
+}
+
+// Fungsi untuk menyamarkan kasir pesanan. Klien API bukan orang sehingga namanya tetap.
+func anonymizeCashier(cashiers *anonymizer, cashier string) string {
+	if strings.HasPrefix(cashier, "api:") {
+		return cashier
+	}
+	return cashiers.alias(cashier)
+}
+
+// Fungsi untuk menyusun data anonim: nama kasir dan pelanggan diganti nama samaran,
+// email pelanggan dan isi kolom tambahan dihapus, nomor telepon di catatan disamarkan
+func buildAnonymizedExport(from, to time.Time) (anonymizedExport, error) {
+	export := anonymizedExport{ExportedAt: time.Now(), From: from, To: to}
+
+	menuMutex.Lock()
+	for _, item := range menu {
+		export.Menu = append(export.Menu, copyMenuItem(item))
+	}
+	menuMutex.Unlock()
+
+	reportOrders, err := ordersForReport(true, from, to)
+	if err != nil {
+		return export, err
+	}
+	cashiers := newAnonymizer("kasir")
+	customers := newAnonymizer("pelanggan")
+	for _, order := range reportOrders {
+		order.Cashier = anonymizeCashier(cashiers, order.Cashier)
+		order.Tab = customers.alias(order.Tab)
+		if order.CustomerEmail != "" {
+			order.CustomerEmail = redacted
+		}
+		// Kolom tambahan bisa berisi nama atau nomor telepon pelanggan, hanya kuncinya yang disimpan
+		fields := map[string]string{}
+		for key := range order.Fields {
+			fields[key] = redacted
+		}
+		if len(fields) > 0 {
+			order.Fields = fields
+		}
+		order.DiscountApproval.RequestedBy = cashiers.alias(order.DiscountApproval.RequestedBy)
+		order.DiscountApproval.ApprovedBy = cashiers.alias(order.DiscountApproval.ApprovedBy)
+		export.Orders = append(export.Orders, order)
+	}
+
+	cashMutex.Lock()
+	ensureCashLoaded()
+	for _, entry := range cashEntries {
+		if (!from.IsZero() && entry.Time.Before(from)) || (!to.IsZero() && !entry.Time.Before(to)) {
+			continue
+		}
+		entry.Cashier = cashiers.alias(entry.Cashier)
+		export.Cash = append(export.Cash, entry)
+	}
+	cashMutex.Unlock()
+
+	activity, err := readActivityLog()
+	if err != nil {
+		return export, err
+	}
+	for _, entry := range activity {
+		if (!from.IsZero() && entry.Time.Before(from)) || (!to.IsZero() && !entry.Time.Before(to)) {
+			continue
+		}
+		entry.User = cashiers.alias(entry.User)
+		export.Activity = append(export.Activity, entry)
+	}
+
+	// Teks bebas disamarkan setelah semua nama terkumpul, misalnya "ganti kasir dari budi"
+	for i := range export.Orders {
+		export.Orders[i].Note = scrubContacts(customers.scrub(cashiers.scrub(export.Orders[i].Note)))
+	}
+	for i := range export.Cash {
+		export.Cash[i].Reason = scrubContacts(customers.scrub(cashiers.scrub(export.Cash[i].Reason)))
+	}
+	for i := range export.Activity {
+		export.Activity[i].Command = scrubContacts(customers.scrub(cashiers.scrub(export.Activity[i].Command)))
+	}
+	return export, nil
+}
+
+// Fungsi untuk mengekspor menu, pesanan, kas dan log aktivitas tanpa data pribadi,
+// misalnya untuk dibagikan ke konsultan atau dilampirkan di laporan bug
+func exportAnonymized(reader *bufio.Reader) {
+	from, ok := readOptionalDate(reader, "Dari tanggal (YYYY-MM-DD, kosongkan untuk semua): ")
+	if !ok {
+		return
+	}
+	to, ok := readOptionalDate(reader, "Sampai tanggal (YYYY-MM-DD, kosongkan untuk semua): ")
+	if !ok {
+		return
+	}
+	if !to.IsZero() {
+		// Tanggal akhir ikut dihitung sampai akhir hari
+		to = to.AddDate(0, 0, 1)
+	}
+
+	fmt.Print("Nama file (default data_anonim.json): ")
+	path, _ := reader.ReadString('\n')
+	path = strings.TrimSpace(path)
+	if path == "" {
+		path = "data_anonim.json"
+	}
+
+	export, err := buildAnonymizedExport(from, to)
+	if err != nil {
+		fmt.Println("Gagal menyiapkan data anonim:", err)
+		return
+	}
+	if err := writeJSONFile(path, export); err != nil {
+		fmt.Println("Gagal mengekspor data anonim:", err)
+		return
+	}
+
+	logActivity(fmt.Sprintf("ekspor data anonim %s (%d pesanan)", path, len(export.Orders)))
+	fmt.Printf("%d pesanan, %d catatan kas dan %d log aktivitas diekspor tanpa data pribadi ke %s.\n", len(export.Orders), len(export.Cash), len(export.Activity), path)
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// Body permintaan untuk membuat pesanan lewat API
+type apiOrderRequest struct {
+	Table  int    `json:"table,omitempty"`
+	Guests int    `json:"guests,omitempty"`
+	Note   string `json:"note,omitempty"`
+	// Pesanan diantar ke pelanggan, tidak boleh bersama nomor meja
+	Delivery bool `json:"delivery,omitempty"`
+	// Isi kolom tambahan dari order_fields, per kunci kolom
+	Fields map[string]string `json:"fields,omitempty"`
+	// Nama daftar harga dari price_lists, kosongkan untuk daftar harga klien atau harga menu
+	PriceList string `json:"price_list,omitempty"`
+	Items     []struct {
+		Name     string `json:"name"`
+		Quantity int    `json:"quantity"`
+		// Nama tambahan dari konfigurasi modifiers, misalnya ["telur"]
+		Modifiers []string `json:"modifiers,omitempty"`
+	} `json:"items"`
+}
+
+// Body jawaban saat pesanan dibuat lewat API
+type apiOrderResponse struct {
+	Order   Order    `json:"order"`
+	Skipped []string `json:"skipped,omitempty"`
+	// Pesanan ditahan sampai dikonfirmasi kasir sesuai api_order_policy klien
+	AwaitingConfirmation bool `json:"awaiting_confirmation,omitempty"`
+}
+
+// Body jawaban kesalahan API
+type apiError struct {
+	Error string `json:"error"`
+}
+
+// Struct untuk satu endpoint API. Daftar ini dipakai untuk mendaftarkan handler
+// sekaligus membuat dokumen OpenAPI, sehingga keduanya tidak bisa berbeda.
+type apiRoute struct {
+	Method  string
+	Path    string
+	Summary string
+	Handler http.HandlerFunc
+	// Contoh nilai body permintaan, nil jika endpoint tidak menerima body
+	Request any
+	// Contoh nilai body jawaban per kode status HTTP
+	Responses map[int]any
+	// Jawaban berupa aliran Server-Sent Events, bukan satu dokumen JSON
+	Stream bool
+}
+
+// Daftar semua endpoint yang dilindungi kunci API
+var apiRoutes = []apiRoute{
+	{
+		Method: "GET", Path: "/menu", Summary: "Daftar item menu beserta varian dan stoknya",
+		Handler:   handleGetMenu,
+		Responses: map[int]any{http.StatusOK: []MenuItem{}, http.StatusNotModified: nil},
+	},
+	{
+		Method: "GET", Path: "/specials", Summary: "Spesial hari ini beserta harga normalnya",
+		Handler:   handleGetSpecials,
+		Responses: map[int]any{http.StatusOK: []DailySpecial{}},
+	},
+	{
+		Method: "POST", Path: "/orders", Summary: "Membuat pesanan dan mengirimnya ke dapur, atau menahannya sampai dikonfirmasi kasir",
+		Handler: handleCreateOrder,
+		Request: apiOrderRequest{},
+		Responses: map[int]any{
+			http.StatusCreated:            apiOrderResponse{},
+			http.StatusBadRequest:         apiError{},
+			http.StatusConflict:           apiOrderResponse{},
+			http.StatusServiceUnavailable: apiError{},
+		},
+	},
+	{
+		Method: "GET", Path: "/orders/{id}", Summary: "Status satu pesanan",
+		Handler: handleGetOrder,
+		Responses: map[int]any{
+			http.StatusOK:         Order{},
+			http.StatusBadRequest: apiError{},
+			http.StatusNotFound:   apiError{},
+		},
+	},
+	{
+		Method: "GET", Path: "/reports/summary", Summary: "Ringkasan periode berjalan sejak penutupan hari terakhir",
+		Handler:   handleSummaryReport,
+		Responses: map[int]any{http.StatusOK: DayClose{}},
+	},
+	{
+		Method: "GET", Path: "/events", Summary: "Aliran event siklus hidup pesanan (Server-Sent Events)",
+		Handler:   handleOrderEvents,
+		Responses: map[int]any{http.StatusOK: OrderEvent{}},
+		Stream:    true,
+	},
+	{
+		Method: "POST", Path: "/sync", Summary: "Sinkronisasi pesanan dan perubahan menu dari terminal yang sempat offline",
+		Handler: handleSync,
+		Request: SyncRequest{},
+		Responses: map[int]any{
+			http.StatusOK:                  SyncResponse{},
+			http.StatusBadRequest:          apiError{},
+			http.StatusForbidden:           apiError{},
+			http.StatusInternalServerError: apiError{},
+		},
+	},
+}
+
+// Bernilai true selama mode serve berjalan, dipakai untuk alamat pencarian di QR struk
+var apiServerRunning atomic.Bool
+
+// Fungsi untuk menjalankan server HTTP mode serve di latar belakang,
+// sehingga kiosk atau aplikasi lain bisa memesan sementara kasir tetap memakai CLI
+func startAPIServer(addr string) *http.Server {
+	mux := http.NewServeMux()
+	for _, route := range apiRoutes {
+		mux.HandleFunc(route.Method+" "+route.Path, route.Handler)
+	}
+
+	// Dokumen OpenAPI bisa dibaca tanpa kunci agar integrator bisa membuat klien
+	root := http.NewServeMux()
+	root.Handle("/", requireAPIKey(mux))
+	root.HandleFunc("GET /openapi.json", handleOpenAPI)
+
+	server := &http.Server{Addr: addr, Handler: root}
+	apiServerRunning.Store(true)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			apiServerRunning.Store(false)
+			fmt.Println("\nServer API berhenti:", err)
+		}
+	}()
+	fmt.Printf("Server API berjalan di %s\n", addr)
+	return server
+}
+
+// Fungsi untuk menulis jawaban JSON
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+// Fungsi untuk menulis jawaban kesalahan dalam format JSON
+func writeAPIError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, apiError{Error: message})
+}
+
+// GET /specials mengembalikan spesial hari ini
+func handleGetSpecials(w http.ResponseWriter, r *http.Request) {
+	list := currentSpecials()
+	if list == nil {
+		list = []DailySpecial{}
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+// POST /orders membuat pesanan dan mengirimnya ke dapur
+func handleCreateOrder(w http.ResponseWriter, r *http.Request) {
+	var request apiOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "body JSON tidak valid: "+err.Error())
+		return
+	}
+	if request.Table < 0 {
+		writeAPIError(w, http.StatusBadRequest, "nomor meja tidak boleh negatif")
+		return
+	}
+	if request.Guests < 0 || (request.Guests > 0 && request.Table == 0) {
+		writeAPIError(w, http.StatusBadRequest, "jumlah tamu harus positif dan hanya untuk pesanan dengan nomor meja")
+		return
+	}
+
+	if request.Delivery && request.Table > 0 {
+		writeAPIError(w, http.StatusBadRequest, "pesanan antar tidak memakai nomor meja")
+		return
+	}
+
+	parsed := quickOrder{Table: request.Table, Guests: request.Guests, Note: request.Note, Delivery: request.Delivery}
+	for _, item := range request.Items {
+		if item.Quantity <= 0 {
+			writeAPIError(w, http.StatusBadRequest, fmt.Sprintf("jumlah %s harus positif", item.Name))
+			return
+		}
+		parsed.Items = append(parsed.Items, quickItem{Name: item.Name, Quantity: item.Quantity, Modifiers: item.Modifiers})
+	}
+	if len(parsed.Items) == 0 {
+		writeAPIError(w, http.StatusBadRequest, "pesanan tidak memiliki item")
+		return
+	}
+	orderType := (&Order{Table: parsed.Table, Delivery: parsed.Delivery}).Type()
+	fields, err := checkOrderFields(request.Fields, orderType)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	client := apiClientName(r)
+	if parsed.PriceList, err = apiPriceList(request.PriceList, client, orderType); err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := checkKitchenCapacity(); err != nil {
+		w.Header().Set("Retry-After", "30")
+		writeAPIError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	orderID, ok := newOrderID()
+	if !ok {
+		writeAPIError(w, http.StatusServiceUnavailable, "gagal mengambil ID pesanan")
+		return
+	}
+	order, skipped := placeQuickOrder(orderID, parsed)
+	response := apiOrderResponse{}
+	for _, err := range skipped {
+		response.Skipped = append(response.Skipped, err.Error())
+	}
+	if order == nil {
+		writeJSON(w, http.StatusConflict, response)
+		return
+	}
+
+	order.Fields = fields
+	order.Cashier = "api:" + client
+	order.Source = apiOrderSource(client)
+	// Klien yang belum dipercaya hanya menitipkan pesanan, kasir mengirimnya ke dapur
+	// lewat opsi kirim item tertahan atau membatalkannya dengan void
+	if apiOrderPolicy(client) == PolicyConfirm {
+		for i := range order.Lines {
+			if order.Lines[i].Status == LineQueued {
+				order.Lines[i].Status = LineHeld
+				response.AwaitingConfirmation = true
+			}
+		}
+	}
+	recordOrder(order)
+	if response.AwaitingConfirmation {
+		notify("Pesanan API menunggu konfirmasi", fmt.Sprintf("pesanan ID %d dari %s, kirim ke dapur lewat opsi 6", order.ID, client))
+		logActivity(fmt.Sprintf("api %s: pesanan ID %d menunggu konfirmasi kasir", client, order.ID))
+	} else {
+		dispatchOrder(order, true)
+		logActivity(fmt.Sprintf("api %s: pesanan ID %d", client, order.ID))
+	}
+	saveStateInBackground()
+
+	ordersMutex.Lock()
+	response.Order = copyOrder(*order)
+	ordersMutex.Unlock()
+	writeJSON(w, http.StatusCreated, response)
+}
+
+// GET /orders/{id} mengembalikan status pesanan
+func handleGetOrder(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, "ID pesanan harus berupa angka")
+		return
+	}
+
+	ordersMutex.Lock()
+	order := findOrder(id)
+	var result Order
+	if order != nil {
+		result = copyOrder(*order)
+	}
+	ordersMutex.Unlock()
+
+	if order == nil {
+		writeAPIError(w, http.StatusNotFound, "pesanan tidak ditemukan")
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// GET /reports/summary mengembalikan ringkasan periode berjalan sejak penutupan hari terakhir
+func handleSummaryReport(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, summarizePeriod(currentPeriodStart(), time.Now()))
+}
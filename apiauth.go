@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Kunci context untuk nama klien API yang sudah terautentikasi
+type apiClientKey struct{}
+
+// Kebijakan pesanan yang dibuat klien API
+type APIOrderPolicy string
+
+const (
+	PolicyAuto    APIOrderPolicy = "auto"
+	PolicyConfirm APIOrderPolicy = "confirm"
+)
+
+// Token bucket sederhana untuk membatasi jumlah permintaan per klien
+type rateBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+var rateBuckets = map[string]*rateBucket{}
+var rateMutex sync.Mutex
+
+// Fungsi untuk mengambil kunci API dari header X-API-Key atau Authorization: Bearer.
+// EventSource di browser tidak bisa mengirim header, jadi parameter api_key juga diterima.
+func apiKeyFromRequest(r *http.Request) string {
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return key
+	}
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return strings.TrimSpace(token)
+	}
+	return r.URL.Query().Get("api_key")
+}
+
+// Fungsi untuk mencari nama klien pemilik kunci API
+func lookupAPIClient(key string) (string, bool) {
+	if key == "" {
+		return "", false
+	}
+	found := ""
+	for client, clientKey := range currentConfig().APIKeys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(clientKey)) == 1 {
+			found = client
+		}
+	}
+	return found, found != ""
+}
+
+// Fungsi untuk mengambil kebijakan pesanan klien, klien yang tidak terdaftar memakai "*"
+func apiOrderPolicy(client string) APIOrderPolicy {
+	policies := currentConfig().APIOrderPolicy
+	if policy, ok := policies[client]; ok {
+		return policy
+	}
+	if policy, ok := policies["*"]; ok {
+		return policy
+	}
+	return PolicyAuto
+}
+
+// Fungsi untuk mengambil satu token dari bucket klien, false jika batas permintaan terlampaui
+func allowRequest(client string, now time.Time) bool {
+	perMinute := float64(currentConfig().APIRateLimit)
+	if perMinute <= 0 {
+		return true
+	}
+
+	rateMutex.Lock()
+	defer rateMutex.Unlock()
+
+	bucket, ok := rateBuckets[client]
+	if !ok {
+		bucket = &rateBucket{tokens: perMinute, last: now}
+		rateBuckets[client] = bucket
+	}
+	bucket.tokens = min(perMinute, bucket.tokens+now.Sub(bucket.last).Minutes()*perMinute)
+	bucket.last = now
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+// Middleware yang menolak permintaan tanpa kunci API valid dan membatasi laju per klien
+func requireAPIKey(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client, ok := lookupAPIClient(apiKeyFromRequest(r))
+		if !ok {
+			writeAPIError(w, http.StatusUnauthorized, "kunci API tidak valid")
+			return
+		}
+		if !allowRequest(client, time.Now()) {
+			w.Header().Set("Retry-After", "60")
+			writeAPIError(w, http.StatusTooManyRequests, fmt.Sprintf("batas %d permintaan per menit terlampaui", currentConfig().APIRateLimit))
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), apiClientKey{}, client)))
+	})
+}
+
+// Fungsi untuk mengambil nama klien API dari permintaan yang sudah diautentikasi
+func apiClientName(r *http.Request) string {
+	client, _ := r.Context().Value(apiClientKey{}).(string)
+	return client
+}
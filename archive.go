@@ -0,0 +1,242 @@
+package main
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Folder arsip di dalam folder data, berisi satu file gzip JSON per bulan
+const archiveDir = "archive"
+
+// Struct untuk indeks arsip. ID pesanan terakhir disimpan agar ID tidak dipakai ulang
+// setelah semua pesanan terbaru ikut diarsipkan.
+type archiveIndex struct {
+	LastOrderID int `json:"last_order_id"`
+}
+
+// Fungsi untuk membuat path file arsip bulan tertentu, misalnya archive/orders-2024-05.json.gz
+func archivePath(month string) string {
+	return filepath.Join(currentConfig().DataDir, archiveDir, "orders-"+month+".json.gz")
+}
+
+// Fungsi untuk memeriksa apakah pesanan sudah selesai sehingga boleh diarsipkan
+func orderClosed(order *Order) bool {
+	if order.Voided || order.MergedInto != 0 {
+		return true
+	}
+	if !order.Paid {
+		return false
+	}
+	for _, line := range order.Lines {
+		if line.Status != LineDone && line.Status != LineCancelled {
+			return false
+		}
+	}
+	return true
+}
+
+// Fungsi untuk memakai ID pesanan terakhir dari indeks arsip, dipanggil setelah data dimuat
+func restoreArchivedOrderID() {
+	var index archiveIndex
+	if _, err := readJSONFile(filepath.Join(currentConfig().DataDir, archiveDir, "index.json"), &index); err != nil {
+		fmt.Println("Gagal membaca indeks arsip:", err)
+		return
+	}
+
+	ordersMutex.Lock()
+	lastOrderID = max(lastOrderID, index.LastOrderID)
+	ordersMutex.Unlock()
+}
+
+// Fungsi untuk memindahkan pesanan selesai yang lebih tua dari order_retention_days
+// ke file arsip bulanan lalu menghapusnya dari penyimpanan utama
+func archiveOldOrders(now time.Time) (int, error) {
+	days := currentConfig().OrderRetentionDays
+	if days == 0 {
+		return 0, nil
+	}
+	cutoff := now.AddDate(0, 0, -days)
+
+	saveMutex.Lock()
+	defer saveMutex.Unlock()
+
+	byMonth := map[string][]Order{}
+	var ids []int
+	ordersMutex.Lock()
+	last := lastOrderID
+	for _, order := range orders {
+		if order.CreatedAt.IsZero() || !order.CreatedAt.Before(cutoff) || !orderClosed(order) {
+			continue
+		}
+		month := order.CreatedAt.Format("2006-01")
+		byMonth[month] = append(byMonth[month], copyOrder(*order))
+		ids = append(ids, order.ID)
+	}
+	ordersMutex.Unlock()
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	if err := os.MkdirAll(filepath.Join(currentConfig().DataDir, archiveDir), 0755); err != nil {
+		return 0, err
+	}
+	// Arsip dan indeks ditulis lebih dulu, pesanan baru dihapus setelah semuanya tersimpan
+	for month, archived := range byMonth {
+		existing, err := readArchiveFile(archivePath(month))
+		if err != nil {
+			return 0, err
+		}
+		if err := writeArchiveFile(archivePath(month), append(existing, archived...)); err != nil {
+			return 0, err
+		}
+	}
+	if err := writeJSONFile(filepath.Join(currentConfig().DataDir, archiveDir, "index.json"), archiveIndex{LastOrderID: last}); err != nil {
+		return 0, err
+	}
+	if err := orderRepo.DeleteOrders(ids); err != nil {
+		return 0, err
+	}
+
+	archived := map[int]bool{}
+	for _, id := range ids {
+		archived[id] = true
+	}
+	ordersMutex.Lock()
+	remaining := orders[:0]
+	for _, order := range orders {
+		if !archived[order.ID] {
+			remaining = append(remaining, order)
+		}
+	}
+	orders = remaining
+	ordersMutex.Unlock()
+	return len(ids), nil
+}
+
+// Fungsi untuk membaca satu file arsip, file yang belum ada berarti arsip kosong
+func readArchiveFile(path string) ([]Order, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	defer reader.Close()
+
+	var result []Order
+	if err := json.NewDecoder(reader).Decode(&result); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return result, nil
+}
+
+// Fungsi untuk menulis file arsip lewat file sementara yang di-fsync agar arsip lama tidak rusak jika gagal
+func writeArchiveFile(path string, archived []Order) error {
+	temp := path + ".tmp"
+	file, err := os.Create(temp)
+	if err != nil {
+		return err
+	}
+
+	writer := gzip.NewWriter(file)
+	err = json.NewEncoder(writer).Encode(archived)
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+	if syncErr := file.Sync(); err == nil {
+		err = syncErr
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(temp)
+		return err
+	}
+	if err := os.Rename(temp, path); err != nil {
+		return err
+	}
+	return fsyncDir(filepath.Dir(path))
+}
+
+// Fungsi untuk membaca pesanan arsip yang dibuat dalam rentang [from, to), waktu nol berarti tanpa batas
+func readArchivedOrders(from, to time.Time) ([]Order, error) {
+	return readArchivedOrdersIn(currentConfig().DataDir, from, to)
+}
+
+// Fungsi untuk membaca pesanan arsip di folder data tertentu, misalnya folder data cabang lain
+func readArchivedOrdersIn(dataDir string, from, to time.Time) ([]Order, error) {
+	paths, err := filepath.Glob(filepath.Join(dataDir, archiveDir, "orders-*.json.gz"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	var result []Order
+	for _, path := range paths {
+		month, err := time.ParseInLocation("2006-01", strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), "orders-"), ".json.gz"), time.Local)
+		if err != nil {
+			continue
+		}
+		// Bulan yang seluruhnya di luar rentang tidak perlu dibuka
+		if (!to.IsZero() && !month.Before(to)) || (!from.IsZero() && !month.AddDate(0, 1, 0).After(from)) {
+			continue
+		}
+
+		archived, err := readArchiveFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, order := range archived {
+			if !from.IsZero() && order.CreatedAt.Before(from) {
+				continue
+			}
+			if !to.IsZero() && !order.CreatedAt.Before(to) {
+				continue
+			}
+			result = append(result, order)
+		}
+	}
+	return result, nil
+}
+
+// Fungsi untuk mengambil salinan pesanan dalam rentang tanggal untuk laporan,
+// pesanan arsip hanya ikut jika diminta
+func ordersForReport(includeArchive bool, from, to time.Time) ([]Order, error) {
+	var result []Order
+	ordersMutex.Lock()
+	for _, order := range orders {
+		if !from.IsZero() && order.CreatedAt.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !order.CreatedAt.Before(to) {
+			continue
+		}
+		result = append(result, copyOrder(*order))
+	}
+	ordersMutex.Unlock()
+
+	if !includeArchive {
+		return result, nil
+	}
+	archived, err := readArchivedOrders(from, to)
+	if err != nil {
+		return result, err
+	}
+	return append(archived, result...), nil
+}
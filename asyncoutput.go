@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Mode keluaran pemrosesan dapur di processing_output
+const (
+	// Pesan proses ditampilkan di layar dan tidak dicatat terpisah
+	OutputScreen = "screen"
+	// Pesan proses hanya dicatat di log aktivitas, layar kasir tetap bersih
+	OutputLogOnly = "log"
+)
+
+// Jumlah pesan yang boleh menunggu ditulis. Jika penuh, pesan proses berikutnya
+// dilewati agar goroutine dapur tidak pernah menunggu terminal.
+const asyncOutputBuffer = 256
+
+// Pesan dari goroutine latar belakang ditulis satu per satu oleh satu goroutine
+// agar karakter dari beberapa pesanan tidak bercampur di layar
+var asyncOutput = make(chan asyncMessage, asyncOutputBuffer)
+var asyncOutputStart sync.Once
+
+// Jumlah pesan yang dilewati karena antrean penuh sejak terakhir dilaporkan
+var asyncDropped atomic.Int64
+
+// Struct untuk satu pesan latar belakang. Flush diisi saat pemanggil menunggu semua
+// pesan sebelumnya selesai ditulis.
+type asyncMessage struct {
+	text  string
+	flush chan struct{}
+}
+
+// Fungsi untuk menulis pesan latar belakang ke layar tanpa menunggu terminal, misalnya
+// peringatan hook yang gagal. Pesan tetap ditampilkan dalam mode log.
+func asyncPrintf(format string, args ...any) {
+	sendAsync(fmt.Sprintf(format, args...))
+}
+
+// Fungsi untuk menulis pesan pemrosesan pesanan, misalnya "Pesanan ID 3 telah diproses".
+// Dengan processing_output "log" pesan hanya dicatat di log aktivitas.
+func processingPrintf(format string, args ...any) {
+	text := fmt.Sprintf(format, args...)
+	if currentConfig().ProcessingOutput == OutputLogOnly {
+		logActivity("proses: " + strings.TrimSpace(text))
+		return
+	}
+	sendAsync(text)
+}
+
+// Fungsi untuk memasukkan pesan ke antrean tanpa menunggu. Pesan yang tidak muat
+// dihitung lalu dicatat di log aktivitas agar tidak hilang sama sekali.
+func sendAsync(text string) {
+	asyncOutputStart.Do(func() { go writeAsyncOutput() })
+	select {
+	case asyncOutput <- asyncMessage{text: text}:
+	default:
+		asyncDropped.Add(1)
+		logActivity("keluaran dilewati: " + strings.TrimSpace(text))
+	}
+}
+
+// Fungsi untuk menulis pesan dari antrean ke layar satu per satu
+func writeAsyncOutput() {
+	for message := range asyncOutput {
+		if message.flush != nil {
+			close(message.flush)
+			continue
+		}
+		if dropped := asyncDropped.Swap(0); dropped > 0 {
+			fmt.Fprintf(os.Stdout, "(%d pesan proses dilewati, lihat log aktivitas)\n", dropped)
+		}
+		os.Stdout.WriteString(message.text)
+	}
+}
+
+// Fungsi untuk menunggu semua pesan yang sudah diantre selesai ditulis, dipanggil
+// sebelum program selesai agar pesan terakhir tidak hilang
+func flushAsyncOutput() {
+	asyncOutputStart.Do(func() { go writeAsyncOutput() })
+	done := make(chan struct{})
+	asyncOutput <- asyncMessage{flush: done}
+	<-done
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"math/rand"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Anggaran kinerja per operasi. Targetnya jam sibuk restoran besar: 100.000 pesanan
+// historis tersimpan, kasir dan kiosk membuat pesanan bersamaan, dan laporan tetap bisa
+// dibuka tanpa menahan dapur. Setiap perubahan desain penyimpanan atau penguncian harus
+// tetap di bawah angka ini. TestPerformanceBudgets gagal jika ada yang terlewati, tetapi
+// hanya dijalankan dengan PERF_BUDGETS=1 di mesin yang tenang: PERF_BUDGETS=1 go test -run Budgets
+var benchBudgets = []struct {
+	Name   string
+	Budget time.Duration
+	Note   string
+	Run    func(b *testing.B)
+}{
+	{"buat pesanan", 200 * time.Microsecond, "minimal 5.000 pesanan/detik termasuk nomor ambil dan pesanan stok", BenchmarkCreateOrder},
+	{"reservasi stok paralel", 20 * time.Microsecond, "8 kasir/kiosk memesan item yang sama bersamaan", BenchmarkReserveStock},
+	{"ringkasan periode", 100 * time.Millisecond, "laporan harian dan tutup hari atas 100.000 pesanan", BenchmarkSummarizePeriod},
+	{"laporan per item", 500 * time.Millisecond, "laporan kustom per item atas 100.000 pesanan", BenchmarkCustomReport},
+	{"simpan state", 500 * time.Millisecond, "menyimpan seluruh pesanan setelah setiap perintah", BenchmarkSaveState},
+}
+
+// Variabel lingkungan yang mengaktifkan TestPerformanceBudgets. Waktu per operasi
+// bergantung pada mesin dan beban lain, jadi tidak diperiksa pada go test biasa.
+const perfBudgetsEnv = "PERF_BUDGETS"
+
+// Jumlah pesanan historis yang dibuat untuk benchmark
+const benchOrderCount = 100000
+
+// Data benchmark hanya disiapkan sekali untuk semua benchmark dalam satu proses test.
+// Folder datanya dihapus TestMain setelah semua test selesai.
+var (
+	benchSetup   sync.Once
+	benchDataDir string
+)
+
+// Fungsi untuk menyiapkan data sintetis di penyimpanan memory dengan folder data
+// sementara, tanpa menyentuh data asli dan tanpa webhook, sinkronisasi, email atau notifikasi
+func setupBench(tb testing.TB) {
+	benchSetup.Do(func() {
+		dir, err := os.MkdirTemp("", "bench-restoran-")
+		if err != nil {
+			tb.Fatalf("Gagal membuat folder sementara: %v", err)
+		}
+		benchDataDir = dir
+
+		cfg := defaultConfig()
+		cfg.Storage = StorageMemory
+		cfg.DataDir = dir
+		cfg.Hooks = nil
+		cfg.Sync = SyncConfig{}
+		cfg.SMTP = SMTPConfig{}
+		cfg.Notify = NotifyConfig{}
+		configMutex.Lock()
+		config = cfg
+		configMutex.Unlock()
+		if err := openStorage(currentConfig()); err != nil {
+			tb.Fatalf("Gagal membuka penyimpanan: %v", err)
+		}
+		seedBenchData()
+	})
+}
+
+// Fungsi untuk mengisi menu dan pesanan historis sintetis. Pesanan tersebar di 90 hari
+// terakhir dengan 1-4 baris per pesanan, sebagian dibayar dan sebagian dibatalkan.
+func seedBenchData() {
+	random := rand.New(rand.NewSource(1))
+	stations := []Station{StationGrill, StationWok, StationBar}
+
+	var items []MenuItem
+	for i := 0; i < 60; i++ {
+		items = append(items, MenuItem{
+			Name:     "Item " + strconv.Itoa(i+1),
+			Price:    Money(10+random.Intn(40)) * 1000 * moneyScale,
+			Quantity: 1 << 30,
+			Station:  stations[i%len(stations)],
+		})
+	}
+	menuMutex.Lock()
+	menu = items
+	menuMutex.Unlock()
+
+	now := time.Now()
+	seeded := make([]*Order, 0, benchOrderCount)
+	for id := 1; id <= benchOrderCount; id++ {
+		created := now.Add(-time.Duration(random.Int63n(int64(90 * 24 * time.Hour))))
+		order := &Order{ID: id, Table: random.Intn(20), CreatedAt: created, AcknowledgedAt: created, Cashier: "kasir"}
+		for n := 0; n < 1+random.Intn(4); n++ {
+			item := items[random.Intn(len(items))]
+			quantity := 1 + random.Intn(3)
+			order.Lines = append(order.Lines, OrderLine{
+				No: n + 1, ItemName: item.Name, Quantity: quantity, Price: item.Price,
+				TotalPrice: item.Price.Times(quantity), Status: LineDone, Station: item.Station,
+			})
+			order.TotalPrice += item.Price.Times(quantity)
+		}
+		switch random.Intn(20) {
+		case 0:
+			order.Voided = true
+		default:
+			order.Paid = true
+			order.PaidAt = created.Add(30 * time.Minute)
+			order.PaymentMethod = []PaymentMethod{PaymentCash, PaymentCard, PaymentQRIS}[random.Intn(3)]
+		}
+		seeded = append(seeded, order)
+	}
+
+	ordersMutex.Lock()
+	orders = seeded
+	lastOrderID = benchOrderCount
+	ordersMutex.Unlock()
+}
+
+// Fungsi untuk membuang pesanan yang dibuat benchmark agar setiap putaran mulai dari
+// jumlah pesanan historis yang sama
+func resetBenchOrders() {
+	ordersMutex.Lock()
+	orders = orders[:benchOrderCount]
+	lastOrderID = benchOrderCount
+	ordersMutex.Unlock()
+
+	ledgerMutex.Lock()
+	ledger = nil
+	ledgerMutex.Unlock()
+}
+
+// Membuat pesanan dua item lewat jalur pesanan cepat sampai tercatat, tanpa dapur
+func BenchmarkCreateOrder(b *testing.B) {
+	setupBench(b)
+	parsed, err := parseQuickOrder("2x item 1, item 2 meja 4")
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(resetBenchOrders)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		id, _ := newOrderID()
+		order, skipped := placeQuickOrder(id, parsed)
+		if order == nil {
+			b.Fatal(skipped)
+		}
+		recordOrder(order)
+	}
+}
+
+// Memesan stok item yang sama dari banyak goroutine sekaligus untuk mengukur antrean menuMutex
+func BenchmarkReserveStock(b *testing.B) {
+	setupBench(b)
+	b.SetParallelism(8)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := reserveQuickLine(quickItem{Name: "Item 3", Quantity: 1}, OrderDineIn, ""); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
+
+// Menyusun ringkasan seluruh periode seperti laporan harian dan tutup hari
+func BenchmarkSummarizePeriod(b *testing.B) {
+	setupBench(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		summarizePeriod(time.Time{}, time.Now())
+	}
+}
+
+// Menyusun laporan kustom per item atas semua pesanan
+func BenchmarkCustomReport(b *testing.B) {
+	setupBench(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := runCustomReport(customReportQuery{GroupBy: "item"}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// Menyimpan menu dan seluruh pesanan seperti setelah setiap perintah kasir
+func BenchmarkSaveState(b *testing.B) {
+	setupBench(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		saveState()
+	}
+}
+
+func TestPerformanceBudgets(t *testing.T) {
+	if os.Getenv(perfBudgetsEnv) != "1" {
+		t.Skip("anggaran kinerja hanya diperiksa dengan " + perfBudgetsEnv + "=1")
+	}
+	if raceEnabled {
+		t.Skip("anggaran kinerja tidak berlaku dengan detektor race")
+	}
+	setupBench(t)
+	for _, bench := range benchBudgets {
+		result := testing.Benchmark(bench.Run)
+		if result.N == 0 {
+			t.Errorf("%s: benchmark gagal dijalankan", bench.Name)
+			continue
+		}
+		perOp := time.Duration(result.NsPerOp())
+		t.Logf("%-24s %14s / anggaran %s, %d B/op, %d alokasi/op", bench.Name, perOp, bench.Budget, result.AllocedBytesPerOp(), result.AllocsPerOp())
+		if perOp > bench.Budget {
+			t.Errorf("%s: %s/op melewati anggaran %s (%s)", bench.Name, perOp, bench.Budget, bench.Note)
+		}
+	}
+}
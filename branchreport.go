@@ -0,0 +1,216 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Contoh perintah laporan antar cabang yang ditampilkan bersama pesan kesalahan
+const branchReportExample = "contoh: report branches dari=2024-05-01 sampai=2024-05-31 arsip"
+
+// Struct untuk ringkasan penjualan satu cabang
+type branchSales struct {
+	Branch  string
+	Orders  int
+	Voids   int
+	Revenue Money
+	// Kesalahan saat membaca data cabang, cabang tetap ditampilkan agar tidak terlewat
+	Err error
+}
+
+// Fungsi untuk menghitung rata-rata transaksi cabang
+func (sales branchSales) Average() Money {
+	if sales.Orders == 0 {
+		return 0
+	}
+	return sales.Revenue.Div(sales.Orders)
+}
+
+// Fungsi untuk membaca rentang tanggal laporan antar cabang dari teks kunci=nilai
+func parseBranchReportQuery(input string) (from, to time.Time, includeArchive bool, err error) {
+	for _, field := range strings.Fields(input) {
+		key, value, _ := strings.Cut(field, "=")
+		switch strings.ToLower(key) {
+		case "dari", "sampai":
+			date, parseErr := time.ParseInLocation("2006-01-02", value, time.Local)
+			if parseErr != nil {
+				return from, to, false, fmt.Errorf("tanggal %q harus berformat YYYY-MM-DD", value)
+			}
+			if strings.EqualFold(key, "dari") {
+				from = date
+			} else {
+				to = date.AddDate(0, 0, 1)
+			}
+		case "arsip":
+			includeArchive = true
+		default:
+			return from, to, false, fmt.Errorf("filter %q tidak dikenal", key)
+		}
+	}
+	return from, to, includeArchive, nil
+}
+
+// Fungsi untuk membaca pesanan cabang lain dari folder datanya. Jenis penyimpanan
+// ditebak dari file yang ada: restoran.db, events.jsonl atau orders.json.
+func loadBranchDirOrders(dir string) ([]Order, error) {
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(dir, name))
+		return err == nil
+	}
+
+	switch {
+	case exists("restoran.db"):
+		store, err := openSQLStore("sqlite", filepath.Join(dir, "restoran.db"), false)
+		if err != nil {
+			return nil, err
+		}
+		defer store.db.Close()
+		return store.LoadOrders()
+	case exists("events.jsonl"):
+		store, err := openEventStore(filepath.Join(dir, "events.jsonl"))
+		if err != nil {
+			return nil, err
+		}
+		return store.LoadOrders()
+	case exists("orders.json"):
+		return (&jsonStore{dir: dir}).LoadOrders()
+	}
+	return nil, fmt.Errorf("tidak ada data pesanan di %s", dir)
+}
+
+// Fungsi untuk membaca pesanan cabang lain dari database yang diatur di branch_databases
+func loadBranchDatabaseOrders(url string) ([]Order, error) {
+	store, err := openSQLStore("postgres", url, true)
+	if err != nil {
+		return nil, err
+	}
+	defer store.db.Close()
+	return store.LoadOrders()
+}
+
+// Fungsi untuk menjumlahkan pesanan dalam rentang [from, to) menjadi ringkasan cabang.
+// Pesanan yang digabung sudah dihitung di pesanan tujuannya dan pesanan batal dihitung
+// terpisah agar tidak menurunkan rata-rata transaksi.
+func summarizeBranchSales(branch string, branchOrders []Order, from, to time.Time) branchSales {
+	sales := branchSales{Branch: branch}
+	for i := range branchOrders {
+		order := &branchOrders[i]
+		if (!from.IsZero() && order.CreatedAt.Before(from)) || (!to.IsZero() && !order.CreatedAt.Before(to)) {
+			continue
+		}
+		if order.MergedInto != 0 {
+			continue
+		}
+		if order.Voided {
+			sales.Voids++
+			continue
+		}
+		sales.Orders++
+		sales.Revenue += orderRevenue(order)
+	}
+	return sales
+}
+
+// Fungsi untuk membaca penjualan satu cabang lain, dari database jika diatur di
+// branch_databases dan dari folder data di branches jika tidak
+func loadBranchSales(cfg Config, branch string, from, to time.Time, includeArchive bool) branchSales {
+	var branchOrders []Order
+	var err error
+	if url, ok := cfg.BranchDatabases[branch]; ok {
+		branchOrders, err = loadBranchDatabaseOrders(url)
+	} else {
+		dir := cfg.Branches[branch]
+		branchOrders, err = loadBranchDirOrders(dir)
+		if err == nil && includeArchive {
+			var archived []Order
+			archived, err = readArchivedOrdersIn(dir, from, to)
+			branchOrders = append(archived, branchOrders...)
+		}
+	}
+	if err != nil {
+		return branchSales{Branch: branch, Err: err}
+	}
+	return summarizeBranchSales(branch, branchOrders, from, to)
+}
+
+// Fungsi untuk menjalankan perintah `report branches`: pendapatan, jumlah pesanan dan
+// rata-rata transaksi cabang ini dibandingkan berdampingan dengan cabang lain di
+// branches dan branch_databases. Cabang yang gagal dibaca tetap ditampilkan dengan alasannya.
+func branchReport(args string) {
+	cfg := currentConfig()
+	if cfg.Branch == "" || (len(cfg.Branches) == 0 && len(cfg.BranchDatabases) == 0) {
+		fmt.Println("Cabang belum diatur, isi branch dan branches atau branch_databases di config.json.")
+		return
+	}
+	from, to, includeArchive, err := parseBranchReportQuery(args)
+	if err != nil {
+		fmt.Printf("Filter tidak valid: %v\n%s\n", err, branchReportExample)
+		return
+	}
+
+	// Cabang ini memakai data di memori agar pesanan yang belum tersimpan ikut terhitung
+	localOrders, err := ordersForReport(includeArchive, from, to)
+	report := []branchSales{summarizeBranchSales(cfg.Branch, localOrders, from, to)}
+	if err != nil {
+		report[0] = branchSales{Branch: cfg.Branch, Err: err}
+	}
+
+	var others []string
+	for branch := range cfg.Branches {
+		others = append(others, branch)
+	}
+	for branch := range cfg.BranchDatabases {
+		if _, ok := cfg.Branches[branch]; !ok {
+			others = append(others, branch)
+		}
+	}
+	sort.Strings(others)
+	for _, branch := range others {
+		report = append(report, loadBranchSales(cfg, branch, from, to, includeArchive))
+	}
+
+	period := "semua tanggal"
+	switch {
+	case !from.IsZero() && !to.IsZero():
+		period = formatDate(from) + " - " + formatDate(to.AddDate(0, 0, -1))
+	case !from.IsZero():
+		period = "sejak " + formatDate(from)
+	case !to.IsZero():
+		period = "sampai " + formatDate(to.AddDate(0, 0, -1))
+	}
+	fmt.Printf("\n===== Perbandingan Cabang (%s) =====\n", period)
+	fmt.Printf("%-15s | %8s | %20s | %18s | %6s | %5s\n", "Cabang", "Pesanan", "Pendapatan", "Rata-rata", "Porsi", "Batal")
+
+	var total branchSales
+	var failed []error
+	for _, sales := range report {
+		if sales.Err == nil {
+			total.Orders += sales.Orders
+			total.Voids += sales.Voids
+			total.Revenue += sales.Revenue
+		}
+	}
+	for _, sales := range report {
+		if sales.Err != nil {
+			fmt.Printf("%-15s | gagal dibaca\n", sales.Branch)
+			failed = append(failed, fmt.Errorf("%s: %w", sales.Branch, sales.Err))
+			continue
+		}
+		share := 0.0
+		if total.Revenue > 0 {
+			share = float64(sales.Revenue) / float64(total.Revenue) * 100
+		}
+		fmt.Printf("%-15s | %8d | %20s | %18s | %6s | %5d\n", sales.Branch, sales.Orders, formatMoney(sales.Revenue), formatMoney(sales.Average()), formatNumber(share, 1)+"%", sales.Voids)
+	}
+	total.Branch = "TOTAL"
+	fmt.Printf("%-15s | %8d | %20s | %18s | %6s | %5d\n", total.Branch, total.Orders, formatMoney(total.Revenue), formatMoney(total.Average()), "", total.Voids)
+	if len(failed) > 0 {
+		fmt.Println("Cabang yang gagal dibaca tidak ikut dijumlahkan:")
+		fmt.Println(errors.Join(failed...))
+	}
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Jenis catatan kas laci
+type CashKind string
+
+const (
+	CashFloat CashKind = "float"
+	CashIn    CashKind = "cash_in"
+	CashOut   CashKind = "cash_out"
+)
+
+// Jenis catatan kas berbahasa Indonesia dari data lama
+var legacyCashKinds = map[CashKind]CashKind{
+	"modal awal": CashFloat,
+	"kas masuk":  CashIn,
+	"kas keluar": CashOut,
+}
+
+// Fungsi untuk menampilkan jenis catatan kas di layar
+func (k CashKind) Label() string {
+	for legacy, kind := range legacyCashKinds {
+		if kind == k {
+			return string(legacy)
+		}
+	}
+	return string(k)
+}
+
+// Fungsi untuk membaca jenis catatan kas dari JSON, nilai lama ikut diterjemahkan
+func (k *CashKind) UnmarshalJSON(data []byte) error {
+	return unmarshalLegacy(data, k, legacyCashKinds)
+}
+
+// Struct untuk satu catatan kas di laci kasir
+type CashEntry struct {
+	Time    time.Time `json:"time"`
+	Kind    CashKind  `json:"kind"`
+	Amount  Money     `json:"amount"`
+	Reason  string    `json:"reason"`
+	Cashier string    `json:"cashier"`
+}
+
+// Nama file catatan kas laci di folder data, agar modal awal tetap ada jika program
+// dijalankan ulang di tengah shift
+const cashFile = "cash.json"
+
+// Catatan kas laci, dibaca dari file saat pertama kali dipakai
+var cashEntries []CashEntry
+var cashLoaded bool
+var cashMutex sync.Mutex
+
+// Fungsi untuk membaca catatan kas dari file jika belum dibaca. Pada storage memory
+// catatan kas hanya ada selama program berjalan. Pemanggil harus memegang cashMutex.
+func ensureCashLoaded() {
+	if cashLoaded {
+		return
+	}
+	cashLoaded = true
+	if currentConfig().Storage == StorageMemory {
+		return
+	}
+	if _, err := readJSONFile(filepath.Join(currentConfig().DataDir, cashFile), &cashEntries); err != nil {
+		fmt.Println("Gagal membaca catatan kas:", err)
+	}
+}
+
+// Fungsi untuk menambah catatan kas lalu menyimpannya ke file, pemanggil harus memegang cashMutex
+func appendCashEntry(entry CashEntry) {
+	ensureCashLoaded()
+	cashEntries = append(cashEntries, entry)
+	if currentConfig().Storage == StorageMemory {
+		return
+	}
+	if err := writeJSONFile(filepath.Join(currentConfig().DataDir, cashFile), cashEntries); err != nil {
+		fmt.Println("Gagal menyimpan catatan kas:", err)
+	}
+}
+
+// Ringkasan uang di laci untuk satu periode
+type CashSummary struct {
+	Float     Money
+	CashSales Money
+	Refunds   Money
+	CashIn    Money
+	CashOut   Money
+}
+
+// Fungsi untuk menghitung uang yang seharusnya ada di laci
+func (summary CashSummary) Expected() Money {
+	return summary.Float + summary.CashSales - summary.Refunds + summary.CashIn - summary.CashOut
+}
+
+// Fungsi untuk menampilkan submenu modal awal dan petty cash
+func cashMenu(reader *bufio.Reader) {
+	fmt.Println("\n===== Kas & Petty Cash =====")
+	fmt.Println("1. Catat Modal Awal")
+	fmt.Println("2. Kas Masuk")
+	fmt.Println("3. Kas Keluar (Petty Cash)")
+	fmt.Println("4. Posisi Kas")
+	fmt.Print("Pilih opsi: ")
+
+	choice, _ := reader.ReadString('\n')
+	switch strings.TrimSpace(choice) {
+	case "1":
+		recordCash(reader, CashFloat)
+	case "2":
+		recordCash(reader, CashIn)
+	case "3":
+		recordCash(reader, CashOut)
+	case "4":
+		from := currentPeriodStart()
+		displayCashSummary(summarizeCash(from, time.Now()), from)
+	default:
+		fmt.Println("Opsi tidak valid.")
+	}
+}
+
+// Fungsi untuk mencatat modal awal, kas masuk atau kas keluar beserta alasannya
+func recordCash(reader *bufio.Reader, kind CashKind) {
+	fmt.Print("Jumlah: ")
+	amountInput, _ := reader.ReadString('\n')
+	amount, err := parseMoneyInput(strings.TrimSpace(amountInput))
+	if err != nil || amount <= 0 {
+		fmt.Println("Jumlah harus berupa angka positif.")
+		return
+	}
+
+	reason := ""
+	if kind != CashFloat {
+		fmt.Print("Alasan: ")
+		reason, _ = reader.ReadString('\n')
+		reason = strings.TrimSpace(reason)
+		if reason == "" {
+			fmt.Println("Alasan wajib diisi.")
+			return
+		}
+	}
+
+	activityMutex.Lock()
+	cashier := currentCashier
+	activityMutex.Unlock()
+
+	cashMutex.Lock()
+	appendCashEntry(CashEntry{Time: time.Now(), Kind: kind, Amount: amount, Reason: reason, Cashier: cashier})
+	cashMutex.Unlock()
+
+	fmt.Printf("Dicatat: %s %s.\n", kind.Label(), formatMoney(amount))
+}
+
+// Fungsi untuk merangkum uang di laci: modal awal, penjualan tunai, refund dan petty cash
+func summarizeCash(from, to time.Time) CashSummary {
+	var summary CashSummary
+	inPeriod := func(t time.Time) bool {
+		return !t.Before(from) && t.Before(to)
+	}
+
+	cashMutex.Lock()
+	ensureCashLoaded()
+	for _, entry := range cashEntries {
+		if !inPeriod(entry.Time) {
+			continue
+		}
+		switch entry.Kind {
+		case CashFloat:
+			summary.Float += entry.Amount
+		case CashIn:
+			summary.CashIn += entry.Amount
+		case CashOut:
+			summary.CashOut += entry.Amount
+		}
+	}
+	cashMutex.Unlock()
+
+	ordersMutex.Lock()
+	for _, order := range orders {
+		if order.Voided {
+			continue
+		}
+		// Dihitung per pembayaran karena tab dan split bill bisa dibayar sebagian dengan metode berbeda
+		for _, payment := range order.PaymentEntries() {
+			if payment.Method == PaymentCash && inPeriod(payment.Time) {
+				summary.CashSales += payment.Amount
+			}
+		}
+	}
+	ordersMutex.Unlock()
+
+	returnsMutex.Lock()
+	ensureReturnsLoaded()
+	for _, record := range returns {
+		if record.Refunded && inPeriod(record.Time) {
+			summary.Refunds += record.Amount
+		}
+	}
+	returnsMutex.Unlock()
+
+	return summary
+}
+
+// Fungsi untuk menampilkan posisi kas laci beserta daftar petty cash sejak from
+func displayCashSummary(summary CashSummary, from time.Time) {
+	l := newReceiptLayout()
+	writeCashSummary(l, summary, from)
+	fmt.Print(l.String())
+}
+
+// Fungsi untuk menulis posisi kas laci ke penyusun teks
+func writeCashSummary(l *textLayout, summary CashSummary, from time.Time) {
+	l.Blank()
+	l.Title("Posisi Kas")
+	l.Pair("Modal Awal", formatMoney(summary.Float))
+	l.Pair("Penjualan Tunai", formatMoney(summary.CashSales))
+	l.Pair("Refund", "-"+formatMoney(summary.Refunds))
+	l.Pair("Kas Masuk", formatMoney(summary.CashIn))
+	l.Pair("Kas Keluar", "-"+formatMoney(summary.CashOut))
+	l.Pair("Seharusnya di Laci", formatMoney(summary.Expected()))
+
+	cashMutex.Lock()
+	defer cashMutex.Unlock()
+	ensureCashLoaded()
+	for _, entry := range cashEntries {
+		if entry.Kind == CashOut && !entry.Time.Before(from) {
+			l.Line("  %s | %s | %s | %s", entry.Time.Format("15:04"), formatMoney(entry.Amount), entry.Reason, entry.Cashier)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Fungsi untuk membuat pesanan tunai yang sudah dibayar pada waktu tertentu
+func paidCashOrder(id int, amount Money, paidAt time.Time) *Order {
+	return &Order{
+		ID:             id,
+		Lines:          []OrderLine{{No: 1, ItemName: "Es Teh", Quantity: 1, Price: amount, TotalPrice: amount, Status: LineDone}},
+		TotalPrice:     amount,
+		CreatedAt:      paidAt.Add(-10 * time.Minute),
+		AcknowledgedAt: paidAt.Add(-10 * time.Minute),
+		Paid:           true,
+		PaidAt:         paidAt,
+		PaymentMethod:  PaymentCash,
+	}
+}
+
+func TestCashSummarySurvivesRestart(t *testing.T) {
+	useJSONStorage(t)
+	before := time.Now().Add(-2 * time.Hour)
+	useOrders(t, nil, []*Order{paidCashOrder(1, 40000*moneyScale, before.Add(time.Hour))}, 0)
+	saveState()
+	cashMutex.Lock()
+	appendCashEntry(CashEntry{Time: before, Kind: CashFloat, Amount: 200000 * moneyScale})
+	appendCashEntry(CashEntry{Time: before.Add(30 * time.Minute), Kind: CashOut, Amount: 50000 * moneyScale, Reason: "es batu"})
+	cashMutex.Unlock()
+
+	closeDay(bufio.NewReader(strings.NewReader("190.000\n")))
+	closed := currentPeriodStart()
+
+	// Laci periode berikutnya hanya berisi catatan dan penjualan setelah penutupan
+	after := closed.Add(time.Second)
+	cashMutex.Lock()
+	appendCashEntry(CashEntry{Time: after, Kind: CashFloat, Amount: 100000 * moneyScale})
+	appendCashEntry(CashEntry{Time: after, Kind: CashIn, Amount: 25000 * moneyScale, Reason: "tambahan kembalian"})
+	cashMutex.Unlock()
+	ordersMutex.Lock()
+	orders = append(orders, paidCashOrder(2, 30000*moneyScale, after.Add(time.Second)))
+	ordersMutex.Unlock()
+	saveState()
+
+	reloadState(t)
+	got := summarizeCash(currentPeriodStart(), after.Add(time.Minute))
+	want := CashSummary{Float: 100000 * moneyScale, CashSales: 30000 * moneyScale, CashIn: 25000 * moneyScale}
+	if got != want {
+		t.Errorf("posisi kas setelah dijalankan ulang = %+v, ingin %+v", got, want)
+	}
+	if expected := got.Expected(); expected != 155000*moneyScale {
+		t.Errorf("uang seharusnya di laci = %s, ingin %s", formatMoney(expected), formatMoney(155000*moneyScale))
+	}
+}
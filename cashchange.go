@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Fungsi untuk menanyakan uang tunai yang diterima dan menghitung kembalian. Uang yang
+// kurang dari tagihan ditolak dan ditanyakan lagi, dikosongkan berarti uang pas.
+// Mengembalikan uang yang diterima dan false jika input berakhir.
+func readCashTendered(reader *bufio.Reader, due Money) (Money, bool) {
+	for {
+		fmt.Printf("Uang diterima (kosongkan jika uang pas %s): ", formatMoney(due))
+		input, err := reader.ReadString('\n')
+		input = strings.TrimSpace(input)
+		if input == "" && err != nil {
+			fmt.Println("\nInput berakhir, pembayaran dibatalkan.")
+			return 0, false
+		}
+		if input == "" {
+			return due, true
+		}
+
+		tendered, err := parseMoneyInput(input)
+		if err != nil || tendered < 0 {
+			fmt.Println("Jumlah uang harus berupa angka.")
+			continue
+		}
+		if tendered < due {
+			fmt.Printf("Uang kurang %s dari tagihan, pembayaran tidak bisa diterima.\n", formatMoney(due-tendered))
+			continue
+		}
+		return tendered, true
+	}
+}
+
+// Fungsi untuk menampilkan kembalian dan, jika change_denominations diatur, saran pecahan
+// uangnya
+func displayCashChange(tendered, due Money) {
+	change := tendered - due
+	fmt.Printf("Uang diterima: %s | Kembalian: %s\n", formatMoney(tendered), formatMoney(change))
+	if suggestion := describeChangeBreakdown(change, currentConfig().ChangeDenominations); suggestion != "" {
+		fmt.Println("Pecahan kembalian:", suggestion)
+	}
+}
+
+// Fungsi untuk menyusun saran pecahan kembalian dari pecahan terbesar, misalnya
+// "1 x Rp 50.000,00, 2 x Rp 2.000,00". Sisa yang lebih kecil dari pecahan terkecil
+// ditulis terpisah.
+func describeChangeBreakdown(change Money, denominations []Money) string {
+	if change <= 0 || len(denominations) == 0 {
+		return ""
+	}
+	sorted := slices.Clone(denominations)
+	slices.Sort(sorted)
+	slices.Reverse(sorted)
+
+	var parts []string
+	for _, denomination := range sorted {
+		if count := change / denomination; count > 0 {
+			parts = append(parts, fmt.Sprintf("%d x %s", count, formatMoney(denomination)))
+			change -= count * denomination
+		}
+	}
+	if change > 0 {
+		parts = append(parts, "sisa "+formatMoney(change))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Fungsi untuk menulis uang diterima dan kembalian di log aktivitas, kosong jika bukan tunai
+func describeTendered(tendered, due Money) string {
+	if tendered == 0 {
+		return ""
+	}
+	return fmt.Sprintf(": diterima %s, kembalian %s", formatMoney(tendered), formatMoney(tendered-due))
+}
+
+// Fungsi untuk menghitung kembalian pembayaran tunai pesanan, 0 jika uang diterima tidak dicatat
+func (order *Order) CashChange() Money {
+	if order.CashTendered == 0 {
+		return 0
+	}
+	return order.CashTendered - order.AmountDue()
+}
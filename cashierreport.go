@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Struct untuk ringkasan kinerja satu kasir dalam periode laporan
+type cashierPerformance struct {
+	Cashier   string
+	Orders    int
+	Revenue   Money
+	Voids     int
+	Discounts int
+	Discount  Money
+}
+
+// Fungsi untuk menampilkan kinerja tiap kasir: jumlah pesanan, pendapatan, rata-rata
+// transaksi, pembatalan dan diskon, dipakai manajer untuk penilaian kinerja
+func displayCashierReport(reader *bufio.Reader) {
+	from, ok := readOptionalDate(reader, "Dari tanggal (YYYY-MM-DD, kosongkan untuk semua): ")
+	if !ok {
+		return
+	}
+	to, ok := readOptionalDate(reader, "Sampai tanggal (YYYY-MM-DD, kosongkan untuk semua): ")
+	if !ok {
+		return
+	}
+	if !to.IsZero() {
+		to = to.AddDate(0, 0, 1)
+	}
+	fmt.Print("Sertakan pesanan arsip? (y/n): ")
+	archiveInput, _ := reader.ReadString('\n')
+
+	reportOrders, err := ordersForReport(strings.EqualFold(strings.TrimSpace(archiveInput), "y"), from, to)
+	if err != nil {
+		fmt.Println("Gagal membaca arsip pesanan:", err)
+		return
+	}
+
+	perCashier := map[string]*cashierPerformance{}
+	for i := range reportOrders {
+		order := &reportOrders[i]
+		// Pesanan yang digabung sudah dihitung di pesanan tujuannya
+		if order.MergedInto != 0 {
+			continue
+		}
+		name := order.Cashier
+		if name == "" {
+			name = "(tidak diketahui)"
+		}
+		stats := perCashier[name]
+		if stats == nil {
+			stats = &cashierPerformance{Cashier: name}
+			perCashier[name] = stats
+		}
+
+		// Pesanan batal dihitung terpisah dan tidak masuk rata-rata transaksi
+		if order.Voided {
+			stats.Voids++
+			continue
+		}
+		stats.Orders++
+		stats.Revenue += orderRevenue(order)
+		if order.Discount > 0 {
+			stats.Discounts++
+			stats.Discount += order.Discount
+		}
+	}
+
+	fmt.Println("\n===== Laporan Kinerja Kasir =====")
+	if len(perCashier) == 0 {
+		fmt.Println("Tidak ada pesanan pada periode ini.")
+		return
+	}
+
+	report := make([]*cashierPerformance, 0, len(perCashier))
+	for _, stats := range perCashier {
+		report = append(report, stats)
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].Revenue != report[j].Revenue {
+			return report[i].Revenue > report[j].Revenue
+		}
+		return report[i].Cashier < report[j].Cashier
+	})
+
+	for _, stats := range report {
+		var average Money
+		if stats.Orders > 0 {
+			average = stats.Revenue.Div(stats.Orders)
+		}
+		fmt.Printf("Kasir: %s | Pesanan: %d | Pendapatan: %s | Rata-rata: %s | Batal: %d | Diskon: %d (%s)\n",
+			stats.Cashier, stats.Orders, formatMoney(stats.Revenue), formatMoney(average),
+			stats.Voids, stats.Discounts, formatMoney(stats.Discount))
+	}
+}
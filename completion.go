@@ -0,0 +1,254 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Jenis argumen flag yang dilengkapi otomatis oleh shell
+var completionArgs = map[string]string{
+	"order":   "menu",
+	"script":  "file",
+	"openapi": "file",
+	"daemon":  "file",
+	"attach":  "file",
+}
+
+// Shell yang didukung perintah completion
+var completionShells = []string{"bash", "zsh", "fish"}
+
+// Subperintah yang bisa diketik setelah flag
+var completionCommands = []string{"menu", "completion", "fsck", "events"}
+
+// True jika "Program selesai" tidak boleh dicetak, misalnya saat keluaran dibaca shell
+var silentExit bool
+
+// Fungsi untuk menjalankan perintah `completion bash|zsh|fish`. Perintah tersembunyi
+// `completion menu` mencetak nama item menu untuk dipakai skrip completion.
+func runCompletion(args []string) {
+	silentExit = true
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "Pemakaian: completion bash|zsh|fish")
+		return
+	}
+
+	name := filepath.Base(os.Args[0])
+	switch args[0] {
+	case "bash":
+		fmt.Print(bashCompletion(name))
+	case "zsh":
+		fmt.Print(zshCompletion(name))
+	case "fish":
+		fmt.Print(fishCompletion(name))
+	case "menu":
+		for _, item := range completionMenuNames() {
+			fmt.Println(item)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "Shell %q tidak didukung, pilih bash, zsh atau fish\n", args[0])
+	}
+}
+
+// Fungsi untuk membaca nama item menu dan variannya dari penyimpanan. Kesalahan
+// diabaikan karena completion tidak boleh mengganggu shell.
+func completionMenuNames() []string {
+	_ = loadConfig(configFile)
+	var items []MenuItem
+	if err := openStorage(currentConfig()); err == nil {
+		items, _ = menuRepo.LoadMenu()
+	}
+	if items == nil {
+		items, _ = loadDefaultMenu(currentConfig().DefaultMenuFile)
+	}
+	if items == nil {
+		items, _ = loadDefaultMenu("")
+	}
+
+	var names []string
+	for _, item := range flattenMenu(items) {
+		names = append(names, item.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Struct untuk satu flag yang ditawarkan skrip completion
+type completionFlag struct {
+	Name        string
+	Description string
+	Arg         string // "" untuk flag boolean, "menu", "file" atau "value"
+}
+
+// Fungsi untuk mengumpulkan flag program beserta deskripsi singkatnya
+func completionFlags() []completionFlag {
+	var flags []completionFlag
+	flag.VisitAll(func(f *flag.Flag) {
+		description, _, _ := strings.Cut(f.Usage, ", misalnya")
+		arg := completionArgs[f.Name]
+		if boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool }); arg == "" && !(ok && boolFlag.IsBoolFlag()) {
+			arg = "value"
+		}
+		flags = append(flags, completionFlag{Name: f.Name, Description: description, Arg: arg})
+	})
+	return flags
+}
+
+// Fungsi untuk membuat nama fungsi shell yang aman dari nama program
+func completionFuncName(name string) string {
+	return "_" + regexp.MustCompile(`[^A-Za-z0-9_]`).ReplaceAllString(name, "_")
+}
+
+// Fungsi untuk membuat skrip completion bash
+func bashCompletion(name string) string {
+	funcName := completionFuncName(name)
+	var flagNames, menuFlags, fileFlags, valueFlags []string
+	for _, f := range completionFlags() {
+		flagNames = append(flagNames, "-"+f.Name)
+		pattern := "-" + f.Name + "|--" + f.Name
+		switch f.Arg {
+		case "menu":
+			menuFlags = append(menuFlags, pattern)
+		case "file":
+			fileFlags = append(fileFlags, pattern)
+		case "value":
+			valueFlags = append(valueFlags, pattern)
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Completion bash untuk %s. Pasang dengan:\n#   source <(%s completion bash)\n", name, name)
+	fmt.Fprintf(&b, "%s() {\n", funcName)
+	b.WriteString("    local cur prev i\n")
+	b.WriteString("    cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	b.WriteString("    prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n")
+	b.WriteString("    COMPREPLY=()\n")
+	b.WriteString("    case \"$prev\" in\n")
+	if len(menuFlags) > 0 {
+		fmt.Fprintf(&b, "        %s)\n", strings.Join(menuFlags, "|"))
+		b.WriteString("            local IFS=$'\\n'\n")
+		fmt.Fprintf(&b, "            COMPREPLY=($(compgen -W \"$(%s completion menu 2>/dev/null)\" -- \"$cur\"))\n", name)
+		b.WriteString("            for i in \"${!COMPREPLY[@]}\"; do\n")
+		b.WriteString("                COMPREPLY[i]=$(printf '%q' \"${COMPREPLY[i]}\")\n")
+		b.WriteString("            done\n")
+		b.WriteString("            return ;;\n")
+	}
+	if len(fileFlags) > 0 {
+		fmt.Fprintf(&b, "        %s)\n", strings.Join(fileFlags, "|"))
+		b.WriteString("            COMPREPLY=($(compgen -f -- \"$cur\"))\n")
+		b.WriteString("            return ;;\n")
+	}
+	if len(valueFlags) > 0 {
+		fmt.Fprintf(&b, "        %s)\n", strings.Join(valueFlags, "|"))
+		b.WriteString("            return ;;\n")
+	}
+	b.WriteString("        completion)\n")
+	fmt.Fprintf(&b, "            COMPREPLY=($(compgen -W \"%s\" -- \"$cur\"))\n", strings.Join(completionShells, " "))
+	b.WriteString("            return ;;\n")
+	b.WriteString("        menu)\n")
+	b.WriteString("            COMPREPLY=($(compgen -W \"diff\" -- \"$cur\"))\n")
+	b.WriteString("            return ;;\n")
+	b.WriteString("        fsck)\n")
+	b.WriteString("            COMPREPLY=($(compgen -W \"--repair\" -- \"$cur\"))\n")
+	b.WriteString("            return ;;\n")
+	b.WriteString("    esac\n")
+	b.WriteString("    for i in \"${COMP_WORDS[@]:1:COMP_CWORD-1}\"; do\n")
+	b.WriteString("        if [[ $i == diff ]]; then\n")
+	b.WriteString("            COMPREPLY=($(compgen -f -- \"$cur\"))\n")
+	b.WriteString("            return\n")
+	b.WriteString("        fi\n")
+	b.WriteString("    done\n")
+	b.WriteString("    if [[ $cur == -* ]]; then\n")
+	fmt.Fprintf(&b, "        COMPREPLY=($(compgen -W \"%s\" -- \"$cur\"))\n", strings.Join(flagNames, " "))
+	b.WriteString("    else\n")
+	fmt.Fprintf(&b, "        COMPREPLY=($(compgen -W \"%s\" -- \"$cur\"))\n", strings.Join(completionCommands, " "))
+	b.WriteString("    fi\n")
+	b.WriteString("}\n")
+	fmt.Fprintf(&b, "complete -F %s %s\n", funcName, name)
+	return b.String()
+}
+
+// Fungsi untuk meloloskan teks deskripsi agar aman di spesifikasi _arguments zsh
+func zshEscape(text string) string {
+	return strings.NewReplacer("'", "'\\''", "[", "\\[", "]", "\\]", ":", "\\:").Replace(text)
+}
+
+// Fungsi untuk membuat skrip completion zsh
+func zshCompletion(name string) string {
+	funcName := completionFuncName(name)
+	var b strings.Builder
+	fmt.Fprintf(&b, "#compdef %s\n# Completion zsh untuk %s. Pasang dengan:\n#   source <(%s completion zsh)\n\n", name, name, name)
+	fmt.Fprintf(&b, "%s() {\n", funcName)
+	b.WriteString("    local state\n")
+	b.WriteString("    local -a items\n")
+	b.WriteString("    _arguments \\\n")
+	for _, f := range completionFlags() {
+		spec := fmt.Sprintf("-%s[%s]", f.Name, zshEscape(f.Description))
+		switch f.Arg {
+		case "menu":
+			spec += ":item menu:->menu"
+		case "file":
+			spec += ":file:_files"
+		case "value":
+			spec += ":nilai: "
+		}
+		fmt.Fprintf(&b, "        '%s' \\\n", spec)
+	}
+	fmt.Fprintf(&b, "        '1:perintah:(%s)' \\\n", strings.Join(completionCommands, " "))
+	b.WriteString("        '*::argumen:->args'\n")
+	b.WriteString("    case $state in\n")
+	b.WriteString("        menu)\n")
+	fmt.Fprintf(&b, "            items=(\"${(@f)$(%s completion menu 2>/dev/null)}\")\n", name)
+	b.WriteString("            compadd -a items ;;\n")
+	b.WriteString("        args)\n")
+	b.WriteString("            case $words[1] in\n")
+	b.WriteString("                menu) _arguments '1:subperintah:(diff)' '*:file:_files' ;;\n")
+	b.WriteString("                fsck) _arguments '--repair[perbaiki masalah yang bisa diperbaiki otomatis]' ;;\n")
+	fmt.Fprintf(&b, "                completion) _arguments '1:shell:(%s)' ;;\n", strings.Join(completionShells, " "))
+	b.WriteString("            esac ;;\n")
+	b.WriteString("    esac\n")
+	b.WriteString("}\n\n")
+	b.WriteString("if [[ $zsh_eval_context[-1] == loadautofunc ]]; then\n")
+	fmt.Fprintf(&b, "    %s \"$@\"\n", funcName)
+	b.WriteString("else\n")
+	fmt.Fprintf(&b, "    compdef %s %s\n", funcName, name)
+	b.WriteString("fi\n")
+	return b.String()
+}
+
+// Fungsi untuk meloloskan teks agar aman di dalam tanda kutip tunggal fish
+func fishEscape(text string) string {
+	return strings.NewReplacer("\\", "\\\\", "'", "\\'").Replace(text)
+}
+
+// Fungsi untuk membuat skrip completion fish
+func fishCompletion(name string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Completion fish untuk %s. Pasang dengan:\n#   %s completion fish | source\n", name, name)
+	fmt.Fprintf(&b, "complete -c %s -f\n", name)
+	for _, f := range completionFlags() {
+		line := fmt.Sprintf("complete -c %s -o %s -d '%s'", name, f.Name, fishEscape(f.Description))
+		switch f.Arg {
+		case "menu":
+			line += fmt.Sprintf(" -x -a '(%s completion menu 2>/dev/null)'", name)
+		case "file":
+			line += " -r -F"
+		case "value":
+			line += " -x"
+		}
+		b.WriteString(line + "\n")
+	}
+	fmt.Fprintf(&b, "complete -c %s -n '__fish_use_subcommand' -a menu -d 'Perintah menu'\n", name)
+	fmt.Fprintf(&b, "complete -c %s -n '__fish_use_subcommand' -a completion -d 'Buat skrip completion shell'\n", name)
+	fmt.Fprintf(&b, "complete -c %s -n '__fish_use_subcommand' -a fsck -d 'Periksa integritas data'\n", name)
+	fmt.Fprintf(&b, "complete -c %s -n '__fish_use_subcommand' -a events -d 'Tampilkan log event penyimpanan'\n", name)
+	fmt.Fprintf(&b, "complete -c %s -n '__fish_seen_subcommand_from menu; and not __fish_seen_subcommand_from diff' -a diff -d 'Bandingkan dua file menu'\n", name)
+	fmt.Fprintf(&b, "complete -c %s -n '__fish_seen_subcommand_from diff' -F\n", name)
+	fmt.Fprintf(&b, "complete -c %s -n '__fish_seen_subcommand_from completion' -a '%s'\n", name, strings.Join(completionShells, " "))
+	fmt.Fprintf(&b, "complete -c %s -n '__fish_seen_subcommand_from fsck' -l repair -d 'Perbaiki masalah yang bisa diperbaiki otomatis'\n", name)
+	return b.String()
+}
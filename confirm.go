@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Fungsi untuk menampilkan ringkasan pesanan dan meminta konfirmasi kasir.
+// Kasir bisa mengubah item atau membatalkan pesanan sebelum stok dikurangi
+// dan pesanan masuk antrian. Pesanan langsung juga bisa diparkir untuk dilanjutkan
+// nanti. Mengembalikan false jika pesanan dibatalkan atau diparkir.
+func confirmOrder(reader *bufio.Reader, order *Order, reserve bool) bool {
+	prompt := "Simpan pesanan? (y = simpan, e = ubah, n = batal): "
+	if reserve {
+		prompt = "Simpan pesanan? (y = simpan, e = ubah, p = parkir, n = batal): "
+	}
+	for {
+		displayOrderSummary(order)
+
+		fmt.Print(prompt)
+		choice, _ := reader.ReadString('\n')
+		switch strings.ToLower(strings.TrimSpace(choice)) {
+		case "y":
+			if len(order.Lines) == 0 {
+				fmt.Println("Pesanan tidak memiliki item.")
+				continue
+			}
+			if reserve && !reserveOrderLines(order) {
+				fmt.Println("Tidak ada item yang bisa dipesan, pesanan dibatalkan.")
+				return false
+			}
+			return true
+		case "e":
+			editPendingOrder(reader, order, reserve)
+		case "p":
+			if !reserve {
+				fmt.Println("Pilihan tidak valid.")
+				continue
+			}
+			if len(order.Lines) == 0 {
+				fmt.Println("Pesanan tidak memiliki item.")
+				continue
+			}
+			if parkOrder(order) {
+				return false
+			}
+		case "n":
+			fmt.Println("Pesanan dibatalkan.")
+			return false
+		default:
+			if inputExhausted(reader) {
+				fmt.Println("\nInput berakhir, pesanan dibatalkan.")
+				return false
+			}
+			fmt.Println("Pilihan tidak valid.")
+		}
+	}
+}
+
+// Fungsi untuk menampilkan baris, subtotal, pajak dan total pesanan yang belum disimpan
+func displayOrderSummary(order *Order) {
+	fmt.Printf("\n===== Ringkasan Pesanan ID %d%s =====\n", order.ID, describeTable(order.Table, order.Delivery))
+	for _, field := range currentConfig().OrderFields {
+		if value := order.Fields[field.Key]; value != "" {
+			fmt.Printf("%s: %s\n", field.label(), value)
+		}
+	}
+	if order.PriceList != "" {
+		fmt.Printf("Daftar harga: %s\n", order.PriceList)
+	}
+	for _, line := range order.Lines {
+		marker := ""
+		if line.Status == LineHeld {
+			marker = " [TAHAN]"
+		}
+		fmt.Printf("%d. %s x%d @ %s = %s%s\n", line.No, lineLabel(line), line.Quantity, formatMoney(line.Price), formatMoney(line.TotalPrice), marker)
+	}
+	fmt.Printf("Subtotal: %s\n", formatMoney(order.TotalPrice))
+	fmt.Printf("Pajak (%s): %s\n", formatPercent(currentConfig().TaxRate), formatMoney(order.Tax()))
+	fmt.Printf("Total: %s\n", formatMoney(order.AmountDue()))
+}
+
+// Fungsi untuk menghapus atau menambah item pada pesanan yang belum disimpan
+func editPendingOrder(reader *bufio.Reader, order *Order, reserve bool) {
+	fmt.Print("Nomor baris yang dihapus, atau \"tambah\" untuk menambah item: ")
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(input)
+
+	if strings.EqualFold(input, "tambah") {
+		readOrderLines(reader, order, reserve)
+		return
+	}
+
+	lineNo, err := strconv.Atoi(input)
+	if err != nil || lineNo < 1 || lineNo > len(order.Lines) {
+		fmt.Println("Nomor baris tidak valid.")
+		return
+	}
+	order.Lines = append(order.Lines[:lineNo-1], order.Lines[lineNo:]...)
+	renumberLines(order)
+}
+
+// Fungsi untuk mengurutkan ulang nomor baris dan menghitung ulang total pesanan
+func renumberLines(order *Order) {
+	order.TotalPrice = 0
+	for i := range order.Lines {
+		order.Lines[i].No = i + 1
+		order.TotalPrice += order.Lines[i].TotalPrice
+	}
+}
+
+// Fungsi untuk mengurangi stok semua baris pesanan yang sudah dikonfirmasi.
+// Baris yang stoknya sudah tidak cukup dilewati, mengembalikan false jika tidak ada yang tersisa.
+func reserveOrderLines(order *Order) bool {
+	menuMutex.Lock()
+	defer menuMutex.Unlock()
+
+	var reserved []OrderLine
+	for _, line := range order.Lines {
+		selectedItem := findMenuItem(line.ItemName)
+		if selectedItem == nil {
+			fmt.Printf("%s sudah tidak ada di menu, dilewati.\n", line.ItemName)
+			continue
+		}
+		if err := reserveModifierStock(line.Modifiers, line.Quantity); err != nil {
+			fmt.Printf("%v %s dilewati.\n", err, line.ItemName)
+			continue
+		}
+		if err := selectedItem.removeStock(line.Quantity); err != nil {
+			releaseModifierStock(line.Modifiers, line.Quantity, "")
+			fmt.Printf("Gagal mengurangi stok %s: %v, dilewati.\n", line.ItemName, err)
+			continue
+		}
+		recordMovement(line.ItemName, -line.Quantity, MovementSale, orderReference(order.ID))
+		recordModifierSale(line.Modifiers, line.Quantity, MovementSale, orderReference(order.ID))
+		reserved = append(reserved, line)
+	}
+
+	order.Lines = reserved
+	renumberLines(order)
+	return len(order.Lines) > 0
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Timer fire otomatis per pesanan, selalu diatur ke waktu fire paling awal pesanan itu
+var courseTimers = map[int]*time.Timer{}
+var courseTimersMutex sync.Mutex
+
+// Bernilai true setelah program mulai berhenti, timer yang terlambat tidak boleh
+// mengirim ke antrian dapur yang sudah ditutup. Dilindungi courseTimersMutex.
+var courseTimersStopped bool
+
+// Fungsi untuk mengambil course item, varian tanpa course sendiri ikut course item
+// induknya. Pemanggil harus memegang menuMutex.
+func itemCourse(item *MenuItem) string {
+	if item.Course != "" {
+		return item.Course
+	}
+	if parent := variantParent(item); parent != nil {
+		return parent.Course
+	}
+	return ""
+}
+
+// Fungsi untuk menampilkan course di daftar menu, kosong jika item tidak punya course
+func describeCourse(course string) string {
+	if course == "" {
+		return ""
+	}
+	return " | Course: " + course
+}
+
+// Fungsi untuk menahan baris antri yang course-nya punya jeda di course_delays. Baris
+// itu dikirim otomatis oleh timer pada waktu pesanan dibuat ditambah jeda.
+// Mengembalikan baris yang ditahan.
+func applyCourseTiming(order *Order) []OrderLine {
+	delays := currentConfig().CourseDelays
+	if len(delays) == 0 {
+		return nil
+	}
+
+	// Jeda dibaca dari menu lebih dulu agar menuMutex tidak dikunci di dalam ordersMutex
+	ordersMutex.Lock()
+	var names []string
+	for _, line := range order.Lines {
+		if line.Status == LineQueued {
+			names = append(names, line.ItemName)
+		}
+	}
+	ordersMutex.Unlock()
+
+	itemDelays := map[string]int{}
+	menuMutex.Lock()
+	for _, name := range names {
+		if item := findMenuItem(name); item != nil {
+			itemDelays[name] = delays[itemCourse(item)]
+		}
+	}
+	menuMutex.Unlock()
+
+	ordersMutex.Lock()
+	defer ordersMutex.Unlock()
+	var timed []OrderLine
+	for i := range order.Lines {
+		line := &order.Lines[i]
+		if line.Status != LineQueued || itemDelays[line.ItemName] <= 0 {
+			continue
+		}
+		line.Status = LineHeld
+		line.FireAt = order.CreatedAt.Add(time.Duration(itemDelays[line.ItemName]) * time.Minute)
+		timed = append(timed, *line)
+	}
+	return timed
+}
+
+// Fungsi untuk mengambil waktu fire otomatis paling awal pesanan, nol jika tidak ada.
+// Pemanggil harus memegang ordersMutex.
+func nextCourseFire(order *Order) time.Time {
+	var next time.Time
+	for _, line := range order.Lines {
+		if line.Status == LineHeld && !line.FireAt.IsZero() && (next.IsZero() || line.FireAt.Before(next)) {
+			next = line.FireAt
+		}
+	}
+	return next
+}
+
+// Fungsi untuk mengatur timer fire otomatis pesanan, timer lama pesanan itu diganti.
+// Waktu nol hanya menghentikan timer.
+func armCourseTimer(orderID int, at time.Time) {
+	courseTimersMutex.Lock()
+	defer courseTimersMutex.Unlock()
+
+	if timer, ok := courseTimers[orderID]; ok {
+		timer.Stop()
+		delete(courseTimers, orderID)
+	}
+	if at.IsZero() || courseTimersStopped {
+		return
+	}
+	courseTimers[orderID] = time.AfterFunc(time.Until(at), func() {
+		fireDueCourses(orderID, time.Now())
+	})
+}
+
+// Fungsi untuk mengirim baris yang waktu fire-nya sudah lewat ke dapur, lalu mengatur
+// timer untuk course berikutnya. Pesanan yang sudah digabung diteruskan ke pesanan tujuan.
+func fireDueCourses(orderID int, now time.Time) {
+	dryRunMutex.Lock()
+	defer dryRunMutex.Unlock()
+
+	ordersMutex.Lock()
+	order := findOrder(orderID)
+	for order != nil && order.MergedInto != 0 {
+		order = findOrder(order.MergedInto)
+	}
+	if order == nil {
+		ordersMutex.Unlock()
+		return
+	}
+	var fired []OrderLine
+	for i := range order.Lines {
+		line := &order.Lines[i]
+		if line.Status == LineHeld && !line.FireAt.IsZero() && !now.Before(line.FireAt) {
+			line.Status = LineQueued
+			line.FireAt = time.Time{}
+			fired = append(fired, *line)
+			publishLineStatus(order.ID, *line)
+		}
+	}
+	id := order.ID
+	next := nextCourseFire(order)
+	ordersMutex.Unlock()
+
+	if orderID != id {
+		armCourseTimer(orderID, time.Time{})
+	}
+	armCourseTimer(id, next)
+	if len(fired) == 0 {
+		return
+	}
+
+	courseTimersMutex.Lock()
+	defer courseTimersMutex.Unlock()
+	if courseTimersStopped {
+		return
+	}
+	processingPrintf("\nFire otomatis pesanan ID %d: %s masuk dapur.\n", id, describeLines(fired))
+	sendToKitchen(id, fired)
+}
+
+// Fungsi untuk mengatur ulang timer fire otomatis setelah program dimulai. Course yang
+// waktunya lewat saat program mati langsung dikirim.
+func resumeCourseTimers() {
+	ordersMutex.Lock()
+	pending := map[int]time.Time{}
+	for _, order := range orders {
+		if next := nextCourseFire(order); !next.IsZero() {
+			pending[order.ID] = next
+		}
+	}
+	ordersMutex.Unlock()
+
+	for id, next := range pending {
+		armCourseTimer(id, next)
+	}
+}
+
+// Fungsi untuk menghentikan semua timer fire otomatis sebelum antrian dapur ditutup.
+// Baris yang belum di-fire tetap ditahan dan diatur ulang saat program dimulai lagi.
+func stopCourseTimers() {
+	courseTimersMutex.Lock()
+	defer courseTimersMutex.Unlock()
+
+	courseTimersStopped = true
+	for id, timer := range courseTimers {
+		timer.Stop()
+		delete(courseTimers, id)
+	}
+}
+
+// Fungsi untuk menampilkan baris yang menunggu fire otomatis pada tiket dapur
+func describeCourseFire(line OrderLine) string {
+	if line.FireAt.IsZero() {
+		return ""
+	}
+	return " | Fire otomatis: " + line.FireAt.Local().Format("15:04")
+}
+
+// Fungsi untuk membatalkan fire otomatis satu pesanan. Baris tetap ditahan dan bisa
+// dikirim manual lewat opsi kirim item tertahan. ID boleh ditulis setelah opsi.
+func cancelCourseFire(reader *bufio.Reader, args string) {
+	ref := strings.TrimSpace(args)
+	if ref == "" {
+		fmt.Print("Masukkan ID pesanan atau meja <nomor>: ")
+		input, _ := reader.ReadString('\n')
+		ref = strings.TrimSpace(input)
+	}
+
+	ordersMutex.Lock()
+	order, err := findOrderRef(ref)
+	if err != nil {
+		ordersMutex.Unlock()
+		fmt.Println(err)
+		return
+	}
+	order = followMergedOrder(order)
+	var cancelled []OrderLine
+	for i := range order.Lines {
+		if order.Lines[i].Status == LineHeld && !order.Lines[i].FireAt.IsZero() {
+			cancelled = append(cancelled, order.Lines[i])
+			order.Lines[i].FireAt = time.Time{}
+		}
+	}
+	id := order.ID
+	ordersMutex.Unlock()
+
+	if len(cancelled) == 0 {
+		fmt.Println("Tidak ada fire otomatis pada pesanan ini.")
+		return
+	}
+	armCourseTimer(id, time.Time{})
+	logActivity(fmt.Sprintf("batal fire otomatis pesanan %d: %s", id, describeLines(cancelled)))
+	fmt.Printf("Fire otomatis pesanan ID %d dibatalkan, %s tetap ditahan sampai dikirim lewat opsi 6.\n", id, describeLines(cancelled))
+}
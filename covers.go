@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Fungsi untuk menampilkan analisis cover tamu: rata-rata belanja per cover dan
+// jumlah cover per jam, dipakai pemilik untuk perencanaan kapasitas
+func displayCoverReport(reader *bufio.Reader) {
+	from, ok := readOptionalDate(reader, "Dari tanggal (YYYY-MM-DD, kosongkan untuk semua): ")
+	if !ok {
+		return
+	}
+	to, ok := readOptionalDate(reader, "Sampai tanggal (YYYY-MM-DD, kosongkan untuk semua): ")
+	if !ok {
+		return
+	}
+	if !to.IsZero() {
+		to = to.AddDate(0, 0, 1)
+	}
+	fmt.Print("Sertakan pesanan arsip? (y/n): ")
+	archiveInput, _ := reader.ReadString('\n')
+
+	reportOrders, err := ordersForReport(strings.EqualFold(strings.TrimSpace(archiveInput), "y"), from, to)
+	if err != nil {
+		fmt.Println("Gagal membaca arsip pesanan:", err)
+		return
+	}
+
+	var tables, covers int
+	var revenue Money
+	perHour := make([]int, 24)
+	days := map[string]bool{}
+	for i := range reportOrders {
+		order := &reportOrders[i]
+		// Hanya pesanan makan di tempat yang jumlah tamunya dicatat
+		if order.Voided || order.Table == 0 || order.Guests == 0 || order.CreatedAt.IsZero() {
+			continue
+		}
+		created := order.CreatedAt.Local()
+		tables++
+		covers += order.Guests
+		revenue += orderRevenue(order)
+		perHour[created.Hour()] += order.Guests
+		days[created.Format("2006-01-02")] = true
+	}
+
+	fmt.Println("\n===== Laporan Cover Tamu =====")
+	if covers == 0 {
+		fmt.Println("Tidak ada pesanan makan di tempat dengan jumlah tamu pada periode ini.")
+		return
+	}
+
+	fmt.Printf("Jumlah hari: %d\n", len(days))
+	fmt.Printf("Meja dilayani: %d\n", tables)
+	fmt.Printf("Total cover: %s (rata-rata %s tamu per meja)\n", formatQuantity(covers), formatNumber(float64(covers)/float64(tables), 1))
+	fmt.Printf("Pendapatan: %s\n", formatMoney(revenue))
+	fmt.Printf("Rata-rata belanja per cover: %s\n", formatMoney(revenue.Div(covers)))
+
+	busiest := 0
+	for _, count := range perHour {
+		busiest = max(busiest, count)
+	}
+	fmt.Println("\nCover per jam:")
+	for hour, count := range perHour {
+		if count == 0 {
+			continue
+		}
+		start := time.Duration(hour) * time.Hour
+		bar := strings.Repeat("#", count*20/busiest)
+		fmt.Printf("%s-%s | Cover: %3d | Rata-rata/hari: %s | %s\n",
+			formatClock(start), formatClock(start+time.Hour), count, formatNumber(float64(count)/float64(len(days)), 1), bar)
+	}
+}
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Dimensi pengelompokan yang didukung laporan kustom
+var customReportGroups = []string{"hari", "jam", "item", "stasiun", "kasir", "bayar", "meja", "sumber", "harga"}
+
+// Filter dan pengelompokan laporan kustom
+type customReportQuery struct {
+	From, To       time.Time
+	Station        Station
+	Payment        PaymentMethod
+	Cashier        string
+	Source         OrderSource
+	GroupBy        string
+	CSVPath        string
+	IncludeArchive bool
+}
+
+// Satu baris hasil laporan kustom
+type customReportRow struct {
+	Key     string
+	Orders  int
+	Units   int
+	Revenue Money
+	ids     map[int]bool
+}
+
+// Contoh perintah yang ditampilkan bersama pesan kesalahan
+const customReportExample = "contoh: report custom dari=2024-05-01 sampai=2024-05-31 stasiun=wok bayar=tunai kasir=budi sumber=kiosk per=item csv=laporan.csv arsip"
+
+// Fungsi untuk menjalankan perintah `report custom` dengan filter berbentuk kunci=nilai.
+// Tanpa argumen kasir diminta mengetik filternya.
+func customReport(reader *bufio.Reader, args string) {
+	args = strings.TrimSpace(args)
+	if rest, ok := strings.CutPrefix(args, "branches"); ok {
+		branchReport(strings.TrimSpace(rest))
+		return
+	}
+	if rest, ok := strings.CutPrefix(args, "custom"); ok {
+		args = strings.TrimSpace(rest)
+	}
+	if args == "" {
+		groups := customReportGroups
+		for _, field := range currentConfig().OrderFields {
+			groups = append(groups[:len(groups):len(groups)], field.Key)
+		}
+		fmt.Println("Filter: dari=, sampai=, stasiun=, bayar=, kasir=, sumber=, per=" + strings.Join(groups, "/") + ", csv=, arsip")
+		fmt.Print("Masukkan filter (kosongkan untuk semua pesanan per hari): ")
+		args, _ = reader.ReadString('\n')
+	}
+
+	query, err := parseCustomReportQuery(args)
+	if err != nil {
+		fmt.Printf("Filter tidak valid: %v\n%s\n", err, customReportExample)
+		return
+	}
+
+	rows, err := runCustomReport(query)
+	if err != nil {
+		fmt.Println("Gagal membuat laporan:", err)
+		return
+	}
+
+	if query.CSVPath != "" {
+		if err := writeCustomReportCSV(query.CSVPath, query.GroupBy, rows); err != nil {
+			fmt.Println("Gagal menulis CSV:", err)
+			return
+		}
+		fmt.Printf("%d baris laporan ditulis ke %s.\n", len(rows), query.CSVPath)
+		return
+	}
+
+	fmt.Printf("\n===== Laporan Kustom per %s =====\n", query.GroupBy)
+	if len(rows) == 0 {
+		fmt.Println("Tidak ada data yang cocok dengan filter.")
+		return
+	}
+	var totalUnits int
+	var totalRevenue Money
+	for _, row := range rows {
+		fmt.Printf("%-20s | Pesanan: %4d | Porsi: %5d | Pendapatan: %s\n", row.Key, row.Orders, row.Units, formatMoney(row.Revenue))
+		totalUnits += row.Units
+		totalRevenue += row.Revenue
+	}
+	fmt.Printf("%-20s | %14s | Porsi: %5d | Pendapatan: %s\n", "TOTAL", "", totalUnits, formatMoney(totalRevenue))
+}
+
+// Fungsi untuk membaca filter laporan kustom dari teks kunci=nilai
+func parseCustomReportQuery(input string) (customReportQuery, error) {
+	query := customReportQuery{GroupBy: "hari"}
+	for _, field := range strings.Fields(input) {
+		key, value, _ := strings.Cut(field, "=")
+		key = strings.ToLower(key)
+		if key != "arsip" && value == "" {
+			return query, fmt.Errorf("%s harus diisi, misalnya %s=...", key, key)
+		}
+
+		switch key {
+		case "dari", "sampai":
+			date, err := time.ParseInLocation("2006-01-02", value, time.Local)
+			if err != nil {
+				return query, fmt.Errorf("tanggal %q harus berformat YYYY-MM-DD", value)
+			}
+			if key == "dari" {
+				query.From = date
+			} else {
+				query.To = date.AddDate(0, 0, 1)
+			}
+		case "stasiun":
+			station, ok := parseStation(value)
+			if !ok {
+				return query, fmt.Errorf("stasiun %q tidak dikenal", value)
+			}
+			query.Station = station
+		case "bayar":
+			method, ok := parsePaymentMethod(value)
+			if !ok {
+				return query, fmt.Errorf("metode bayar %q tidak dikenal", value)
+			}
+			query.Payment = method
+		case "kasir":
+			query.Cashier = value
+		case "sumber":
+			source, ok := parseOrderSource(value)
+			if !ok {
+				return query, fmt.Errorf("sumber %q tidak dikenal", value)
+			}
+			query.Source = source
+		case "per":
+			if field, ok := findOrderField(value); ok {
+				query.GroupBy = field.Key
+				continue
+			}
+			value = strings.ToLower(value)
+			if !containsString(customReportGroups, value) {
+				return query, fmt.Errorf("pengelompokan %q tidak dikenal, pilih %s", value, strings.Join(customReportGroups, "/"))
+			}
+			query.GroupBy = value
+		case "csv":
+			query.CSVPath = value
+		case "arsip":
+			query.IncludeArchive = true
+		default:
+			return query, fmt.Errorf("filter %q tidak dikenal", key)
+		}
+	}
+	if !query.From.IsZero() && !query.To.IsZero() && !query.From.Before(query.To) {
+		return query, errors.New("tanggal dari harus sebelum tanggal sampai")
+	}
+	return query, nil
+}
+
+// Fungsi untuk memeriksa apakah daftar berisi teks tertentu
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+// Fungsi untuk menghitung laporan kustom. Pendapatan dihitung per baris item
+// (harga x porsi yang tidak diretur), sehingga filter stasiun dan pengelompokan
+// per item tetap konsisten.
+func runCustomReport(query customReportQuery) ([]customReportRow, error) {
+	reportOrders, err := ordersForReport(query.IncludeArchive, query.From, query.To)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := map[string]*customReportRow{}
+	for _, order := range reportOrders {
+		if order.Voided || order.CreatedAt.IsZero() {
+			continue
+		}
+		if query.Payment != "" && !order.PaidWith(query.Payment) {
+			continue
+		}
+		if query.Cashier != "" && !strings.EqualFold(order.Cashier, query.Cashier) {
+			continue
+		}
+		if query.Source != "" && order.Channel() != query.Source {
+			continue
+		}
+
+		for _, line := range order.Lines {
+			if line.Status == LineCancelled {
+				continue
+			}
+			if query.Station != "" && line.Station != query.Station {
+				continue
+			}
+
+			key := customReportKey(query.GroupBy, &order, line)
+			row := groups[key]
+			if row == nil {
+				row = &customReportRow{Key: key, ids: map[int]bool{}}
+				groups[key] = row
+			}
+			units := line.Quantity - line.Returned
+			row.Units += units
+			row.Revenue += line.Price.Times(units)
+			row.ids[order.ID] = true
+		}
+	}
+
+	rows := make([]customReportRow, 0, len(groups))
+	for _, row := range groups {
+		row.Orders = len(row.ids)
+		rows = append(rows, *row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		// Waktu diurutkan menurut kunci, dimensi lain dari pendapatan terbesar
+		if query.GroupBy == "hari" || query.GroupBy == "jam" {
+			return rows[i].Key < rows[j].Key
+		}
+		if rows[i].Revenue != rows[j].Revenue {
+			return rows[i].Revenue > rows[j].Revenue
+		}
+		return rows[i].Key < rows[j].Key
+	})
+	return rows, nil
+}
+
+// Fungsi untuk menentukan kunci kelompok satu baris pesanan
+func customReportKey(groupBy string, order *Order, line OrderLine) string {
+	switch groupBy {
+	case "jam":
+		return order.CreatedAt.Local().Format("15") + ":00"
+	case "item":
+		return line.ItemName
+	case "stasiun":
+		return string(line.Station)
+	case "kasir":
+		if order.Cashier == "" {
+			return "(tidak diketahui)"
+		}
+		return order.Cashier
+	case "bayar":
+		if !order.Paid {
+			return "belum dibayar"
+		}
+		return order.Payment().Label()
+	case "meja":
+		if order.Table == 0 {
+			return order.Type().Label()
+		}
+		return "meja " + strconv.Itoa(order.Table)
+	case "sumber":
+		return order.Channel().Label()
+	case "harga":
+		return describePriceList(order.PriceList)
+	}
+	if field, ok := findOrderField(groupBy); ok {
+		if value := order.Fields[field.Key]; value != "" {
+			return value
+		}
+		return "(kosong)"
+	}
+	return order.CreatedAt.Local().Format("2006-01-02")
+}
+
+// Fungsi untuk menulis hasil laporan kustom ke file CSV
+func writeCustomReportCSV(path, groupBy string, rows []customReportRow) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	writer.Write([]string{groupBy, "orders", "units", "revenue"})
+	for _, row := range rows {
+		writer.Write([]string{row.Key, strconv.Itoa(row.Orders), strconv.Itoa(row.Units), formatMoneyPlain(row.Revenue)})
+	}
+	writer.Flush()
+	return writer.Error()
+}
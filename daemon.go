@@ -0,0 +1,343 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Hanya satu terminal yang menjalankan perintah pada satu waktu karena keluaran dan kasir
+// aktif dipakai bersama. Giliran dilepas selama terminal menunggu input kasir.
+var sessionMutex sync.Mutex
+
+// Struct untuk meneruskan keluaran program ke terminal yang sedang menjalankan perintah.
+// os.Stdout diganti pipe sekali saat daemon dimulai dan tujuan akhirnya berpindah-pindah.
+type outputRouter struct {
+	mu     sync.Mutex
+	stdout *os.File
+	target io.Writer
+	synced chan struct{}
+}
+
+// Fungsi untuk mengarahkan os.Stdout ke pipe yang diteruskan oleh outputRouter.
+// Harus dipanggil sebelum goroutine lain mulai mencetak.
+func newOutputRouter() (*outputRouter, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	router := &outputRouter{stdout: os.Stdout, target: os.Stdout, synced: make(chan struct{})}
+	os.Stdout = w
+	go router.forward(r)
+	return router, nil
+}
+
+// Fungsi untuk meneruskan isi pipe ke tujuan saat ini. Byte nol adalah penanda dari
+// sync dan tidak pernah ditulis ke terminal.
+func (o *outputRouter) forward(r io.Reader) {
+	buf := make([]byte, 4096)
+	for {
+		n, err := r.Read(buf)
+		chunk := buf[:n]
+		for len(chunk) > 0 {
+			i := bytes.IndexByte(chunk, 0)
+			if i < 0 {
+				o.write(chunk)
+				break
+			}
+			o.write(chunk[:i])
+			o.synced <- struct{}{}
+			chunk = chunk[i+1:]
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// Fungsi untuk menulis ke tujuan saat ini, kesalahan terminal yang terputus diabaikan
+func (o *outputRouter) write(p []byte) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	// Terminal yang berhenti membaca tidak boleh menahan keluaran terminal lain
+	if conn, ok := o.target.(net.Conn); ok {
+		conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+	}
+	o.target.Write(p)
+}
+
+// Fungsi untuk menunggu semua keluaran yang sudah dicetak sampai ke tujuan, lalu
+// memindahkan keluaran berikutnya ke tujuan baru
+func (o *outputRouter) switchTo(target io.Writer) {
+	os.Stdout.Write([]byte{0})
+	<-o.synced
+	o.mu.Lock()
+	o.target = target
+	o.mu.Unlock()
+}
+
+// Fungsi untuk mengembalikan os.Stdout ke layar daemon saat program selesai
+func (o *outputRouter) restore() {
+	o.switchTo(o.stdout)
+	os.Stdout = o.stdout
+}
+
+// Fungsi untuk memisahkan jenis dan alamat socket. Alamat dengan awalan unix: atau
+// yang berisi / adalah socket Unix, selain itu alamat TCP seperti 127.0.0.1:7070.
+func parseSocketAddress(addr string) (string, string) {
+	if path, ok := strings.CutPrefix(addr, "unix:"); ok {
+		return "unix", path
+	}
+	if strings.Contains(addr, "/") {
+		return "unix", addr
+	}
+	return "tcp", addr
+}
+
+// Fungsi untuk membuka socket daemon. File socket Unix sisa daemon yang sudah mati
+// dihapus, tetapi daemon yang masih berjalan tidak diganggu.
+func listenDaemon(addr string) (net.Listener, error) {
+	network, address := parseSocketAddress(addr)
+	if network == "unix" {
+		if conn, err := net.Dial(network, address); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("daemon lain sudah berjalan di %s", address)
+		}
+		os.Remove(address)
+	}
+	listener, err := net.Listen(network, address)
+	if err != nil {
+		return nil, err
+	}
+	if network == "tcp" {
+		if host, _, err := net.SplitHostPort(address); err == nil {
+			if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+				fmt.Println("Peringatan: socket TCP tanpa autentikasi, sebaiknya pakai 127.0.0.1 atau socket Unix.")
+			}
+		}
+	}
+	return listener, nil
+}
+
+// Fungsi untuk menjalankan daemon yang memegang data dan melayani terminal kasir lewat
+// socket sampai menerima SIGINT/SIGTERM, lalu menyimpan data dan keluar
+func runDaemon(addr string, router *outputRouter, stopScheduler func()) {
+	listener, err := listenDaemon(addr)
+	if err != nil {
+		fmt.Println("Gagal membuka socket daemon:", err)
+		router.restore()
+		shutdown(stopScheduler)
+		return
+	}
+	daemonRunning.Store(true)
+	fmt.Printf("Daemon berjalan di %s, sambungkan terminal dengan -attach %s. Tekan Ctrl+C untuk berhenti.\n", addr, addr)
+	logActivity("daemon mulai di " + addr)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		listener.Close()
+	}()
+
+	var connsMutex sync.Mutex
+	conns := map[net.Conn]bool{}
+	var sessions sync.WaitGroup
+	for id := 1; ; id++ {
+		conn, err := listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				fmt.Println("Gagal menerima terminal:", err)
+			}
+			break
+		}
+		connsMutex.Lock()
+		conns[conn] = true
+		connsMutex.Unlock()
+
+		sessions.Add(1)
+		go func() {
+			defer sessions.Done()
+			serveTerminal(conn, id, router)
+			connsMutex.Lock()
+			delete(conns, conn)
+			connsMutex.Unlock()
+		}()
+	}
+
+	// Terminal yang sedang menunggu input diputus, perintah yang sedang berjalan ditunggu
+	fmt.Println("\nDaemon berhenti, memutus terminal...")
+	connsMutex.Lock()
+	for conn := range conns {
+		conn.Close()
+	}
+	connsMutex.Unlock()
+	sessions.Wait()
+
+	logActivity("daemon berhenti")
+	router.restore()
+	shutdown(stopScheduler)
+}
+
+// Struct untuk satu terminal kasir yang tersambung ke daemon
+type terminalSession struct {
+	conn   net.Conn
+	router *outputRouter
+	// Kasir terminal ini, dipasang sebagai currentCashier selama terminal memegang sesi
+	cashier       string
+	daemonCashier string
+	// Bernilai true selama terminal ini memegang sessionMutex untuk menjalankan perintah
+	active bool
+	// Batas menunggu input sebelum terminal diputus, 0 berarti tanpa batas
+	idle     time.Duration
+	timedOut bool
+}
+
+// Fungsi untuk mengambil giliran menjalankan perintah: keluaran diarahkan ke terminal ini
+// dan kasir terminal ini dipasang sebagai kasir aktif
+func (s *terminalSession) acquire(notify bool) {
+	if !sessionMutex.TryLock() {
+		if notify {
+			fmt.Fprintln(s.conn, "Menunggu terminal lain selesai...")
+		}
+		sessionMutex.Lock()
+	}
+	s.router.switchTo(s.conn)
+	activityMutex.Lock()
+	s.daemonCashier = currentCashier
+	currentCashier = s.cashier
+	activityMutex.Unlock()
+	s.active = true
+}
+
+// Fungsi untuk melepas giliran setelah semua keluaran terkirim ke terminal ini
+func (s *terminalSession) release() {
+	activityMutex.Lock()
+	s.cashier = currentCashier
+	currentCashier = s.daemonCashier
+	activityMutex.Unlock()
+	s.router.switchTo(s.router.stdout)
+	s.active = false
+	sessionMutex.Unlock()
+}
+
+// Fungsi untuk membaca input terminal. Selama kasir mengetik di tengah perintah giliran
+// dilepas, karena perintah tidak memegang kunci data saat menunggu input, sehingga terminal
+// lain tetap bisa bekerja. Terminal yang diam melebihi terminal_idle_minutes diputus.
+func (s *terminalSession) Read(p []byte) (int, error) {
+	if s.timedOut {
+		return 0, os.ErrDeadlineExceeded
+	}
+	if s.idle > 0 {
+		s.conn.SetReadDeadline(time.Now().Add(s.idle))
+	}
+	if !s.active {
+		return s.read(p)
+	}
+	s.release()
+	n, err := s.read(p)
+	s.acquire(false)
+	return n, err
+}
+
+// Fungsi untuk membaca dari koneksi dan mencatat jika batas waktu diam terlewati
+func (s *terminalSession) read(p []byte) (int, error) {
+	n, err := s.conn.Read(p)
+	if errors.Is(err, os.ErrDeadlineExceeded) {
+		s.timedOut = true
+		fmt.Fprintf(s.conn, "\nTerminal diputus karena tidak aktif selama %s.\n", s.idle)
+	}
+	return n, err
+}
+
+// Fungsi untuk melayani satu terminal kasir. Menu dan prompt opsi ditulis langsung ke
+// terminal, sedangkan perintah dijalankan bergantian dengan terminal lain dan
+// keluarannya diteruskan ke terminal ini.
+func serveTerminal(conn net.Conn, id int, router *outputRouter) {
+	defer conn.Close()
+	session := &terminalSession{
+		conn:   conn,
+		router: router,
+		idle:   time.Duration(currentConfig().TerminalIdleMinutes) * time.Minute,
+	}
+	reader := newInputReader(session)
+	defer forgetInputReader(reader)
+
+	activityMutex.Lock()
+	session.cashier = currentCashier
+	activityMutex.Unlock()
+
+	from := ""
+	if conn.RemoteAddr().Network() == "tcp" {
+		from = " dari " + conn.RemoteAddr().String()
+	}
+	logActivity(fmt.Sprintf("terminal %d terhubung%s", id, from))
+	defer func() {
+		reason := ""
+		if session.timedOut {
+			reason = " karena tidak aktif"
+		}
+		logActivity(fmt.Sprintf("terminal %d terputus%s", id, reason))
+	}()
+	fmt.Fprintf(conn, "Terhubung ke daemon sebagai terminal %d, kasir: %s. Ganti kasir lewat opsi 23.\n", id, session.cashier)
+
+	for {
+		printMainMenu(conn)
+		input, err := reader.ReadString('\n')
+		if err != nil && strings.TrimSpace(input) == "" {
+			return
+		}
+		option, args := resolveCommand(input)
+		args, dry := cutDryRunFlag(args)
+		if dry {
+			fmt.Fprintln(conn, "--dry-run tidak tersedia di terminal daemon.")
+			continue
+		}
+		if option == "4" {
+			fmt.Fprintln(conn, "Terminal terputus, data tetap dipegang daemon.")
+			return
+		}
+
+		session.acquire(true)
+		refreshSharedStock()
+		reloadChangedFiles()
+		logCommand(option)
+		runMenuOption(reader, option, args)
+		saveState()
+		session.release()
+		if session.timedOut {
+			return
+		}
+	}
+}
+
+// Fungsi untuk menjalankan terminal tipis yang meneruskan input dan keluaran ke
+// daemon. Data tidak dibaca atau disimpan oleh proses ini.
+func runAttach(addr string) {
+	network, address := parseSocketAddress(addr)
+	conn, err := net.Dial(network, address)
+	if err != nil {
+		fmt.Println("Gagal terhubung ke daemon:", err)
+		return
+	}
+	defer conn.Close()
+
+	// Input yang habis (misalnya Ctrl+D) menutup sisi tulis supaya daemon tahu terminal selesai
+	go func() {
+		io.Copy(conn, bufio.NewReader(os.Stdin))
+		if closer, ok := conn.(interface{ CloseWrite() error }); ok {
+			closer.CloseWrite()
+		}
+	}()
+	io.Copy(os.Stdout, conn)
+	fmt.Println("\nTerputus dari daemon.")
+}
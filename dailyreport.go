@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Folder laporan harian otomatis di dalam folder data
+const reportsDir = "reports"
+
+// Fungsi untuk menyusun teks laporan harian dari awal hari sampai waktu tertentu
+func formatDailyReport(from, to time.Time) string {
+	l := newReceiptLayout()
+	summary := summarizePeriod(from, to)
+	cash := summarizeCash(from, to)
+
+	l.Title("Laporan Harian " + currentConfig().RestaurantName)
+	l.Pair("Tanggal", fmt.Sprintf("%s (sampai %s)", formatDate(from), to.Local().Format("15:04")))
+	l.Pair("Jumlah Pesanan", fmt.Sprintf("%d (dibatalkan: %d)", summary.Orders, summary.Voided))
+	l.Pair("Pendapatan", formatMoney(summary.Revenue))
+	writeTableCharges(l, summary.CoverCharges, summary.MinimumTopUps)
+	l.Pair("Diskon", formatMoney(summary.Discounts))
+	l.Pair("Refund", formatMoney(summary.Refunds))
+	l.Pair("Nilai Waste", formatMoney(summary.Waste))
+	l.Pair("Retur Pemasok", formatMoney(summary.SupplierReturns))
+	l.Pair("Uang di laci seharusnya", formatMoney(cash.Expected()))
+	formatPriceOverrides(l, priceOverrides(from, to))
+
+	// Uang yang benar-benar diterima per metode, pembayaran split dihitung per bagian
+	if payments := paymentTotals(from, to); len(payments) > 0 {
+		l.Blank()
+		l.Line("Pembayaran per metode:")
+		for _, method := range []PaymentMethod{PaymentCash, PaymentCard, PaymentQRIS} {
+			if amount, ok := payments[method]; ok {
+				l.Pair("  "+method.Label(), formatMoney(amount))
+			}
+		}
+	}
+
+	sections := []struct{ groupBy, label string }{{"bayar", "metode bayar"}, {"sumber", "sumber"}, {"harga", "daftar harga"}, {"item", "item"}}
+	for _, section := range sections {
+		rows, err := runCustomReport(customReportQuery{From: from, To: to, GroupBy: section.groupBy})
+		if err != nil || len(rows) == 0 {
+			continue
+		}
+		l.Blank()
+		l.Line("Penjualan per %s:", section.label)
+		for _, row := range rows {
+			l.Pair("  "+row.Key, fmt.Sprintf("%d porsi, %s", row.Units, formatMoney(row.Revenue)))
+		}
+	}
+	return l.String()
+}
+
+// Fungsi untuk membuat laporan harian otomatis setelah jam yang diatur di
+// report_schedule.time. Laporan disimpan ke file dan dikirim lewat email jika diatur,
+// dan hanya dibuat sekali per hari karena file laporan hari itu sudah ada.
+func generateScheduledReport(now time.Time) {
+	schedule := currentConfig().ReportSchedule
+	if schedule.Time == "" {
+		return
+	}
+	clock, err := time.Parse("15:04", schedule.Time)
+	if err != nil {
+		return
+	}
+	local := now.Local()
+	startOfDay := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.Local)
+	if local.Before(startOfDay.Add(time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute)) {
+		return
+	}
+
+	dir := filepath.Join(currentConfig().DataDir, reportsDir)
+	path := filepath.Join(dir, "laporan-"+startOfDay.Format("2006-01-02")+".txt")
+	if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+		return
+	}
+
+	report := formatDailyReport(startOfDay, now)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		asyncPrintf("\nGagal menyimpan laporan harian: %v\n", err)
+		return
+	}
+	if err := os.WriteFile(path, []byte(report), 0644); err != nil {
+		asyncPrintf("\nGagal menyimpan laporan harian: %v\n", err)
+		return
+	}
+	asyncPrintf("\nLaporan harian disimpan ke %s.\n", path)
+
+	if schedule.Email == "" || !smtpConfigured() {
+		return
+	}
+	subject := fmt.Sprintf("Laporan harian %s %s", currentConfig().RestaurantName, formatDate(startOfDay))
+	if err := sendEmail(schedule.Email, subject, report); err != nil {
+		asyncPrintf("Gagal mengirim laporan harian ke %s, akan dicoba lagi: %v\n", schedule.Email, err)
+		queueEmail(PendingEmail{To: schedule.Email, Subject: subject, Body: report, Attempts: 1, LastAttempt: now, LastError: err.Error()})
+		return
+	}
+	asyncPrintf("Laporan harian dikirim ke %s.\n", schedule.Email)
+}
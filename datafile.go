@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Akhiran file cadangan yang dibuat sebelum file data ditimpa
+const backupSuffix = ".bak"
+
+// Kesalahan membaca file data yang rusak atau salah diedit, lengkap dengan posisi baris
+// dan kolom agar mudah diperbaiki manual
+type DataFileError struct {
+	Path    string
+	Line    int
+	Column  int
+	Snippet string
+	Err     error
+}
+
+func (e *DataFileError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%s: %v", e.Path, e.Err)
+	}
+	msg := fmt.Sprintf("%s baris %d kolom %d: %v", e.Path, e.Line, e.Column, e.Err)
+	if e.Snippet != "" {
+		msg += fmt.Sprintf("\n  %s\n  %s^", e.Snippet, strings.Repeat(" ", max(e.Column-1, 0)))
+	}
+	return msg
+}
+
+func (e *DataFileError) Unwrap() error {
+	return e.Err
+}
+
+// Fungsi untuk menerjemahkan kesalahan JSON menjadi DataFileError dengan baris dan kolom.
+// Kesalahan lain dikembalikan dengan nama file saja.
+func describeJSONError(path string, data []byte, err error) *DataFileError {
+	var offset int64
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+		if offset >= int64(len(bytes.TrimSpace(data))) {
+			err = errors.New("file terpotong, data JSON tidak lengkap")
+		}
+	case errors.As(err, &typeErr):
+		offset = valueStart(data, typeErr.Offset)
+		field := typeErr.Field
+		if field == "" {
+			field = "isi file"
+		}
+		err = fmt.Errorf("%s harus bertipe %s, bukan %s", field, typeErr.Type, typeErr.Value)
+	default:
+		return &DataFileError{Path: path, Err: err}
+	}
+
+	line, column, snippet := jsonPosition(data, offset)
+	return &DataFileError{Path: path, Line: line, Column: column, Snippet: snippet, Err: err}
+}
+
+// Fungsi untuk mencari awal nilai yang tipenya salah. encoding/json melaporkan posisi
+// setelah nilai selesai dibaca, sedangkan tanda ^ lebih jelas di awal nilai.
+func valueStart(data []byte, offset int64) int64 {
+	i := min(offset, int64(len(data))) - 1
+	for i > 0 && strings.ContainsRune(" \t\r\n", rune(data[i])) {
+		i--
+	}
+	if i <= 0 {
+		return offset
+	}
+	if data[i] == '"' {
+		for i--; i > 0 && (data[i] != '"' || data[i-1] == '\\'); i-- {
+		}
+		return i + 1
+	}
+	for i > 0 && !strings.ContainsRune(":[,{ \t\r\n", rune(data[i-1])) {
+		i--
+	}
+	return i + 1
+}
+
+// Fungsi untuk mengubah posisi byte menjadi nomor baris, kolom dan isi baris tersebut.
+// Posisi dari encoding/json menunjuk setelah karakter yang salah, jadi dimundurkan satu.
+func jsonPosition(data []byte, offset int64) (int, int, string) {
+	offset = min(max(offset-1, 0), int64(len(data)))
+	start := bytes.LastIndexByte(data[:offset], '\n') + 1
+	end := bytes.IndexByte(data[offset:], '\n')
+	if end < 0 {
+		end = len(data)
+	} else {
+		end += int(offset)
+	}
+	line := bytes.Count(data[:offset], []byte("\n")) + 1
+	column := len([]rune(string(data[start:offset]))) + 1
+
+	snippet := strings.TrimRight(string(data[start:end]), "\r")
+	// Baris yang sangat panjang dipotong agar tanda ^ tetap terbaca
+	if runes := []rune(snippet); len(runes) > 80 {
+		from := max(column-40, 0)
+		to := min(from+80, len(runes))
+		snippet = string(runes[from:to])
+		column -= from
+	}
+	return line, column, snippet
+}
+
+// Fungsi untuk menulis file data secara aman: isi lama yang masih valid disimpan sebagai
+// cadangan .bak, isi lama yang rusak disimpan terpisah agar tidak hilang, dan isi baru
+// ditulis ke file sementara lalu diganti namanya sehingga crash tidak meninggalkan file setengah jadi.
+// Isi yang sama dengan file sekarang tidak ditulis ulang agar cadangan tetap berisi versi sebelumnya.
+func writeFileSafely(path string, data []byte) error {
+	if old, err := os.ReadFile(path); err == nil && len(old) > 0 {
+		if bytes.Equal(old, data) {
+			return nil
+		}
+		target := path + backupSuffix
+		if !json.Valid(old) {
+			target = corruptPath(path)
+		}
+		if err := writeFileAtomic(target, old); err != nil {
+			return fmt.Errorf("gagal membuat cadangan %s: %w", path, err)
+		}
+	}
+	return writeFileAtomic(path, data)
+}
+
+// Fungsi untuk mengganti isi file secara atomik: isi ditulis ke file sementara di folder
+// yang sama, dipaksa sampai ke disk dengan fsync, baru diganti namanya. Jika listrik mati
+// di tengah penulisan, file lama tetap utuh dan yang tertinggal hanya file .tmp.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	file, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmp := file.Name()
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Chmod(tmp, 0644); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return fsyncDir(dir)
+}
+
+// Fungsi untuk memaksa isi folder (nama file hasil rename) sampai ke disk. Windows tidak
+// mendukung fsync folder sehingga dilewati.
+func fsyncDir(dir string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	folder, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer folder.Close()
+	return folder.Sync()
+}
+
+// Fungsi untuk membuat nama file penyimpan isi yang rusak, misalnya menu.json.rusak-20240101-153000
+func corruptPath(path string) string {
+	return path + ".rusak-" + time.Now().Format("20060102-150405")
+}
+
+// Fungsi untuk memulihkan file data dari cadangan .bak. File yang rusak dipindah ke
+// nama lain dan nama itu dikembalikan agar bisa diperiksa.
+func restoreFromBackup(path string) (string, error) {
+	backup, err := os.ReadFile(path + backupSuffix)
+	if err != nil {
+		return "", err
+	}
+	kept := corruptPath(path)
+	if err := os.Rename(path, kept); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+	return kept, writeFileAtomic(path, backup)
+}
+
+// Fungsi untuk memuat data saat program dimulai. Jika file data rusak dan ada cadangan,
+// kasir ditawari memulihkan dari cadangan lalu pemuatan diulang.
+func loadStateWithRecovery(reader *bufio.Reader) error {
+	for {
+		err := loadState()
+		if err == nil {
+			migrateStoredMoney()
+			return nil
+		}
+		var fileErr *DataFileError
+		if !errors.As(err, &fileErr) {
+			return err
+		}
+		info, statErr := os.Stat(fileErr.Path + backupSuffix)
+		if statErr != nil {
+			return err
+		}
+
+		fmt.Println("File data rusak:", err)
+		fmt.Printf("Pulihkan %s dari cadangan terakhir (%s)? (y/n): ", fileErr.Path, formatDateTime(info.ModTime()))
+		answer, _ := reader.ReadString('\n')
+		if strings.ToLower(strings.TrimSpace(answer)) != "y" {
+			return fmt.Errorf("%s belum dipulihkan, perbaiki manual atau salin dari %s", fileErr.Path, fileErr.Path+backupSuffix)
+		}
+		kept, restoreErr := restoreFromBackup(fileErr.Path)
+		if restoreErr != nil {
+			return fmt.Errorf("gagal memulihkan %s: %w", fileErr.Path, restoreErr)
+		}
+		logActivity(fmt.Sprintf("pulihkan %s dari cadangan, file rusak disimpan di %s", fileErr.Path, kept))
+		fmt.Printf("%s dipulihkan dari cadangan, file yang rusak disimpan di %s.\n", fileErr.Path, kept)
+	}
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Struct untuk ringkasan penutupan hari
+type DayClose struct {
+	ClosedAt  time.Time
+	Orders    int
+	Voided    int
+	Revenue   Money
+	Discounts Money
+	Refunds   Money
+	Waste     Money
+	// Nilai barang yang dikembalikan ke pemasok dengan harga beli PO
+	SupplierReturns Money
+	// Biaya cover dan top-up minimum belanja meja, terpisah dari pendapatan makanan
+	CoverCharges  Money
+	MinimumTopUps Money
+	// Rekonsiliasi laci kasir
+	Cash        CashSummary
+	CountedCash Money
+	// Pesanan yang masih di dapur saat penutupan dan dibawa ke hari berikutnya
+	CarriedOver []int
+}
+
+// Nama file riwayat penutupan hari di folder data, agar periode berjalan, rekonsiliasi
+// kas dan laporan tetap dihitung dari penutupan terakhir setelah program dijalankan ulang
+const dayCloseFile = "dayclose.json"
+
+// Riwayat penutupan hari, periode berjalan dimulai dari penutupan terakhir
+var dayCloses []DayClose
+var dayCloseMutex sync.Mutex
+
+// Fungsi untuk membaca riwayat penutupan hari dari file. Pada storage memory riwayat
+// hanya ada selama program berjalan.
+func loadDayCloses() error {
+	dayCloseMutex.Lock()
+	defer dayCloseMutex.Unlock()
+
+	dayCloses = nil
+	if currentConfig().Storage == StorageMemory {
+		return nil
+	}
+	_, err := readJSONFile(filepath.Join(currentConfig().DataDir, dayCloseFile), &dayCloses)
+	return err
+}
+
+// Fungsi untuk menambah penutupan hari lalu menyimpan riwayatnya ke file
+func appendDayClose(summary DayClose) {
+	dayCloseMutex.Lock()
+	defer dayCloseMutex.Unlock()
+
+	dayCloses = append(dayCloses, summary)
+	if currentConfig().Storage == StorageMemory {
+		return
+	}
+	if err := writeJSONFile(filepath.Join(currentConfig().DataDir, dayCloseFile), dayCloses); err != nil {
+		fmt.Println("Gagal menyimpan riwayat penutupan hari:", err)
+	}
+}
+
+// Fungsi untuk mengambil awal periode berjalan
+func currentPeriodStart() time.Time {
+	dayCloseMutex.Lock()
+	defer dayCloseMutex.Unlock()
+
+	if len(dayCloses) == 0 {
+		return time.Time{}
+	}
+	return dayCloses[len(dayCloses)-1].ClosedAt
+}
+
+// Fungsi untuk menutup hari, menampilkan ringkasan sejak penutupan terakhir, perlu PIN admin
+func closeDay(reader *bufio.Reader) {
+	if !requireAdminPIN(reader, "tutup hari") {
+		return
+	}
+
+	carried, ok := resolveStaleOrders(reader)
+	if !ok {
+		fmt.Println("Penutupan hari dibatalkan, selesaikan dulu pesanan yang masih di dapur.")
+		return
+	}
+	if pending := pendingAckOrders(); len(pending) > 0 {
+		fmt.Printf("Peringatan: %d pesanan belum dikonfirmasi dapur dan belum masuk pendapatan.\n", len(pending))
+	}
+
+	now := time.Now()
+	from := currentPeriodStart()
+	summary := summarizePeriod(from, now)
+	summary.Cash = summarizeCash(from, now)
+	summary.CarriedOver = carried
+
+	fmt.Printf("Uang di laci seharusnya %s. Jumlah uang hasil hitung: ", formatMoney(summary.Cash.Expected()))
+	countedInput, _ := reader.ReadString('\n')
+	counted, err := parseMoneyInput(strings.TrimSpace(countedInput))
+	if err != nil || counted < 0 {
+		fmt.Println("Jumlah uang harus berupa angka.")
+		return
+	}
+	summary.CountedCash = counted
+
+	appendDayClose(summary)
+
+	// Ringkasan memakai lebar receipt_paper agar bisa dicetak sebagai laporan Z
+	l := newReceiptLayout()
+	l.Blank()
+	l.Title("Penutupan Hari")
+	l.Pair("Ditutup", formatDateTime(summary.ClosedAt))
+	l.Pair("Jumlah Pesanan", fmt.Sprintf("%d (dibatalkan: %d)", summary.Orders, summary.Voided))
+	l.Pair("Pendapatan", formatMoney(summary.Revenue))
+	writeTableCharges(l, summary.CoverCharges, summary.MinimumTopUps)
+	l.Pair("Diskon", formatMoney(summary.Discounts))
+	l.Pair("Refund", formatMoney(summary.Refunds))
+	l.Pair("Nilai Waste", formatMoney(summary.Waste))
+	l.Pair("Retur Pemasok", formatMoney(summary.SupplierReturns))
+	writeCashSummary(l, summary.Cash, from)
+	l.Pair("Uang Dihitung", formatMoney(summary.CountedCash))
+	l.Pair("Selisih", formatMoney(summary.CountedCash-summary.Cash.Expected()))
+	if len(summary.CarriedOver) > 0 {
+		l.Pair("Dibawa ke Hari Berikutnya", joinOrderIDs(summary.CarriedOver))
+	}
+	fmt.Print(l.String())
+
+	activityMutex.Lock()
+	cashier := currentCashier
+	activityMutex.Unlock()
+	closeShift(cashier, "tutup hari")
+
+	if count, err := archiveOldOrders(now); err != nil {
+		fmt.Println("Gagal mengarsipkan pesanan lama:", err)
+	} else if count > 0 {
+		fmt.Printf("%d pesanan lama dipindahkan ke arsip.\n", count)
+	}
+	expireSpecials()
+}
+
+// Fungsi untuk mengambil pesanan yang masih tertahan, antri atau diproses di dapur.
+// Baris terjadwal tidak dihitung karena pesanan terjadwal memang menunggu waktu ambil.
+func staleOrders() []Order {
+	ordersMutex.Lock()
+	defer ordersMutex.Unlock()
+
+	var stale []Order
+	for _, order := range orders {
+		if order.Voided || order.MergedInto != 0 {
+			continue
+		}
+		for _, line := range order.Lines {
+			if line.Status == LineHeld || line.Status == LineQueued || line.Status == LinePreparing {
+				stale = append(stale, copyOrder(*order))
+				break
+			}
+		}
+	}
+	return stale
+}
+
+// Fungsi untuk meminta manajer menyelesaikan setiap pesanan yang masih di dapur sebelum
+// laporan Z dibuat: diselesaikan, dibatalkan atau dibawa ke hari berikutnya. Mengembalikan
+// ID pesanan yang dibawa dan false jika ada pesanan yang belum diputuskan.
+func resolveStaleOrders(reader *bufio.Reader) ([]int, bool) {
+	stale := staleOrders()
+	if len(stale) == 0 {
+		return nil, true
+	}
+
+	fmt.Printf("\n%d pesanan masih di dapur dan harus diputuskan sebelum tutup hari.\n", len(stale))
+	var carried []int
+	for _, order := range stale {
+		var pending []OrderLine
+		for _, line := range order.Lines {
+			if line.Status == LineHeld || line.Status == LineQueued || line.Status == LinePreparing {
+				pending = append(pending, line)
+			}
+		}
+		fmt.Printf("\nPesanan ID %d%s%s | Dibuat: %s\n", order.ID, describePickupCode(order.PickupCode), describeTable(order.Table, order.Delivery), formatDateTime(order.CreatedAt))
+		for _, line := range pending {
+			fmt.Printf("  %d. %s x%d | Status: %s\n", line.No, line.ItemName, line.Quantity, line.Status.Label())
+		}
+
+		for {
+			fmt.Print("Putuskan (s = selesaikan, v = batalkan, b = bawa ke hari berikutnya, kosongkan untuk berhenti): ")
+			input, _ := reader.ReadString('\n')
+			switch strings.ToLower(strings.TrimSpace(input)) {
+			case "":
+				return nil, false
+			case "s":
+				completeStaleOrder(order.ID)
+				logActivity(fmt.Sprintf("tutup hari: selesaikan pesanan %d", order.ID))
+				fmt.Printf("Pesanan ID %d ditandai selesai.\n", order.ID)
+			case "v":
+				id, err := cancelOrder(order.ID)
+				if err != nil {
+					fmt.Println(err)
+					continue
+				}
+				logActivity(fmt.Sprintf("tutup hari: void pesanan %d", id))
+				fmt.Printf("Pesanan ID %d dibatalkan.\n", id)
+			case "b":
+				carried = append(carried, order.ID)
+				logActivity(fmt.Sprintf("tutup hari: bawa pesanan %d ke hari berikutnya", order.ID))
+				fmt.Printf("Pesanan ID %d dibawa ke hari berikutnya.\n", order.ID)
+			default:
+				fmt.Println("Pilihan tidak valid.")
+				continue
+			}
+			break
+		}
+	}
+	return carried, true
+}
+
+// Fungsi untuk menandai semua baris pesanan yang masih di dapur sebagai selesai. Baris
+// yang belum sempat diproses dicatat sebagai pendapatan seperti saat masuk dapur.
+func completeStaleOrder(id int) {
+	ordersMutex.Lock()
+	order := findOrder(id)
+	if order == nil {
+		ordersMutex.Unlock()
+		return
+	}
+	now := time.Now()
+	var revenue Money
+	for i := range order.Lines {
+		line := &order.Lines[i]
+		switch line.Status {
+		case LineHeld, LineQueued:
+			if !order.PendingAck {
+				revenue += line.TotalPrice
+			}
+		case LinePreparing:
+		default:
+			continue
+		}
+		line.Status = LineDone
+		line.FireAt = time.Time{}
+		line.ReadyAt = now
+		publishLineStatus(order.ID, *line)
+	}
+	completeOrderIfDone(order)
+	ordersMutex.Unlock()
+
+	armCourseTimer(id, time.Time{})
+	recognizeRevenue(revenue)
+}
+
+// Fungsi untuk merangkum pesanan, refund dan waste dalam satu periode
+func summarizePeriod(from, to time.Time) DayClose {
+	summary := DayClose{ClosedAt: to}
+
+	ordersMutex.Lock()
+	for _, order := range orders {
+		if order.CreatedAt.Before(from) || !order.CreatedAt.Before(to) {
+			continue
+		}
+		summary.Orders++
+		if order.Voided {
+			summary.Voided++
+			continue
+		}
+		summary.Revenue += orderRevenue(order)
+		summary.Discounts += order.Discount
+		if order.Paid {
+			summary.CoverCharges += order.CoverCharge
+			summary.MinimumTopUps += order.MinimumSpendTopUp
+		}
+	}
+	ordersMutex.Unlock()
+
+	returnsMutex.Lock()
+	ensureReturnsLoaded()
+	for _, record := range returns {
+		if record.Refunded && !record.Time.Before(from) && record.Time.Before(to) {
+			summary.Refunds += record.Amount
+		}
+	}
+	returnsMutex.Unlock()
+
+	wasteMutex.Lock()
+	ensureWasteLoaded()
+	for _, record := range wasteLog {
+		if !record.Time.Before(from) && record.Time.Before(to) {
+			summary.Waste += record.UnitCost.Times(record.Quantity)
+		}
+	}
+	wasteMutex.Unlock()
+
+	summary.SupplierReturns = supplierReturnValue(from, to)
+	return summary
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Fungsi untuk memakai penyimpanan JSON di folder sementara selama satu test, tanpa PIN
+// admin, webhook, sinkronisasi, email atau notifikasi. Mengembalikan folder datanya.
+func useJSONStorage(t *testing.T) string {
+	t.Helper()
+	previous := currentConfig()
+	cfg := defaultConfig()
+	cfg.Storage = StorageJSON
+	cfg.DataDir = t.TempDir()
+	cfg.AdminPIN = ""
+	cfg.Hooks = nil
+	cfg.Sync = SyncConfig{}
+	cfg.SMTP = SMTPConfig{}
+	cfg.Notify = NotifyConfig{}
+	configMutex.Lock()
+	config = cfg
+	configMutex.Unlock()
+
+	previousMenuRepo, previousOrderRepo, previousShared := menuRepo, orderRepo, sharedStore
+	if err := openStorage(cfg); err != nil {
+		t.Fatalf("openStorage: %v", err)
+	}
+	useOrders(t, nil, nil, 0)
+	forgetSideData()
+
+	t.Cleanup(func() {
+		configMutex.Lock()
+		config = previous
+		configMutex.Unlock()
+		menuRepo, orderRepo, sharedStore = previousMenuRepo, previousOrderRepo, previousShared
+		forgetSideData()
+	})
+	return cfg.DataDir
+}
+
+// Fungsi untuk mengosongkan riwayat penutupan hari dan catatan kas di memori seperti
+// saat program baru dijalankan, agar dibaca ulang dari folder data
+func forgetSideData() {
+	dayCloseMutex.Lock()
+	dayCloses = nil
+	dayCloseMutex.Unlock()
+	cashMutex.Lock()
+	cashEntries, cashLoaded = nil, false
+	cashMutex.Unlock()
+}
+
+// Fungsi untuk memuat ulang data dari penyimpanan seperti saat program dijalankan ulang
+func reloadState(t *testing.T) {
+	t.Helper()
+	forgetSideData()
+	if err := openStorage(currentConfig()); err != nil {
+		t.Fatalf("openStorage: %v", err)
+	}
+	if err := loadState(); err != nil {
+		t.Fatalf("loadState: %v", err)
+	}
+}
+
+func TestDayCloseSurvivesRestart(t *testing.T) {
+	dir := useJSONStorage(t)
+	if start := currentPeriodStart(); !start.IsZero() {
+		t.Fatalf("awal periode sebelum tutup hari = %v, ingin kosong", start)
+	}
+
+	closeDay(bufio.NewReader(strings.NewReader("0\n")))
+	closed := currentPeriodStart()
+	if closed.IsZero() {
+		t.Fatal("tutup hari tidak tercatat")
+	}
+	if _, err := os.Stat(filepath.Join(dir, dayCloseFile)); err != nil {
+		t.Fatalf("riwayat penutupan hari tidak tersimpan: %v", err)
+	}
+
+	reloadState(t)
+	if start := currentPeriodStart(); !start.Equal(closed) {
+		t.Errorf("awal periode setelah dijalankan ulang = %v, ingin %v", start, closed)
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Menu awal yang ikut dikompilasi ke dalam program. Bisa diganti tanpa
+// kompilasi ulang lewat pengaturan default_menu_file di config.json.
+//
+//go:embed default_menu.csv
+var defaultMenuData []byte
+
+// Kolom yang wajib ada pada file CSV menu awal
+var menuCSVColumns = []string{"name", "price", "quantity", "station"}
+
+// Fungsi untuk memuat menu awal dari file di konfigurasi, atau dari menu bawaan
+// jika file tidak diatur. File boleh berformat CSV atau JSON.
+func loadDefaultMenu(path string) ([]MenuItem, error) {
+	if path == "" {
+		return parseMenuCSV(bytes.NewReader(defaultMenuData))
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		var items []MenuItem
+		found, err := readJSONFile(path, &items)
+		if err == nil && !found {
+			err = fmt.Errorf("%s tidak ditemukan", path)
+		}
+		return items, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := parseMenuCSV(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return items, nil
+}
+
+// Fungsi untuk membaca menu dari CSV dengan header name,price,quantity,station serta
+// kolom cost, course dan parent (nama item induk untuk varian) yang opsional
+func parseMenuCSV(r io.Reader) ([]MenuItem, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("header CSV tidak bisa dibaca: %w", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range menuCSVColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("kolom %q tidak ada di header CSV", name)
+		}
+	}
+
+	var items []MenuItem
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		item, err := parseMenuCSVRecord(record, columns)
+		if err != nil {
+			return nil, fmt.Errorf("baris %d: %w", line, err)
+		}
+
+		// Baris dengan kolom parent menjadi varian dari item induk yang ditulis sebelumnya
+		parentName := ""
+		if i, ok := columns["parent"]; ok && i < len(record) {
+			parentName = strings.TrimSpace(record[i])
+		}
+		if parentName == "" {
+			items = append(items, item)
+			continue
+		}
+		parent := findMenuItemIn(items, parentName)
+		if parent == nil {
+			return nil, fmt.Errorf("baris %d: item induk %q belum ditulis", line, parentName)
+		}
+		item.Name = variantName(parent.Name, item.Name)
+		parent.Variants = append(parent.Variants, item)
+	}
+	return items, nil
+}
+
+// Fungsi untuk mengubah satu baris CSV menjadi item menu
+func parseMenuCSVRecord(record []string, columns map[string]int) (MenuItem, error) {
+	field := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	item := MenuItem{Name: field("name")}
+	if item.Name == "" {
+		return MenuItem{}, errors.New("nama item kosong")
+	}
+
+	var err error
+	if item.Price, err = parseMoney(field("price")); err != nil || item.Price < 0 {
+		return MenuItem{}, fmt.Errorf("harga %q tidak valid", field("price"))
+	}
+	if item.Quantity, err = strconv.Atoi(field("quantity")); err != nil || item.Quantity < 0 {
+		return MenuItem{}, fmt.Errorf("stok %q tidak valid", field("quantity"))
+	}
+	station, ok := parseStation(field("station"))
+	if !ok {
+		return MenuItem{}, fmt.Errorf("stasiun %q tidak dikenal", field("station"))
+	}
+	item.Station = station
+	item.Course = strings.ToLower(field("course"))
+	if cost := field("cost"); cost != "" {
+		if item.Cost, err = parseMoney(cost); err != nil || item.Cost < 0 {
+			return MenuItem{}, fmt.Errorf("harga pokok %q tidak valid", cost)
+		}
+	}
+	return item, nil
+}
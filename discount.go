@@ -0,0 +1,232 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Cara manajer menyetujui diskon
+type ApprovalMethod string
+
+const (
+	ApprovalPIN      ApprovalMethod = "pin"
+	ApprovalTerminal ApprovalMethod = "terminal"
+)
+
+// Fungsi untuk menampilkan cara persetujuan di layar
+func (m ApprovalMethod) Label() string {
+	switch m {
+	case ApprovalTerminal:
+		return "dari terminal lain"
+	default:
+		return "PIN di terminal kasir"
+	}
+}
+
+// Struct untuk persetujuan manajer atas diskon di atas batas, disimpan bersama pesanan
+type DiscountApproval struct {
+	Amount      Money          `json:"amount"`
+	Percent     float64        `json:"percent"`
+	RequestedBy string         `json:"requested_by"`
+	Method      ApprovalMethod `json:"method"`
+	// Kasir yang login di terminal manajer, kosong jika disetujui dengan PIN di terminal kasir
+	ApprovedBy string    `json:"approved_by,omitempty"`
+	ApprovedAt time.Time `json:"approved_at"`
+}
+
+// Struct untuk permintaan diskon yang menunggu persetujuan dari terminal lain
+type DiscountRequest struct {
+	ID          int
+	OrderID     int
+	Amount      Money
+	Percent     float64
+	RequestedBy string
+	RequestedAt time.Time
+}
+
+// Permintaan diskon yang belum disetujui atau ditolak, hanya dipakai di mode daemon
+var discountRequests []DiscountRequest
+var lastDiscountRequestID int
+var discountRequestMutex sync.Mutex
+
+// Bernilai true saat program berjalan sebagai daemon sehingga manajer bisa menyetujui
+// dari terminal lain
+var daemonRunning atomic.Bool
+
+// Fungsi untuk memeriksa apakah diskon melebihi batas persentase yang perlu persetujuan
+func discountNeedsApproval(discount, total Money) bool {
+	return total > 0 && discount.Float()/total.Float()*100 > currentConfig().DiscountPINThreshold
+}
+
+// Fungsi untuk meminta persetujuan manajer atas diskon di atas batas. Di mode daemon kasir
+// bisa mengirim permintaan ke terminal manajer; diskon baru diberikan setelah disetujui
+// sehingga fungsi ini mengembalikan false.
+func requestDiscountApproval(reader *bufio.Reader, id int, discount, total Money) (DiscountApproval, bool) {
+	activityMutex.Lock()
+	cashier := currentCashier
+	activityMutex.Unlock()
+	approval := DiscountApproval{Amount: discount, Percent: discount.Float() / total.Float() * 100, RequestedBy: cashier, Method: ApprovalPIN}
+
+	fmt.Printf("Diskon %s melebihi batas %s dan perlu persetujuan manajer.\n", formatPercent(approval.Percent), formatPercent(currentConfig().DiscountPINThreshold))
+	if daemonRunning.Load() {
+		fmt.Print("Persetujuan (1 = PIN manajer di sini, 2 = minta dari terminal manajer): ")
+		choice, _ := reader.ReadString('\n')
+		switch strings.TrimSpace(choice) {
+		case "1":
+		case "2":
+			discountRequestMutex.Lock()
+			lastDiscountRequestID++
+			request := DiscountRequest{ID: lastDiscountRequestID, OrderID: id, Amount: discount, Percent: approval.Percent, RequestedBy: cashier, RequestedAt: time.Now()}
+			discountRequests = append(discountRequests, request)
+			discountRequestMutex.Unlock()
+
+			logActivity(fmt.Sprintf("minta persetujuan diskon %s pesanan %d", formatMoney(discount), id))
+			notify("Permintaan diskon", fmt.Sprintf("%s meminta diskon %s untuk pesanan %d", cashier, formatMoney(discount), id))
+			fmt.Printf("Permintaan #%d dikirim, diskon diberikan setelah disetujui manajer lewat opsi 44.\n", request.ID)
+			return approval, false
+		default:
+			fmt.Println("Pilihan tidak valid.")
+			return approval, false
+		}
+	}
+
+	if !requireAdminPIN(reader, fmt.Sprintf("diskon %s pesanan %d", formatMoney(discount), id)) {
+		return approval, false
+	}
+	approval.ApprovedAt = time.Now()
+	return approval, true
+}
+
+// Fungsi untuk memberikan diskon ke pesanan yang belum dibayar beserta persetujuannya.
+// Persetujuan kosong berarti diskon di bawah batas, persetujuan lama ikut dihapus.
+func applyDiscount(id int, discount Money, approval DiscountApproval) (*Order, error) {
+	ordersMutex.Lock()
+	order := followMergedOrder(findOrder(id))
+	if order == nil {
+		ordersMutex.Unlock()
+		return nil, fmt.Errorf("pesanan %d tidak ditemukan", id)
+	}
+	if order.Paid || order.Voided {
+		ordersMutex.Unlock()
+		return nil, fmt.Errorf("pesanan %d sudah dibayar atau dibatalkan", order.ID)
+	}
+	if discount > order.TotalPrice {
+		ordersMutex.Unlock()
+		return nil, fmt.Errorf("diskon %s melebihi total pesanan %d", formatMoney(discount), order.ID)
+	}
+	previous := order.Discount
+	order.Discount = discount
+	order.DiscountApproval = approval
+	pending := order.PendingAck
+	ordersMutex.Unlock()
+
+	if !pending {
+		totalMutex.Lock()
+		totalAllOrders -= discount - previous
+		totalMutex.Unlock()
+	}
+	return order, nil
+}
+
+// Fungsi untuk menampilkan keterangan persetujuan diskon, kosong jika tidak ada
+func describeDiscountApproval(approval DiscountApproval) string {
+	if approval.ApprovedAt.IsZero() {
+		return ""
+	}
+	text := fmt.Sprintf("disetujui %s %s", approval.Method.Label(), formatDateTime(approval.ApprovedAt))
+	if approval.ApprovedBy != "" {
+		text += " oleh " + approval.ApprovedBy
+	}
+	return text + ", diminta " + approval.RequestedBy
+}
+
+// Fungsi untuk menampilkan permintaan diskon yang menunggu dan menyetujui atau
+// menolaknya dari terminal manajer, perlu PIN admin
+func discountApprovalMenu(reader *bufio.Reader) {
+	discountRequestMutex.Lock()
+	pending := append([]DiscountRequest(nil), discountRequests...)
+	discountRequestMutex.Unlock()
+
+	if len(pending) == 0 {
+		if !daemonRunning.Load() {
+			fmt.Println("Persetujuan dari terminal lain hanya tersedia di mode daemon.")
+			return
+		}
+		fmt.Println("Tidak ada permintaan diskon yang menunggu persetujuan.")
+		return
+	}
+
+	fmt.Println("\n===== Permintaan Diskon =====")
+	for _, request := range pending {
+		fmt.Printf("#%d | Pesanan ID %d | %s (%s) | %s | %s\n", request.ID, request.OrderID, formatMoney(request.Amount), formatPercent(request.Percent), request.RequestedBy, request.RequestedAt.Format("15:04"))
+	}
+	fmt.Print("Pilih nomor permintaan (kosongkan untuk kembali): ")
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimPrefix(strings.TrimSpace(input), "#")
+	if input == "" {
+		return
+	}
+	number, err := strconv.Atoi(input)
+	if err != nil {
+		fmt.Println("Nomor permintaan harus berupa angka.")
+		return
+	}
+
+	fmt.Print("Setujui diskon? (y = setujui, n = tolak): ")
+	answer, _ := reader.ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	if answer != "y" && answer != "n" {
+		fmt.Println("Jawaban harus y atau n.")
+		return
+	}
+	if !requireAdminPIN(reader, fmt.Sprintf("persetujuan diskon #%d", number)) {
+		return
+	}
+
+	// Permintaan diambil setelah PIN benar agar tidak disetujui dua manajer sekaligus
+	discountRequestMutex.Lock()
+	var request DiscountRequest
+	found := false
+	for i, candidate := range discountRequests {
+		if candidate.ID == number {
+			request, found = candidate, true
+			discountRequests = append(discountRequests[:i], discountRequests[i+1:]...)
+			break
+		}
+	}
+	discountRequestMutex.Unlock()
+	if !found {
+		fmt.Println("Permintaan tidak ditemukan atau sudah diproses.")
+		return
+	}
+
+	if answer == "n" {
+		logActivity(fmt.Sprintf("tolak diskon %s pesanan %d dari %s", formatMoney(request.Amount), request.OrderID, request.RequestedBy))
+		fmt.Printf("Permintaan #%d ditolak.\n", request.ID)
+		return
+	}
+
+	activityMutex.Lock()
+	manager := currentCashier
+	activityMutex.Unlock()
+	approval := DiscountApproval{
+		Amount:      request.Amount,
+		Percent:     request.Percent,
+		RequestedBy: request.RequestedBy,
+		Method:      ApprovalTerminal,
+		ApprovedBy:  manager,
+		ApprovedAt:  time.Now(),
+	}
+	order, err := applyDiscount(request.OrderID, request.Amount, approval)
+	if err != nil {
+		fmt.Printf("Diskon tidak bisa diberikan: %v.\n", err)
+		return
+	}
+	logActivity(fmt.Sprintf("setujui diskon %s pesanan %d dari %s", formatMoney(request.Amount), order.ID, request.RequestedBy))
+	fmt.Printf("Diskon %s untuk pesanan ID %d disetujui.\n", formatMoney(request.Amount), order.ID)
+}
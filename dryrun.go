@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Opsi menu utama yang bisa dijalankan dengan --dry-run: buat pesanan, ulangi pesanan,
+// restock, penerimaan purchase order, ubah item, hapus item dan kelola varian
+var dryRunOptions = map[string]bool{
+	"2": true, "7": true, "12": true, "14": true, "18": true, "20": true, "28": true, "39": true,
+}
+
+// Status dry-run. Selama dry-run perubahan hanya terjadi di memori dan penyimpanan
+// menu diganti salinan sementara, lalu semuanya dikembalikan setelah perintah selesai.
+var dryRun atomic.Bool
+var dryRunRepo MenuRepository
+
+// Mutex yang dipegang selama dry-run agar penjadwal tidak mengubah stok di tengah perintah
+var dryRunMutex sync.Mutex
+
+// Salinan keadaan sebelum perintah dry-run dijalankan
+type dryRunSnapshot struct {
+	menu                []MenuItem
+	ledgerLen           int
+	suppliers           []Supplier
+	purchaseOrders      []PurchaseOrder
+	nextSupplierID      int
+	nextPurchaseOrderID int
+	supplierReturnsLen  int
+}
+
+// Fungsi untuk memisahkan --dry-run dari argumen perintah
+func cutDryRunFlag(args string) (string, bool) {
+	var rest []string
+	found := false
+	for _, word := range strings.Fields(args) {
+		if word == "--dry-run" || word == "-dry-run" {
+			found = true
+			continue
+		}
+		rest = append(rest, word)
+	}
+	if !found {
+		return args, false
+	}
+	return strings.Join(rest, " "), true
+}
+
+// Fungsi untuk memeriksa apakah dry-run sedang berjalan
+func dryRunActive() bool {
+	return dryRun.Load()
+}
+
+// Fungsi untuk memilih repository menu, salinan sementara selama dry-run
+func activeMenuRepo() MenuRepository {
+	if dryRunActive() {
+		return dryRunRepo
+	}
+	return menuRepo
+}
+
+// Fungsi untuk menyimpan keadaan sekarang lalu memulai dry-run
+func beginDryRun() dryRunSnapshot {
+	dryRunMutex.Lock()
+
+	var snapshot dryRunSnapshot
+	menuMutex.Lock()
+	snapshot.menu = make([]MenuItem, len(menu))
+	for i, item := range menu {
+		snapshot.menu[i] = copyMenuItem(item)
+	}
+	repoMenu := make([]MenuItem, len(menu))
+	for i, item := range menu {
+		repoMenu[i] = copyMenuItem(item)
+	}
+	menuMutex.Unlock()
+
+	// Data yang disimpan di file dibaca dulu agar pemotongan setelah dry-run tidak ikut membuangnya
+	ledgerMutex.Lock()
+	ensureLedgerLoaded()
+	snapshot.ledgerLen = len(ledger)
+	ledgerMutex.Unlock()
+
+	purchasingMutex.Lock()
+	ensurePurchasingLoaded()
+	snapshot.suppliers = append([]Supplier(nil), suppliers...)
+	for _, po := range purchaseOrders {
+		copied := *po
+		copied.Lines = append([]PurchaseOrderLine(nil), po.Lines...)
+		snapshot.purchaseOrders = append(snapshot.purchaseOrders, copied)
+	}
+	snapshot.nextSupplierID = nextSupplierID
+	snapshot.nextPurchaseOrderID = nextPurchaseOrderID
+	snapshot.supplierReturnsLen = len(supplierReturns)
+	purchasingMutex.Unlock()
+
+	dryRunRepo = &memoryStore{menu: repoMenu}
+	dryRun.Store(true)
+	fmt.Println("[DRY-RUN] Perubahan tidak akan disimpan.")
+	return snapshot
+}
+
+// Fungsi untuk menampilkan semua perubahan selama dry-run lalu mengembalikan keadaan semula
+func endDryRun(snapshot dryRunSnapshot) {
+	defer dryRunMutex.Unlock()
+
+	menuMutex.Lock()
+	after := make([]MenuItem, len(menu))
+	for i, item := range menu {
+		after[i] = copyMenuItem(item)
+	}
+	menu = snapshot.menu
+	invalidateMenuCache()
+	menuMutex.Unlock()
+
+	ledgerMutex.Lock()
+	movements := append([]StockMovement(nil), ledger[snapshot.ledgerLen:]...)
+	ledger = ledger[:snapshot.ledgerLen]
+	ledgerMutex.Unlock()
+
+	purchasingMutex.Lock()
+	addedSuppliers := len(suppliers) - len(snapshot.suppliers)
+	addedPurchaseOrders := len(purchaseOrders) - len(snapshot.purchaseOrders)
+	suppliers = snapshot.suppliers
+	purchaseOrders = nil
+	for i := range snapshot.purchaseOrders {
+		purchaseOrders = append(purchaseOrders, &snapshot.purchaseOrders[i])
+	}
+	nextSupplierID = snapshot.nextSupplierID
+	nextPurchaseOrderID = snapshot.nextPurchaseOrderID
+	supplierReturns = supplierReturns[:snapshot.supplierReturnsLen]
+	purchasingMutex.Unlock()
+
+	dryRun.Store(false)
+	dryRunRepo = nil
+
+	fmt.Println("\n[DRY-RUN] Perubahan yang akan terjadi:")
+	diff := diffMenus(snapshot.menu, after)
+	if len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Changed) == 0 {
+		fmt.Println("Menu dan stok tidak berubah.")
+	} else {
+		printMenuDiff(diff)
+	}
+	for _, movement := range movements {
+		fmt.Printf("Mutasi stok %s %+d (%s, %s)\n", movement.ItemName, movement.Change, movement.Kind.Label(), movement.Reference)
+	}
+	if addedSuppliers > 0 {
+		fmt.Printf("%d pemasok baru.\n", addedSuppliers)
+	}
+	if addedPurchaseOrders > 0 {
+		fmt.Printf("%d purchase order baru.\n", addedPurchaseOrders)
+	}
+	fmt.Println("[DRY-RUN] Tidak ada yang disimpan.")
+}
+
+// Fungsi untuk menampilkan pesanan yang akan dibuat tanpa mencatat atau mengirimnya ke dapur
+func describeDryRunOrder(order *Order) {
+	fmt.Printf("[DRY-RUN] Pesanan ID %d%s: %s\n", order.ID, describeTable(order.Table, order.Delivery), describeLines(order.Lines))
+	fmt.Printf("Total %s, pajak %s, yang harus dibayar %s\n", formatMoney(order.TotalPrice), formatMoney(order.Tax()), formatMoney(order.AmountDue()))
+}
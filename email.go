@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"net/mail"
+	"net/smtp"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Nama file antrian email yang gagal dikirim, disimpan di folder data
+const emailQueueFile = "email_queue.json"
+
+// Jeda minimum sebelum email yang gagal dicoba lagi
+const emailRetryInterval = time.Minute
+
+// Struct untuk email struk yang menunggu dikirim ulang
+type PendingEmail struct {
+	To          string    `json:"to"`
+	Subject     string    `json:"subject"`
+	Body        string    `json:"body"`
+	Attempts    int       `json:"attempts"`
+	LastAttempt time.Time `json:"last_attempt"`
+	LastError   string    `json:"last_error"`
+}
+
+// Antrian email yang gagal dikirim
+var emailQueue []PendingEmail
+var emailQueueLoaded bool
+var emailMutex sync.Mutex
+
+// Fungsi untuk memeriksa apakah pengiriman email diaktifkan
+func smtpConfigured() bool {
+	return currentConfig().SMTP.Host != ""
+}
+
+// Fungsi untuk mengirim satu email lewat server SMTP di konfigurasi
+func sendEmail(to, subject, body string) error {
+	smtpConfig := currentConfig().SMTP
+	var auth smtp.Auth
+	if smtpConfig.Username != "" {
+		auth = smtp.PlainAuth("", smtpConfig.Username, smtpConfig.Password, smtpConfig.Host)
+	}
+
+	message := "From: " + smtpConfig.From + "\r\n" +
+		"To: " + to + "\r\n" +
+		"Subject: " + subject + "\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n" +
+		"\r\n" + strings.ReplaceAll(body, "\n", "\r\n")
+
+	addr := smtpConfig.Host + ":" + strconv.Itoa(smtpConfig.Port)
+	return smtp.SendMail(addr, auth, smtpConfig.From, []string{to}, []byte(message))
+}
+
+// Fungsi untuk mencatat email pelanggan pada pesanan lalu mengirim struknya,
+// email yang gagal masuk antrian untuk dicoba lagi
+func emailReceipt(orderID int, email string) {
+	address, err := mail.ParseAddress(email)
+	if err != nil {
+		fmt.Println("Alamat email tidak valid.")
+		return
+	}
+
+	ordersMutex.Lock()
+	order := findOrder(orderID)
+	if order == nil {
+		ordersMutex.Unlock()
+		fmt.Println("Pesanan tidak ditemukan.")
+		return
+	}
+	order.CustomerEmail = address.Address
+	body := formatReceipt(order)
+	ordersMutex.Unlock()
+
+	subject := fmt.Sprintf("Struk Pesanan ID %d", orderID)
+	if err := sendEmail(address.Address, subject, body); err != nil {
+		queueEmail(PendingEmail{To: address.Address, Subject: subject, Body: body, Attempts: 1, LastAttempt: time.Now(), LastError: err.Error()})
+		fmt.Println("Gagal mengirim struk, email dimasukkan ke antrian untuk dicoba lagi:", err)
+		return
+	}
+	fmt.Printf("Struk dikirim ke %s.\n", address.Address)
+}
+
+// Fungsi untuk mengirim ulang struk pesanan yang sudah ada lewat email
+func resendReceipt(reader *bufio.Reader) {
+	if !smtpConfigured() {
+		fmt.Println("SMTP belum dikonfigurasi.")
+		return
+	}
+
+	id, ok := readOrderID(reader)
+	if !ok {
+		return
+	}
+
+	ordersMutex.Lock()
+	order := followMergedOrder(findOrder(id))
+	if order != nil {
+		id = order.ID
+	}
+	var saved string
+	if order != nil {
+		saved = order.CustomerEmail
+	}
+	ordersMutex.Unlock()
+	if order == nil {
+		fmt.Println("Pesanan tidak ditemukan.")
+		return
+	}
+
+	if saved != "" {
+		fmt.Printf("Email pelanggan (kosongkan untuk %s): ", saved)
+	} else {
+		fmt.Print("Email pelanggan: ")
+	}
+	email, _ := reader.ReadString('\n')
+	if email = strings.TrimSpace(email); email == "" {
+		email = saved
+	}
+	if email == "" {
+		fmt.Println("Email pelanggan wajib diisi.")
+		return
+	}
+
+	emailReceipt(id, email)
+}
+
+// Fungsi untuk memuat antrian email dari file sekali saja, pemanggil harus memegang emailMutex.
+// Antrian juga dipakai penjadwal, jadi kegagalan ditulis lewat asyncPrintf.
+func ensureEmailQueueLoaded() {
+	if emailQueueLoaded {
+		return
+	}
+	emailQueueLoaded = true
+	if _, err := readJSONFile(filepath.Join(currentConfig().DataDir, emailQueueFile), &emailQueue); err != nil {
+		asyncPrintf("Gagal membaca antrian email: %v\n", err)
+	}
+}
+
+// Fungsi untuk menyimpan antrian email ke file, pemanggil harus memegang emailMutex.
+// Kegagalan ditulis lewat asyncPrintf seperti ensureEmailQueueLoaded.
+func saveEmailQueue() {
+	if err := writeJSONFile(filepath.Join(currentConfig().DataDir, emailQueueFile), emailQueue); err != nil {
+		asyncPrintf("Gagal menyimpan antrian email: %v\n", err)
+	}
+}
+
+// Fungsi untuk menambahkan email ke antrian kirim ulang
+func queueEmail(pending PendingEmail) {
+	emailMutex.Lock()
+	defer emailMutex.Unlock()
+
+	ensureEmailQueueLoaded()
+	emailQueue = append(emailQueue, pending)
+	saveEmailQueue()
+}
+
+// Fungsi untuk mencoba lagi email yang gagal, dipanggil berkala oleh penjadwal
+func retryPendingEmails(now time.Time) {
+	if !smtpConfigured() {
+		return
+	}
+
+	emailMutex.Lock()
+	defer emailMutex.Unlock()
+
+	ensureEmailQueueLoaded()
+	if len(emailQueue) == 0 {
+		return
+	}
+
+	remaining := emailQueue[:0]
+	for _, pending := range emailQueue {
+		if now.Sub(pending.LastAttempt) < emailRetryInterval {
+			remaining = append(remaining, pending)
+			continue
+		}
+
+		if err := sendEmail(pending.To, pending.Subject, pending.Body); err != nil {
+			pending.Attempts++
+			pending.LastAttempt = now
+			pending.LastError = err.Error()
+			remaining = append(remaining, pending)
+			continue
+		}
+		asyncPrintf("\nStruk tertunda terkirim ke %s.\n", pending.To)
+	}
+	emailQueue = remaining
+	saveEmailQueue()
+}
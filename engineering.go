@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Kelompok item pada matriks menu engineering
+const (
+	EngineeringStar      = "Star"
+	EngineeringPlowhorse = "Plowhorse"
+	EngineeringPuzzle    = "Puzzle"
+	EngineeringDog       = "Dog"
+)
+
+// Item dengan penjualan di bawah persentase ini dari rata-rata dianggap kurang populer
+const popularityFactor = 0.7
+
+// Hasil analisis satu item menu
+type engineeringItem struct {
+	Name   string
+	Sold   int
+	Margin Money
+	Class  string
+}
+
+// Fungsi untuk menggolongkan item menjadi star/plowhorse/puzzle/dog berdasarkan
+// margin kontribusi (harga jual dikurangi harga pokok) dan jumlah terjual
+func displayMenuEngineering(reader *bufio.Reader) {
+	from, ok := readOptionalDate(reader, "Dari tanggal (YYYY-MM-DD, kosongkan untuk semua): ")
+	if !ok {
+		return
+	}
+	to, ok := readOptionalDate(reader, "Sampai tanggal (YYYY-MM-DD, kosongkan untuk semua): ")
+	if !ok {
+		return
+	}
+	if !to.IsZero() {
+		to = to.AddDate(0, 0, 1)
+	}
+	fmt.Print("Sertakan pesanan arsip? (y/n): ")
+	archiveInput, _ := reader.ReadString('\n')
+
+	reportOrders, err := ordersForReport(strings.EqualFold(strings.TrimSpace(archiveInput), "y"), from, to)
+	if err != nil {
+		fmt.Println("Gagal membaca arsip pesanan:", err)
+		return
+	}
+
+	sold := map[string]int{}
+	for _, order := range reportOrders {
+		if order.Voided {
+			continue
+		}
+		for _, line := range order.Lines {
+			if line.Status != LineCancelled {
+				sold[line.ItemName] += line.Quantity - line.Returned
+			}
+		}
+	}
+
+	var items []engineeringItem
+	var noCost []string
+	menuMutex.Lock()
+	for _, item := range stockItems() {
+		if item.Cost == 0 {
+			noCost = append(noCost, item.Name)
+			continue
+		}
+		items = append(items, engineeringItem{Name: item.Name, Sold: sold[item.Name], Margin: item.Price - item.Cost})
+	}
+	menuMutex.Unlock()
+
+	fmt.Println("\n===== Matriks Menu Engineering =====")
+	totalSold := 0
+	var totalMargin Money
+	for _, item := range items {
+		totalSold += item.Sold
+		totalMargin += item.Margin.Times(item.Sold)
+	}
+	if totalSold == 0 {
+		fmt.Println("Belum ada penjualan item yang punya harga pokok pada periode ini.")
+		printMissingCost(noCost)
+		return
+	}
+
+	// Batas popularitas 70% dari rata-rata porsi per item, batas margin memakai
+	// rata-rata margin tertimbang jumlah terjual
+	popularityLine := popularityFactor * float64(totalSold) / float64(len(items))
+	marginLine := totalMargin.Div(totalSold)
+	for i := range items {
+		popular := float64(items[i].Sold) >= popularityLine
+		profitable := items[i].Margin >= marginLine
+		switch {
+		case popular && profitable:
+			items[i].Class = EngineeringStar
+		case popular:
+			items[i].Class = EngineeringPlowhorse
+		case profitable:
+			items[i].Class = EngineeringPuzzle
+		default:
+			items[i].Class = EngineeringDog
+		}
+	}
+
+	fmt.Printf("Batas populer: %s porsi | Batas margin: %s\n", formatNumber(popularityLine, 1), formatMoney(marginLine))
+	fmt.Println("                 | Margin rendah        | Margin tinggi")
+	fmt.Printf("Populer          | Plowhorse: %-9d | Star: %d\n", countClass(items, EngineeringPlowhorse), countClass(items, EngineeringStar))
+	fmt.Printf("Kurang populer   | Dog: %-15d | Puzzle: %d\n", countClass(items, EngineeringDog), countClass(items, EngineeringPuzzle))
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Class != items[j].Class {
+			return engineeringOrder(items[i].Class) < engineeringOrder(items[j].Class)
+		}
+		return items[i].Sold > items[j].Sold
+	})
+	fmt.Println()
+	for _, item := range items {
+		fmt.Printf("%-10s | %s | Terjual: %d | Margin: %s\n", item.Class, item.Name, item.Sold, formatMoney(item.Margin))
+	}
+	printMissingCost(noCost)
+}
+
+// Fungsi untuk menghitung jumlah item pada satu kelompok
+func countClass(items []engineeringItem, class string) int {
+	count := 0
+	for _, item := range items {
+		if item.Class == class {
+			count++
+		}
+	}
+	return count
+}
+
+// Fungsi untuk urutan tampil kelompok, star lebih dulu
+func engineeringOrder(class string) int {
+	switch class {
+	case EngineeringStar:
+		return 0
+	case EngineeringPlowhorse:
+		return 1
+	case EngineeringPuzzle:
+		return 2
+	}
+	return 3
+}
+
+// Fungsi untuk menampilkan item yang tidak ikut dianalisis karena belum punya harga pokok
+func printMissingCost(names []string) {
+	if len(names) > 0 {
+		fmt.Printf("\nTanpa harga pokok (tidak dianalisis): %s\n", strings.Join(names, ", "))
+	}
+}
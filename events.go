@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Jenis event siklus hidup pesanan
+const (
+	EventOrderCreated      = "order_created"
+	EventLineStatus        = "line_status"
+	EventOrderPaid         = "order_paid"
+	EventOrderVoided       = "order_voided"
+	EventOrderMerged       = "order_merged"
+	EventOrderMoved        = "order_moved"
+	EventOrderCompleted    = "order_completed"
+	EventOrderAcknowledged = "order_acknowledged"
+)
+
+// Struct untuk event pesanan yang dikirim ke layar dapur dan papan status
+type OrderEvent struct {
+	Type    string `json:"type"`
+	OrderID int    `json:"order_id"`
+	// Nomor ambil harian untuk papan status, hanya pada event pesanan
+	PickupCode string     `json:"pickup_code,omitempty"`
+	Table      int        `json:"table,omitempty"`
+	LineNo     int        `json:"line_no,omitempty"`
+	ItemName   string     `json:"item_name,omitempty"`
+	Status     LineStatus `json:"status,omitempty"`
+	Time       time.Time  `json:"time"`
+}
+
+// Pelanggan event yang sedang terhubung, masing-masing punya channel sendiri
+var eventSubscribers = map[chan OrderEvent]struct{}{}
+var eventMutex sync.Mutex
+
+// Fungsi untuk mengirim event ke semua pelanggan. Pengiriman tidak pernah menunggu,
+// event untuk pelanggan yang lambat dibuang agar kasir dan dapur tidak tertahan.
+func publishOrderEvent(event OrderEvent) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	eventMutex.Lock()
+	defer eventMutex.Unlock()
+
+	for subscriber := range eventSubscribers {
+		select {
+		case subscriber <- event:
+		default:
+		}
+	}
+}
+
+// Fungsi untuk mengirim event perubahan status satu baris pesanan
+func publishLineStatus(orderID int, line OrderLine) {
+	publishOrderEvent(OrderEvent{Type: EventLineStatus, OrderID: orderID, LineNo: line.No, ItemName: line.ItemName, Status: line.Status})
+}
+
+// Fungsi untuk mendaftarkan pelanggan event baru
+func subscribeOrderEvents() chan OrderEvent {
+	subscriber := make(chan OrderEvent, 32)
+	eventMutex.Lock()
+	eventSubscribers[subscriber] = struct{}{}
+	eventMutex.Unlock()
+	return subscriber
+}
+
+// Fungsi untuk menghapus pelanggan event
+func unsubscribeOrderEvents(subscriber chan OrderEvent) {
+	eventMutex.Lock()
+	delete(eventSubscribers, subscriber)
+	eventMutex.Unlock()
+}
+
+// GET /events mengalirkan event pesanan secara real time dengan Server-Sent Events
+func handleOrderEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeAPIError(w, http.StatusInternalServerError, "streaming tidak didukung")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	subscriber := subscribeOrderEvents()
+	defer unsubscribeOrderEvents(subscriber)
+
+	// Komentar berkala menjaga koneksi tetap hidup melewati proxy
+	heartbeat := time.NewTicker(15 * time.Second)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+		case event := <-subscriber:
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+		}
+		flusher.Flush()
+	}
+}
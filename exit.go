@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+)
+
+// Fungsi untuk meminta konfirmasi sebelum keluar jika masih ada pesanan yang
+// dimasak, meja yang belum bayar atau perubahan yang gagal disimpan, supaya
+// opsi 4 yang tidak sengaja dipilih saat ramai tidak langsung menutup program
+func confirmExit(reader *bufio.Reader) bool {
+	var warnings []string
+
+	ordersMutex.Lock()
+	var inKitchen, unpaid int
+	for _, order := range orders {
+		if order.Voided || order.MergedInto != 0 {
+			continue
+		}
+		cooking := false
+		for _, line := range order.Lines {
+			if line.Status == LineHeld || line.Status == LineQueued || line.Status == LinePreparing {
+				cooking = true
+				break
+			}
+		}
+		if cooking {
+			inKitchen++
+		}
+		if !order.Paid && len(order.Lines) > 0 {
+			unpaid++
+		}
+	}
+	ordersMutex.Unlock()
+	if inKitchen > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d pesanan masih diproses atau ditahan di dapur", inKitchen))
+	}
+	if unpaid > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d pesanan belum dibayar", unpaid))
+	}
+
+	if pending := pendingAckOrders(); len(pending) > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d pesanan belum dikonfirmasi dapur", len(pending)))
+	}
+
+	saveMutex.Lock()
+	failed := saveFailed
+	saveMutex.Unlock()
+	if failed {
+		warnings = append(warnings, "perubahan terakhir gagal disimpan ke penyimpanan")
+	}
+
+	if len(warnings) == 0 {
+		return true
+	}
+	fmt.Println("Peringatan sebelum keluar:")
+	for _, warning := range warnings {
+		fmt.Println("-", warning)
+	}
+	fmt.Print("Tetap keluar? (y/n): ")
+	input, _ := reader.ReadString('\n')
+	// Tanpa input lagi tidak ada yang bisa mengonfirmasi, data tetap disimpan saat keluar
+	if strings.EqualFold(strings.TrimSpace(input), "y") || inputExhausted(reader) {
+		return true
+	}
+	fmt.Println("Batal keluar.")
+	return false
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Kategori biaya yang disarankan, kasir tetap boleh menulis kategori lain
+var expenseCategories = []string{"gas", "bahan", "gaji", "sewa", "listrik", "lainnya"}
+
+// Struct untuk biaya operasional
+type Expense struct {
+	Time        time.Time `json:"time"`
+	Category    string    `json:"category"`
+	Amount      Money     `json:"amount"`
+	Description string    `json:"description"`
+	Cashier     string    `json:"cashier"`
+}
+
+// Nama file biaya operasional di folder data
+const expensesFile = "expenses.json"
+
+// Catatan biaya operasional, dibaca dari file saat pertama kali dipakai
+var expenses []Expense
+var expensesLoaded bool
+var expensesMutex sync.Mutex
+
+// Fungsi untuk membaca biaya operasional dari file jika belum dibaca. Pada storage memory
+// biaya hanya ada selama program berjalan. Pemanggil harus memegang expensesMutex.
+func ensureExpensesLoaded() {
+	if expensesLoaded {
+		return
+	}
+	expensesLoaded = true
+	if currentConfig().Storage == StorageMemory {
+		return
+	}
+	if _, err := readJSONFile(filepath.Join(currentConfig().DataDir, expensesFile), &expenses); err != nil {
+		fmt.Println("Gagal membaca biaya operasional:", err)
+	}
+}
+
+// Fungsi untuk menyimpan biaya operasional ke file, pemanggil harus memegang expensesMutex
+func saveExpenses() {
+	if currentConfig().Storage == StorageMemory {
+		return
+	}
+	if err := writeJSONFile(filepath.Join(currentConfig().DataDir, expensesFile), expenses); err != nil {
+		fmt.Println("Gagal menyimpan biaya operasional:", err)
+	}
+}
+
+// Fungsi untuk menampilkan submenu biaya dan laporan laba rugi
+func expensesMenu(reader *bufio.Reader) {
+	fmt.Println("\n===== Biaya & Laba Rugi =====")
+	fmt.Println("1. Catat Biaya")
+	fmt.Println("2. Laporan Laba Rugi")
+	fmt.Print("Pilih opsi: ")
+
+	choice, _ := reader.ReadString('\n')
+	switch strings.TrimSpace(choice) {
+	case "1":
+		logExpense(reader)
+	case "2":
+		displayProfitAndLoss(reader)
+	default:
+		fmt.Println("Opsi tidak valid.")
+	}
+}
+
+// Fungsi untuk mencatat biaya operasional, biaya yang dibayar dari laci ikut tercatat sebagai kas keluar
+func logExpense(reader *bufio.Reader) {
+	fmt.Printf("Kategori (%s): ", strings.Join(expenseCategories, "/"))
+	category, _ := reader.ReadString('\n')
+	category = strings.ToLower(strings.TrimSpace(category))
+	if category == "" {
+		fmt.Println("Kategori wajib diisi.")
+		return
+	}
+
+	fmt.Print("Jumlah: ")
+	amountInput, _ := reader.ReadString('\n')
+	amount, err := parseMoneyInput(strings.TrimSpace(amountInput))
+	if err != nil || amount <= 0 {
+		fmt.Println("Jumlah harus berupa angka positif.")
+		return
+	}
+
+	fmt.Print("Keterangan: ")
+	description, _ := reader.ReadString('\n')
+	description = strings.TrimSpace(description)
+
+	fmt.Print("Dibayar dari laci kasir? (y/n): ")
+	fromDrawer, _ := reader.ReadString('\n')
+
+	activityMutex.Lock()
+	cashier := currentCashier
+	activityMutex.Unlock()
+
+	now := time.Now()
+	expensesMutex.Lock()
+	ensureExpensesLoaded()
+	expenses = append(expenses, Expense{Time: now, Category: category, Amount: amount, Description: description, Cashier: cashier})
+	saveExpenses()
+	expensesMutex.Unlock()
+
+	if strings.EqualFold(strings.TrimSpace(fromDrawer), "y") {
+		cashMutex.Lock()
+		appendCashEntry(CashEntry{Time: now, Kind: CashOut, Amount: amount, Reason: "biaya " + category + ": " + description, Cashier: cashier})
+		cashMutex.Unlock()
+	}
+
+	fmt.Printf("Biaya %s %s dicatat.\n", category, formatMoney(amount))
+}
+
+// Fungsi untuk menampilkan laporan laba rugi sederhana: pendapatan pesanan
+// dikurangi nilai waste dan biaya operasional per kategori
+func displayProfitAndLoss(reader *bufio.Reader) {
+	from, ok := readOptionalDate(reader, "Dari tanggal (YYYY-MM-DD, kosongkan untuk semua): ")
+	if !ok {
+		return
+	}
+	to, ok := readOptionalDate(reader, "Sampai tanggal (YYYY-MM-DD, kosongkan untuk semua): ")
+	if !ok {
+		return
+	}
+	if to.IsZero() {
+		to = time.Now()
+	} else {
+		to = to.AddDate(0, 0, 1)
+	}
+
+	// Pendapatan dari pesanan sudah dikurangi diskon dan refund
+	summary := summarizePeriod(from, to)
+
+	perCategory := map[string]Money{}
+	var totalExpenses Money
+	expensesMutex.Lock()
+	ensureExpensesLoaded()
+	for _, expense := range expenses {
+		if expense.Time.Before(from) || !expense.Time.Before(to) {
+			continue
+		}
+		perCategory[expense.Category] += expense.Amount
+		totalExpenses += expense.Amount
+	}
+	expensesMutex.Unlock()
+
+	fmt.Println("\n===== Laporan Laba Rugi =====")
+	fmt.Printf("Pendapatan: %s\n", formatMoney(summary.Revenue))
+	fmt.Printf("Nilai Waste: -%s\n", formatMoney(summary.Waste))
+
+	categories := make([]string, 0, len(perCategory))
+	for category := range perCategory {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	fmt.Println("Biaya Operasional:")
+	for _, category := range categories {
+		fmt.Printf("  %s: -%s\n", category, formatMoney(perCategory[category]))
+	}
+	fmt.Printf("Total Biaya: -%s\n", formatMoney(totalExpenses))
+	fmt.Printf("Laba Bersih: %s\n", formatMoneyChange(summary.Revenue-summary.Waste-totalExpenses))
+}
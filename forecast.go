@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Ramalan stok untuk satu item
+type stockForecast struct {
+	Item      string
+	Stock     int
+	Incoming  int
+	DailyRate float64
+	DaysLeft  float64
+	Suggested int
+}
+
+// Fungsi untuk menghitung rata-rata penjualan harian per item dari pesanan
+// dalam jangka waktu tertentu sebelum now, pemanggil harus memegang ordersMutex
+func dailySalesRates(now time.Time, days int) map[string]float64 {
+	since := now.AddDate(0, 0, -days)
+	sold := map[string]int{}
+	earliest := now
+	for _, order := range orders {
+		if order.Voided || order.CreatedAt.Before(since) {
+			continue
+		}
+		earliest = minTime(earliest, order.CreatedAt)
+		for _, line := range order.Lines {
+			if line.Status == LineCancelled || line.Status == LineScheduled {
+				continue
+			}
+			sold[line.ItemName] += line.Quantity - line.Returned
+		}
+	}
+
+	// Data yang lebih pendek dari jangka waktu dihitung dari pesanan pertama, minimal satu hari
+	span := math.Max(now.Sub(earliest).Hours()/24, 1)
+	rates := map[string]float64{}
+	for name, quantity := range sold {
+		rates[name] = float64(quantity) / span
+	}
+	return rates
+}
+
+// Fungsi untuk mengambil waktu yang lebih awal
+func minTime(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+// Fungsi untuk menghitung jumlah item yang sudah dipesan ke pemasok tetapi belum diterima
+func incomingStock() map[string]int {
+	purchasingMutex.Lock()
+	defer purchasingMutex.Unlock()
+
+	ensurePurchasingLoaded()
+
+	incoming := map[string]int{}
+	for _, po := range purchaseOrders {
+		if po.Status != PurchaseOrdered {
+			continue
+		}
+		for _, line := range po.Lines {
+			incoming[line.ItemName] += line.Quantity
+		}
+	}
+	return incoming
+}
+
+// Fungsi untuk meramal kapan stok setiap item habis dan berapa yang perlu dipesan ulang
+// agar cukup selama waktu kirim pemasok ditambah jumlah hari persediaan
+func forecastStock(now time.Time) []stockForecast {
+	cfg := currentConfig()
+	ordersMutex.Lock()
+	rates := dailySalesRates(now, cfg.ForecastDays)
+	ordersMutex.Unlock()
+	incoming := incomingStock()
+
+	menuMutex.Lock()
+	defer menuMutex.Unlock()
+
+	var result []stockForecast
+	for _, item := range stockItems() {
+		forecast := stockForecast{
+			Item:      item.Name,
+			Stock:     item.Quantity,
+			Incoming:  incoming[item.Name],
+			DailyRate: rates[item.Name],
+			DaysLeft:  math.Inf(1),
+		}
+		if forecast.DailyRate > 0 {
+			forecast.DaysLeft = float64(forecast.Stock) / forecast.DailyRate
+			target := forecast.DailyRate * float64(cfg.ReorderLeadDays+cfg.ReorderCoverDays)
+			forecast.Suggested = max(int(math.Ceil(target))-forecast.Stock-forecast.Incoming, 0)
+		}
+		result = append(result, forecast)
+	}
+	return result
+}
+
+// Fungsi untuk menampilkan ramalan stok dan daftar saran pemesanan ulang
+func displayReorderSuggestions() {
+	now := time.Now()
+	cfg := currentConfig()
+	forecasts := forecastStock(now)
+
+	fmt.Printf("\n===== Saran Reorder (penjualan %d hari terakhir) =====\n", cfg.ForecastDays)
+	fmt.Println("Ramalan stok:")
+	for _, forecast := range forecasts {
+		if math.IsInf(forecast.DaysLeft, 1) {
+			fmt.Printf("  %s | Stok: %s | Belum ada penjualan\n", forecast.Item, formatQuantity(forecast.Stock))
+			continue
+		}
+		runOut := now.Add(time.Duration(forecast.DaysLeft * 24 * float64(time.Hour)))
+		fmt.Printf("  %s | Stok: %s | Terjual/hari: %s | Habis dalam %s hari (%s)\n",
+			forecast.Item, formatQuantity(forecast.Stock), formatNumber(forecast.DailyRate, 1), formatNumber(forecast.DaysLeft, 1), formatDate(runOut))
+	}
+
+	fmt.Printf("Saran pemesanan (waktu kirim %d hari, persediaan %d hari):\n", cfg.ReorderLeadDays, cfg.ReorderCoverDays)
+	found := false
+	for _, forecast := range forecasts {
+		if forecast.Suggested == 0 {
+			continue
+		}
+		found = true
+		incoming := ""
+		if forecast.Incoming > 0 {
+			incoming = fmt.Sprintf(" (sudah dipesan %d)", forecast.Incoming)
+		}
+		fmt.Printf("  %s: pesan %d%s\n", forecast.Item, forecast.Suggested, incoming)
+	}
+	if !found {
+		fmt.Println("  Stok masih cukup, belum perlu pesan ulang.")
+	}
+}
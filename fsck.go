@@ -0,0 +1,256 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Struct untuk satu masalah yang ditemukan pemeriksaan integritas. Repair nil berarti
+// masalah harus diperbaiki manual.
+type integrityIssue struct {
+	Description string
+	Repair      func() string
+}
+
+// Fungsi untuk menjalankan perintah `fsck [--repair]`: memeriksa data tersimpan setelah
+// crash atau file yang diedit manual, dan memperbaiki yang bisa diperbaiki otomatis
+func runFsck(args []string) {
+	repair := false
+	for _, arg := range args {
+		switch arg {
+		case "--repair", "-repair":
+			repair = true
+		default:
+			fmt.Println("Pemakaian: fsck [--repair]")
+			return
+		}
+	}
+
+	// Menu bawaan dipakai jika penyimpanan belum berisi menu, sama seperti saat program dimulai
+	if items, err := loadDefaultMenu(currentConfig().DefaultMenuFile); err == nil {
+		menu = items
+	}
+	if err := openStorage(currentConfig()); err != nil {
+		fmt.Println("Gagal membuka penyimpanan:", err)
+		return
+	}
+	if err := loadState(); err != nil {
+		fmt.Println("Gagal memuat data:", err)
+		return
+	}
+
+	if _, repaired := checkIntegrity(repair); repaired > 0 {
+		saveState()
+	}
+}
+
+// Fungsi untuk memeriksa integritas data dari menu utama. Dengan "repair" setelah opsi
+// masalah langsung diperbaiki setelah PIN admin dimasukkan.
+func integrityCheckMenu(reader *bufio.Reader, args string) {
+	repair := strings.TrimSpace(args) == "repair"
+	if repair && !requireAdminPIN(reader, "perbaikan integritas data") {
+		return
+	}
+	checkIntegrity(repair)
+}
+
+// Fungsi untuk memeriksa stok, total pesanan, item yang dirujuk pesanan dan total
+// pendapatan, lalu menampilkan hasilnya. Mengembalikan jumlah masalah dan jumlah
+// yang diperbaiki.
+func checkIntegrity(repair bool) (int, int) {
+	fmt.Println("\n===== Pemeriksaan Integritas Data =====")
+
+	// Urutan kunci sama dengan saat menyimpan data agar tidak terjadi deadlock
+	menuMutex.Lock()
+	ordersMutex.Lock()
+	totalMutex.Lock()
+	issues := append(checkMenuIntegrity(), checkOrderIntegrity()...)
+	issues = append(issues, checkTotalIntegrity()...)
+
+	repaired := 0
+	var repairs []string
+	for _, issue := range issues {
+		fmt.Println("-", issue.Description)
+		if issue.Repair == nil {
+			fmt.Println("  Tidak bisa diperbaiki otomatis, periksa data secara manual.")
+			continue
+		}
+		if repair {
+			result := issue.Repair()
+			fmt.Println("  Diperbaiki:", result)
+			repairs = append(repairs, result)
+			repaired++
+		}
+	}
+	totalMutex.Unlock()
+	ordersMutex.Unlock()
+	menuMutex.Unlock()
+
+	switch {
+	case len(issues) == 0:
+		fmt.Println("Tidak ada masalah ditemukan.")
+	case repair:
+		fmt.Printf("%d masalah ditemukan, %d diperbaiki.\n", len(issues), repaired)
+	default:
+		fixable := 0
+		for _, issue := range issues {
+			if issue.Repair != nil {
+				fixable++
+			}
+		}
+		fmt.Printf("%d masalah ditemukan, %d bisa diperbaiki dengan --repair.\n", len(issues), fixable)
+	}
+	for _, result := range repairs {
+		logActivity("perbaikan integritas: " + result)
+	}
+	return len(issues), repaired
+}
+
+// Fungsi untuk memeriksa stok dan batch setiap item. Pemanggil harus memegang menuMutex.
+func checkMenuIntegrity() []integrityIssue {
+	var issues []integrityIssue
+	seen := map[string]bool{}
+	var walk func(items []MenuItem)
+	walk = func(items []MenuItem) {
+		for i := range items {
+			key := normalizeMenuName(items[i].Name)
+			if seen[key] {
+				issues = append(issues, integrityIssue{Description: fmt.Sprintf("Nama item %s dipakai lebih dari sekali", items[i].Name)})
+			}
+			seen[key] = true
+			walk(items[i].Variants)
+		}
+	}
+	walk(menu)
+
+	for _, item := range stockItems() {
+		if item.Price < 0 {
+			issues = append(issues, integrityIssue{Description: fmt.Sprintf("Harga %s negatif (%s)", item.Name, formatMoney(item.Price))})
+		}
+		if item.Quantity < 0 {
+			issues = append(issues, integrityIssue{
+				Description: fmt.Sprintf("Stok %s negatif (%d)", item.Name, item.Quantity),
+				Repair: func() string {
+					recordMovement(item.Name, -item.Quantity, MovementAdjustment, "fsck")
+					item.Quantity = 0
+					return fmt.Sprintf("stok %s diatur menjadi 0", item.Name)
+				},
+			})
+		}
+
+		if slices.ContainsFunc(item.Batches, func(batch StockBatch) bool { return batch.Quantity <= 0 }) {
+			issues = append(issues, integrityIssue{
+				Description: fmt.Sprintf("Batch stok %s berisi jumlah nol atau negatif", item.Name),
+				Repair: func() string {
+					item.Batches = slices.DeleteFunc(item.Batches, func(batch StockBatch) bool { return batch.Quantity <= 0 })
+					return fmt.Sprintf("batch kosong %s dihapus", item.Name)
+				},
+			})
+		}
+		inBatches := 0
+		for _, batch := range item.Batches {
+			inBatches += max(batch.Quantity, 0)
+		}
+		if inBatches > max(item.Quantity, 0) {
+			issues = append(issues, integrityIssue{
+				Description: fmt.Sprintf("Jumlah batch %s (%d) melebihi stok (%d)", item.Name, inBatches, item.Quantity),
+				Repair: func() string {
+					// Kelebihan diambil dari batch terlama, sama seperti pengurangan stok FIFO
+					excess := -max(item.Quantity, 0)
+					for _, batch := range item.Batches {
+						excess += max(batch.Quantity, 0)
+					}
+					for len(item.Batches) > 0 && excess > 0 {
+						batch := &item.Batches[0]
+						if batch.Quantity > excess {
+							batch.Quantity -= excess
+							break
+						}
+						excess -= max(batch.Quantity, 0)
+						item.Batches = item.Batches[1:]
+					}
+					return fmt.Sprintf("batch %s disesuaikan dengan stok", item.Name)
+				},
+			})
+		}
+	}
+	return issues
+}
+
+// Fungsi untuk memeriksa setiap pesanan. Pemanggil harus memegang menuMutex dan ordersMutex.
+func checkOrderIntegrity() []integrityIssue {
+	var issues []integrityIssue
+	ids := map[int]bool{}
+	for _, order := range orders {
+		if ids[order.ID] {
+			issues = append(issues, integrityIssue{Description: fmt.Sprintf("ID pesanan %d dipakai lebih dari sekali", order.ID)})
+		}
+		ids[order.ID] = true
+	}
+
+	for _, order := range orders {
+		var sum Money
+		numbered := true
+		for i, line := range order.Lines {
+			sum += line.TotalPrice
+			if line.No != i+1 {
+				numbered = false
+			}
+			if findMenuItem(line.ItemName) == nil {
+				issues = append(issues, integrityIssue{Description: fmt.Sprintf("Pesanan %d baris %d merujuk item %s yang tidak ada di menu", order.ID, line.No, line.ItemName)})
+			}
+			if line.Quantity <= 0 || line.Returned < 0 || line.Returned > line.Quantity {
+				issues = append(issues, integrityIssue{Description: fmt.Sprintf("Pesanan %d baris %d: jumlah %d dengan retur %d tidak valid", order.ID, line.No, line.Quantity, line.Returned)})
+			}
+		}
+
+		if !numbered {
+			issues = append(issues, integrityIssue{
+				Description: fmt.Sprintf("Nomor baris pesanan %d tidak berurutan", order.ID),
+				Repair: func() string {
+					for i := range order.Lines {
+						order.Lines[i].No = i + 1
+					}
+					return fmt.Sprintf("baris pesanan %d dinomori ulang", order.ID)
+				},
+			})
+		}
+		if order.TotalPrice != sum {
+			issues = append(issues, integrityIssue{
+				Description: fmt.Sprintf("Total pesanan %d %s tidak sama dengan jumlah baris %s", order.ID, formatMoney(order.TotalPrice), formatMoney(sum)),
+				Repair: func() string {
+					order.TotalPrice = sum
+					return fmt.Sprintf("total pesanan %d diatur menjadi %s", order.ID, formatMoney(sum))
+				},
+			})
+		}
+		if order.MergedInto != 0 && !ids[order.MergedInto] {
+			issues = append(issues, integrityIssue{Description: fmt.Sprintf("Pesanan %d digabung ke pesanan %d yang tidak ada", order.ID, order.MergedInto)})
+		}
+		if order.Paid && order.PaidAt.IsZero() {
+			issues = append(issues, integrityIssue{Description: fmt.Sprintf("Pesanan %d lunas tanpa waktu pembayaran", order.ID)})
+		}
+	}
+	return issues
+}
+
+// Fungsi untuk memeriksa total pendapatan berjalan terhadap riwayat pesanan. Pemanggil
+// harus memegang ordersMutex dan totalMutex.
+func checkTotalIntegrity() []integrityIssue {
+	var total Money
+	for _, order := range orders {
+		total += orderRevenue(order)
+	}
+	if totalAllOrders == total {
+		return nil
+	}
+	return []integrityIssue{{
+		Description: fmt.Sprintf("Total semua pesanan %s tidak sama dengan riwayat pesanan %s", formatMoney(totalAllOrders), formatMoney(total)),
+		Repair: func() string {
+			totalAllOrders = total
+			return fmt.Sprintf("total semua pesanan diatur menjadi %s", formatMoney(total))
+		},
+	}}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Jumlah saran maksimal yang ditampilkan saat nama item tidak ditemukan
+const maxMenuSuggestions = 3
+
+// Fungsi untuk menghitung jarak edit Levenshtein antara dua teks
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+// Fungsi untuk mencari item menu yang namanya paling mirip, diurutkan dari yang
+// paling dekat. Nama yang memuat kata yang diketik juga dianggap mirip.
+// Pemanggil harus memegang menuMutex.
+func closestMenuItems(name string) []*MenuItem {
+	target := normalizeMenuName(name)
+	if target == "" {
+		return nil
+	}
+
+	type candidate struct {
+		item     *MenuItem
+		distance int
+	}
+	var candidates []candidate
+	var visit func(items []MenuItem)
+	visit = func(items []MenuItem) {
+		for i := range items {
+			itemName := normalizeMenuName(items[i].Name)
+			distance := editDistance(target, itemName)
+			// Batas jarak mengikuti panjang nama agar nama pendek tidak cocok dengan semua item
+			if distance > max(2, len([]rune(target))/3) && !strings.Contains(itemName, target) {
+				visit(items[i].Variants)
+				continue
+			}
+			candidates = append(candidates, candidate{&items[i], distance})
+			visit(items[i].Variants)
+		}
+	}
+	visit(menu)
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].distance < candidates[j].distance })
+	var result []*MenuItem
+	for _, c := range candidates {
+		if len(result) == maxMenuSuggestions {
+			break
+		}
+		result = append(result, c.item)
+	}
+	return result
+}
+
+// Fungsi untuk menyarankan item menu yang namanya mirip, pemanggil harus memegang menuMutex
+func suggestMenuItem(name string) string {
+	matches := closestMenuItems(name)
+	if len(matches) == 0 {
+		return ""
+	}
+	names := make([]string, len(matches))
+	for i, item := range matches {
+		names[i] = item.Name
+	}
+	return " Maksud Anda: " + strings.Join(names, ", ") + "?"
+}
+
+// Fungsi untuk mencari nama item menu, jika tidak ditemukan kasir ditawari item yang
+// paling mirip untuk dikonfirmasi. Mengembalikan nama kosong jika batal. menuMutex hanya
+// dipegang saat mencari, tidak selama kasir menjawab, jadi pemanggil tidak boleh memegangnya.
+func findMenuItemOrSuggest(reader *bufio.Reader, name string) string {
+	menuMutex.Lock()
+	var matches []string
+	if item := findMenuItem(name); item != nil {
+		matches = []string{item.Name}
+	} else {
+		for _, item := range closestMenuItems(name) {
+			matches = append(matches, item.Name)
+		}
+		name = ""
+	}
+	menuMutex.Unlock()
+	if name != "" {
+		return matches[0]
+	}
+
+	switch len(matches) {
+	case 0:
+		fmt.Println("Item tidak ditemukan.")
+		return ""
+	case 1:
+		fmt.Printf("Item tidak ditemukan. Maksud Anda: %s? (y/n): ", matches[0])
+		answer, _ := reader.ReadString('\n')
+		if strings.EqualFold(strings.TrimSpace(answer), "y") {
+			return matches[0]
+		}
+		return ""
+	}
+
+	fmt.Println("Item tidak ditemukan. Maksud Anda:")
+	for i, match := range matches {
+		fmt.Printf("%d. %s\n", i+1, match)
+	}
+	fmt.Print("Pilih nomor (kosongkan untuk batal): ")
+	answer, _ := reader.ReadString('\n')
+	var choice int
+	if _, err := fmt.Sscan(strings.TrimSpace(answer), &choice); err != nil || choice < 1 || choice > len(matches) {
+		return ""
+	}
+	return matches[choice-1]
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Event yang bisa memicu hook
+const (
+	HookOrderCreated   = "order_created"
+	HookOrderCompleted = "order_completed"
+	// Baris pesanan melewati batas waktu SLA course-nya
+	HookOrderLate = "order_late"
+)
+
+// Batas waktu satu perintah hook sebelum dihentikan
+const hookTimeout = 30 * time.Second
+
+// Menunggu hook yang masih berjalan saat program ditutup
+var hookWG sync.WaitGroup
+
+// Fungsi untuk menjalankan semua perintah hook sebuah event di latar belakang.
+// Data pesanan dikirim sebagai JSON lewat stdin, nama event dan ID pesanan juga
+// tersedia di variabel lingkungan ORDER_EVENT dan ORDER_ID. Saat dry-run hook tidak dijalankan.
+func runOrderHooks(event string, order Order) {
+	commands := currentConfig().Hooks[event]
+	if len(commands) == 0 || dryRunActive() {
+		return
+	}
+
+	data, err := json.Marshal(order)
+	if err != nil {
+		fmt.Printf("Gagal menyiapkan data hook %s: %v\n", event, err)
+		return
+	}
+	for _, command := range commands {
+		hookWG.Add(1)
+		go func(command string) {
+			defer hookWG.Done()
+			if err := execHook(command, event, order.ID, data); err != nil {
+				asyncPrintf("\nHook %s (%s) untuk pesanan ID %d gagal: %v\n", event, command, order.ID, err)
+				logActivity(fmt.Sprintf("hook %s gagal untuk pesanan %d: %v", event, order.ID, err))
+			}
+		}(command)
+	}
+}
+
+// Fungsi untuk menjalankan satu perintah hook lewat shell sistem
+func execHook(command, event string, orderID int, data []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Env = append(os.Environ(), "ORDER_EVENT="+event, "ORDER_ID="+strconv.Itoa(orderID))
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		if message := strings.TrimSpace(string(output)); message != "" {
+			return fmt.Errorf("%w: %s", err, message)
+		}
+		return err
+	}
+	return nil
+}
+
+// Fungsi untuk memicu event selesai jika pesanan sudah dibayar dan semua itemnya
+// selesai atau dibatalkan. Pemanggil harus memegang ordersMutex.
+func completeOrderIfDone(order *Order) {
+	if order.Voided || order.MergedInto != 0 || !orderClosed(order) {
+		return
+	}
+	publishOrderEvent(OrderEvent{Type: EventOrderCompleted, OrderID: order.ID, PickupCode: order.PickupCode, Table: order.Table})
+	runOrderHooks(HookOrderCompleted, copyOrder(*order))
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// Penanda input habis per reader, diisi saat sumber input (stdin, skrip atau terminal
+// daemon) berakhir atau gagal dibaca
+var inputStates sync.Map
+
+// Sumber input yang mencatat saat input berakhir, supaya program yang dijalankan
+// lewat pipe (misalnya `echo 1 | app`) berhenti dengan rapi dan tidak berputar terus
+type inputSource struct {
+	r     io.Reader
+	ended *atomic.Bool
+}
+
+func (s inputSource) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	if err != nil {
+		s.ended.Store(true)
+	}
+	return n, err
+}
+
+// Fungsi untuk membuat reader input kasir dari stdin, file skrip atau koneksi terminal
+func newInputReader(r io.Reader) *bufio.Reader {
+	ended := &atomic.Bool{}
+	reader := bufio.NewReader(inputSource{r: r, ended: ended})
+	inputStates.Store(reader, ended)
+	return reader
+}
+
+// Fungsi untuk melupakan reader yang tidak dipakai lagi, misalnya saat terminal terputus
+func forgetInputReader(reader *bufio.Reader) {
+	inputStates.Delete(reader)
+}
+
+// Fungsi untuk memeriksa apakah input sudah habis dan tidak ada sisa baris di buffer
+func inputExhausted(reader *bufio.Reader) bool {
+	ended, ok := inputStates.Load(reader)
+	return ok && ended.(*atomic.Bool).Load() && reader.Buffered() == 0
+}
@@ -0,0 +1,367 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Status untuk setiap baris item pesanan di dapur
+type LineStatus string
+
+const (
+	LineScheduled LineStatus = "scheduled"
+	LineCancelled LineStatus = "cancelled"
+	LineHeld      LineStatus = "held"
+	LineQueued    LineStatus = "queued"
+	LinePreparing LineStatus = "preparing"
+	LineDone      LineStatus = "done"
+)
+
+// Status baris berbahasa Indonesia dari data lama
+var legacyLineStatuses = map[LineStatus]LineStatus{
+	"terjadwal":  LineScheduled,
+	"dibatalkan": LineCancelled,
+	"ditahan":    LineHeld,
+	"antri":      LineQueued,
+	"diproses":   LinePreparing,
+	"selesai":    LineDone,
+}
+
+// Fungsi untuk menampilkan status baris di layar
+func (s LineStatus) Label() string {
+	for legacy, status := range legacyLineStatuses {
+		if status == s {
+			return string(legacy)
+		}
+	}
+	return string(s)
+}
+
+// Fungsi untuk membaca status baris dari JSON, nilai lama ikut diterjemahkan
+func (s *LineStatus) UnmarshalJSON(data []byte) error {
+	return unmarshalLegacy(data, s, legacyLineStatuses)
+}
+
+// Stasiun persiapan di dapur, setiap item menu diarahkan ke satu stasiun
+type Station string
+
+const (
+	StationGrill Station = "grill"
+	StationWok   Station = "wok"
+	StationBar   Station = "bar"
+)
+
+// Daftar stasiun sesuai urutan tampilan
+var stations = []Station{StationGrill, StationWok, StationBar}
+
+// Fungsi untuk mengubah input menjadi stasiun yang dikenal
+func parseStation(input string) (Station, bool) {
+	for _, station := range stations {
+		if strings.EqualFold(string(station), strings.TrimSpace(input)) {
+			return station, true
+		}
+	}
+	return "", false
+}
+
+// Fungsi untuk mengirim baris yang tidak ditahan dari pesanan baru ke antrian dapur.
+// Pesanan dari API (background bernilai true) tidak punya terminal kasir, jadi pesannya
+// lewat antrean keluaran latar belakang agar tidak memotong prompt kasir.
+func dispatchOrder(order *Order, background bool) {
+	printf := func(format string, args ...any) { fmt.Printf(format, args...) }
+	if background {
+		printf = processingPrintf
+	}
+	timed := applyCourseTiming(order)
+
+	ordersMutex.Lock()
+	next := nextCourseFire(order)
+	var lines []OrderLine
+	for _, line := range order.Lines {
+		if line.Status == LineQueued {
+			lines = append(lines, line)
+		}
+	}
+	readyAt := estimateReadyAt(order, time.Now())
+	code := order.PickupCode
+	ticket := *order
+	ticket.Lines = append([]OrderLine(nil), order.Lines...)
+	ordersMutex.Unlock()
+
+	printKitchenTickets(ticket, background)
+
+	for _, line := range timed {
+		printf("%s x%d masuk dapur otomatis pukul %s.\n", line.ItemName, line.Quantity, line.FireAt.Local().Format("15:04"))
+	}
+	armCourseTimer(order.ID, next)
+	if len(lines) == 0 && len(timed) > 0 {
+		return
+	}
+	if len(lines) == 0 {
+		printf("Pesanan ID %d%s ditahan, gunakan opsi kirim item tertahan untuk mengirimnya.\n", order.ID, describePickupCode(code))
+		return
+	}
+	if kitchenAtCapacity() {
+		waiting, _ := orderQueue.backlog()
+		printf("Dapur penuh, pesanan ID %d%s menunggu giliran setelah %d tiket.\n", order.ID, describePickupCode(code), waiting)
+	} else {
+		printf("Estimasi pesanan ID %d%s siap: %s\n", order.ID, describePickupCode(code), readyAt.Format("15:04"))
+	}
+
+	sendToKitchen(order.ID, lines)
+}
+
+// Fungsi untuk mengirim tiket berisi baris pesanan ke antrian dapur
+func sendToKitchen(orderID int, lines []OrderLine) {
+	ticket := Order{ID: orderID, Lines: lines}
+	for _, line := range lines {
+		ticket.TotalPrice += line.TotalPrice
+	}
+
+	wg.Add(1)
+	if !orderQueue.push(ticket) {
+		wg.Done()
+	}
+}
+
+// Fungsi untuk memperbarui status baris pesanan yang ada di tiket dapur,
+// mengembalikan total harga baris yang benar-benar berubah
+func setLineStatus(ticket Order, status LineStatus) Money {
+	ordersMutex.Lock()
+	defer ordersMutex.Unlock()
+
+	order := findOrder(ticket.ID)
+	if order == nil {
+		return 0
+	}
+
+	var total Money
+	for _, line := range ticket.Lines {
+		for i := range order.Lines {
+			// Baris yang sudah di-bump atau dibatalkan tidak boleh berubah statusnya
+			current := order.Lines[i].Status
+			if order.Lines[i].No == line.No && current != LineDone && current != LineCancelled {
+				order.Lines[i].Status = status
+				// Pesanan yang belum dikonfirmasi dihitung saat konfirmasi
+				if !order.PendingAck {
+					total += order.Lines[i].TotalPrice
+				}
+				if status == LinePreparing {
+					order.Lines[i].StartedAt = time.Now()
+					order.Lines[i].EstimatedPrep = estimatePrepTime(order.Lines[i].ItemName, order.Lines[i].Station)
+				}
+				publishLineStatus(order.ID, order.Lines[i])
+			}
+		}
+	}
+	return total
+}
+
+// Fungsi untuk meringkas baris pesanan, misalnya "Nasi Goreng x2;Es Teh x1"
+func describeLines(lines []OrderLine) string {
+	parts := make([]string, 0, len(lines))
+	for _, line := range lines {
+		parts = append(parts, fmt.Sprintf("%s x%d", lineLabel(line), line.Quantity))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Fungsi untuk menampilkan pesanan yang belum selesai di dapur, termasuk item yang ditahan
+func displayKitchenQueue() {
+	courses := menuCourses()
+	ordersMutex.Lock()
+	defer ordersMutex.Unlock()
+
+	fmt.Println("\n===== Antrian Dapur =====")
+	if waiting, active := orderQueue.backlog(); waiting > 0 {
+		fmt.Printf("%d tiket menunggu giliran, %d sedang diproses (batas %d).\n", waiting, active, currentConfig().KitchenQueueLimit)
+	}
+	now := time.Now()
+	empty := true
+	for _, order := range orders {
+		var pending []OrderLine
+		for _, line := range order.Lines {
+			if line.Status != LineDone && line.Status != LineCancelled {
+				pending = append(pending, line)
+			}
+		}
+		if len(pending) == 0 {
+			continue
+		}
+
+		empty = false
+		fmt.Printf("Pesanan ID %d%s%s%s%s%s\n", order.ID, describePickupCode(order.PickupCode), describeTable(order.Table, order.Delivery), describePickup(order.PickupAt), describeAck(order), describeOrderTimes(order, now))
+		if order.Note != "" {
+			fmt.Printf("  Catatan: %s\n", order.Note)
+		}
+		for _, line := range pending {
+			marker := ""
+			if line.Status == LineHeld {
+				marker = " [TAHAN]" + describeCourseFire(line)
+			}
+			if readyAt := estimateLineReadyAt(line, now); !readyAt.IsZero() {
+				marker += " | Estimasi siap: " + readyAt.Format("15:04")
+			}
+			marker += describeOverdue(order, line, courses[line.ItemName], now)
+			fmt.Printf("  %d. %s x%d | Stasiun: %s | Status: %s%s\n", line.No, lineLabel(line), line.Quantity, line.Station, line.Status.Label(), marker)
+		}
+	}
+
+	if empty {
+		fmt.Println("Tidak ada pesanan di dapur.")
+	}
+}
+
+// Fungsi untuk melepas item yang ditahan ke antrian dapur
+func fireHeldLines(reader *bufio.Reader) {
+	id, ok := readOrderID(reader)
+	if !ok {
+		return
+	}
+
+	ordersMutex.Lock()
+	order := followMergedOrder(findOrder(id))
+	if order != nil {
+		id = order.ID
+	}
+	if order == nil {
+		ordersMutex.Unlock()
+		fmt.Println("Pesanan tidak ditemukan.")
+		return
+	}
+
+	var fired []OrderLine
+	for i := range order.Lines {
+		if order.Lines[i].Status == LineHeld {
+			order.Lines[i].Status = LineQueued
+			order.Lines[i].FireAt = time.Time{}
+			fired = append(fired, order.Lines[i])
+			publishLineStatus(order.ID, order.Lines[i])
+		}
+	}
+	ticket := *order
+	ticket.Lines = fired
+	ordersMutex.Unlock()
+
+	if len(fired) == 0 {
+		fmt.Println("Tidak ada item yang ditahan pada pesanan ini.")
+		return
+	}
+	printKitchenTickets(ticket, false)
+
+	fmt.Printf("%d item dari pesanan ID %d dikirim ke dapur.\n", len(fired), id)
+	sendToKitchen(id, fired)
+}
+
+// Fungsi untuk menampilkan antrian satu stasiun dan mem-bump item yang sudah siap
+func stationView(reader *bufio.Reader) {
+	fmt.Print("Pilih stasiun (grill/wok/bar): ")
+	input, _ := reader.ReadString('\n')
+	station, ok := parseStation(input)
+	if !ok {
+		fmt.Println("Stasiun tidak dikenal.")
+		return
+	}
+
+	for {
+		if !displayStationQueue(station) {
+			return
+		}
+
+		fmt.Print("Bump item (ID pesanan dan nomor baris, misal \"3 1\"), \"ack <ID>\" untuk konfirmasi pesanan, kosongkan untuk kembali: ")
+		bumpInput, _ := reader.ReadString('\n')
+		fields := strings.Fields(bumpInput)
+		if len(fields) == 0 {
+			return
+		}
+		if strings.EqualFold(fields[0], "ack") {
+			id, err := strconv.Atoi(strings.Join(fields[1:], ""))
+			if err != nil {
+				fmt.Println("ID pesanan harus berupa angka.")
+				continue
+			}
+			acknowledgeOrderByID(id)
+			continue
+		}
+
+		if len(fields) != 2 {
+			fmt.Println("Format bump tidak valid.")
+			continue
+		}
+		orderID, err := strconv.Atoi(fields[0])
+		if err != nil {
+			fmt.Println("ID pesanan harus berupa angka.")
+			continue
+		}
+		lineNo, err := strconv.Atoi(fields[1])
+		if err != nil {
+			fmt.Println("Nomor baris harus berupa angka.")
+			continue
+		}
+		bumpLine(station, orderID, lineNo)
+	}
+}
+
+// Fungsi untuk menampilkan baris pesanan yang belum selesai di satu stasiun
+func displayStationQueue(station Station) bool {
+	courses := menuCourses()
+	ordersMutex.Lock()
+	defer ordersMutex.Unlock()
+
+	fmt.Printf("\n===== Stasiun %s =====\n", station)
+	now := time.Now()
+	empty := true
+	for _, order := range orders {
+		for _, line := range order.Lines {
+			if line.Station != station || line.Status == LineDone || line.Status == LineCancelled {
+				continue
+			}
+			empty = false
+			fmt.Printf("Pesanan ID %d%s%s | %d. %s x%d | Status: %s%s%s%s\n", order.ID, describePickupCode(order.PickupCode), describeTable(order.Table, order.Delivery), line.No, lineLabel(line), line.Quantity, line.Status.Label(), describeAck(order), describeOrderTimes(order, now), describeOverdue(order, line, courses[line.ItemName], now))
+		}
+	}
+
+	if empty {
+		fmt.Println("Tidak ada item di stasiun ini.")
+	}
+	return !empty
+}
+
+// Fungsi untuk menandai baris pesanan di sebuah stasiun sudah siap
+func bumpLine(station Station, orderID, lineNo int) {
+	ordersMutex.Lock()
+	defer ordersMutex.Unlock()
+
+	order := findOrder(orderID)
+	if order == nil {
+		fmt.Println("Pesanan tidak ditemukan.")
+		return
+	}
+
+	for i := range order.Lines {
+		line := &order.Lines[i]
+		if line.No != lineNo || line.Station != station {
+			continue
+		}
+		if line.Status != LinePreparing {
+			fmt.Printf("Item berstatus %s, hanya item yang diproses yang bisa di-bump.\n", line.Status.Label())
+			return
+		}
+		line.Status = LineDone
+		line.ReadyAt = time.Now()
+		publishLineStatus(order.ID, *line)
+		completeOrderIfDone(order)
+		if prep, ok := actualPrepTime(*line); ok {
+			learnPrepTime(line.ItemName, line.Station, prep)
+			fmt.Printf("%s x%d dari pesanan ID %d siap dalam %s.\n", line.ItemName, line.Quantity, order.ID, formatPrepTime(prep))
+		} else {
+			fmt.Printf("%s x%d dari pesanan ID %d siap.\n", line.ItemName, line.Quantity, order.ID)
+		}
+		return
+	}
+
+	fmt.Println("Baris pesanan tidak ada di stasiun ini.")
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Kebijakan saat antrian dapur penuh
+type KitchenFullPolicy string
+
+const (
+	KitchenFullDefer  KitchenFullPolicy = "defer"
+	KitchenFullReject KitchenFullPolicy = "reject"
+)
+
+// Antrian tiket dapur dengan batas kapasitas. Tiket selalu diterima sehingga pengirim
+// tidak pernah tertahan saat memegang kunci; dapur hanya memproses sebanyak
+// kitchen_queue_limit tiket sekaligus dan tiket lain menunggu giliran.
+type kitchenQueue struct {
+	mu      sync.Mutex
+	ready   *sync.Cond
+	waiting []Order
+	// Tiket yang sedang diproses dapur
+	active int
+	closed bool
+}
+
+// Fungsi untuk membuat antrian dapur kosong
+func newKitchenQueue() *kitchenQueue {
+	q := &kitchenQueue{}
+	q.ready = sync.NewCond(&q.mu)
+	return q
+}
+
+// Fungsi untuk menambahkan tiket ke antrian, false jika antrian sudah ditutup
+func (q *kitchenQueue) push(ticket Order) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return false
+	}
+	q.waiting = append(q.waiting, ticket)
+	q.ready.Broadcast()
+	return true
+}
+
+// Fungsi untuk mengambil tiket berikutnya setelah dapur punya kapasitas. Mengembalikan
+// false setelah antrian ditutup dan semua tiket sudah diambil.
+func (q *kitchenQueue) pop() (Order, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for {
+		limit := currentConfig().KitchenQueueLimit
+		if len(q.waiting) > 0 && (limit == 0 || q.active < limit) {
+			ticket := q.waiting[0]
+			q.waiting = q.waiting[1:]
+			q.active++
+			return ticket, true
+		}
+		if q.closed && len(q.waiting) == 0 {
+			return Order{}, false
+		}
+		q.ready.Wait()
+	}
+}
+
+// Fungsi untuk menandai satu tiket selesai diproses sehingga tiket berikutnya bisa masuk
+func (q *kitchenQueue) done() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.active--
+	q.ready.Broadcast()
+}
+
+// Fungsi untuk menutup antrian, tiket yang masih menunggu tetap diproses sampai habis
+func (q *kitchenQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.closed = true
+	q.ready.Broadcast()
+}
+
+// Fungsi untuk mengambil jumlah tiket yang menunggu dan yang sedang diproses
+func (q *kitchenQueue) backlog() (waiting, active int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.waiting), q.active
+}
+
+// Fungsi untuk memeriksa apakah dapur sudah mencapai kitchen_queue_limit
+func kitchenAtCapacity() bool {
+	limit := currentConfig().KitchenQueueLimit
+	waiting, active := orderQueue.backlog()
+	return limit > 0 && waiting+active >= limit
+}
+
+// Fungsi untuk menolak pesanan baru sebelum dibuat jika dapur penuh dan kebijakannya
+// reject. Dengan kebijakan defer pesanan tetap dibuat dan menunggu giliran di antrian.
+func checkKitchenCapacity() error {
+	if currentConfig().KitchenQueueFull != KitchenFullReject || !kitchenAtCapacity() {
+		return nil
+	}
+	waiting, active := orderQueue.backlog()
+	return fmt.Errorf("dapur penuh (%d tiket diproses, %d menunggu), coba lagi sebentar", active, waiting)
+}
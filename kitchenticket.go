@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Folder di dalam folder data untuk tiket dapur stasiun yang tidak punya printer
+const ticketsDir = "tickets"
+
+// Format tiket dapur yang didukung
+const (
+	TicketText   = "text"
+	TicketESCPOS = "escpos"
+)
+
+// Perintah ESC/POS untuk printer dapur: reset printer, huruf tebal besar untuk judul,
+// lalu dorong kertas dan potong sebagian setelah tiket selesai
+const (
+	escposInit       = "\x1b@"
+	escposTitleOn    = "\x1bE\x01\x1d!\x01"
+	escposTitleOff   = "\x1d!\x00\x1bE\x00"
+	escposFeedAndCut = "\n\n\n\x1dV\x42\x00"
+)
+
+// Struct untuk pengaturan tiket dapur per stasiun
+type KitchenTicketConfig struct {
+	// Format tiket: text atau escpos, kosongkan untuk tidak membuat tiket
+	Format string `json:"format"`
+	// Tujuan tiket per stasiun, misalnya {"grill": "/dev/usb/lp0"}. Stasiun tanpa tujuan
+	// ditulis ke folder tickets di folder data.
+	Printers map[string]string `json:"printers"`
+}
+
+// Fungsi untuk membuat tiket dapur setiap stasiun saat pesanan dikonfirmasi atau item
+// tertahan dikirim. Pesanan berupa salinan yang hanya berisi baris yang perlu dimasak,
+// setiap tiket hanya berisi baris stasiunnya dan terpisah dari struk pelanggan.
+// Kegagalan dari jalur latar belakang (background) ditulis lewat asyncPrintf.
+func printKitchenTickets(order Order, background bool) {
+	settings := currentConfig().KitchenTickets
+	if settings.Format == "" {
+		return
+	}
+	printf := func(format string, args ...any) { fmt.Printf(format, args...) }
+	if background {
+		printf = asyncPrintf
+	}
+
+	for _, station := range stations {
+		ticket := formatKitchenTicket(order, station, settings.Format)
+		if ticket == nil {
+			continue
+		}
+		if err := writeKitchenTicket(order.ID, station, ticket, settings); err != nil {
+			printf("Gagal mencetak tiket dapur %s pesanan ID %d: %v\n", station, order.ID, err)
+		}
+	}
+}
+
+// Fungsi untuk menyusun tiket dapur satu stasiun, nil jika stasiun tidak punya baris
+// yang perlu dimasak
+func formatKitchenTicket(order Order, station Station, format string) []byte {
+	var lines []OrderLine
+	for _, line := range order.Lines {
+		if line.Station == station && (line.Status == LineQueued || line.Status == LineHeld) {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+
+	title := newReceiptLayout()
+	title.Title("DAPUR " + string(station))
+
+	l := newReceiptLayout()
+	l.Line("Pesanan ID %d%s", order.ID, describeTable(order.Table, order.Delivery))
+	if order.PickupCode != "" {
+		l.Pair("Nomor Ambil", order.PickupCode)
+	}
+	created := order.CreatedAt
+	if created.IsZero() {
+		created = time.Now()
+	}
+	l.Pair("Waktu", formatDateTime(created))
+	writeOrderFields(l, order.Fields)
+	l.Separator("-")
+	for _, line := range lines {
+		l.Line("%dx %s", line.Quantity, line.ItemName)
+		for _, modifier := range line.Modifiers {
+			l.Line("   + %s", modifier.Name)
+		}
+		l.Line("   Fire: %s", describeTicketFire(line))
+	}
+	if order.Note != "" {
+		l.Separator("-")
+		l.Line("Catatan: %s", order.Note)
+	}
+
+	if format == TicketESCPOS {
+		return []byte(escposInit + escposTitleOn + title.String() + escposTitleOff + l.String() + escposFeedAndCut)
+	}
+	return []byte(title.String() + l.String() + "\n")
+}
+
+// Fungsi untuk menampilkan kapan baris mulai dimasak di tiket dapur
+func describeTicketFire(line OrderLine) string {
+	switch {
+	case line.Status == LineQueued:
+		return "sekarang"
+	case !line.FireAt.IsZero():
+		return line.FireAt.Local().Format("15:04")
+	default:
+		return "tunggu aba-aba kasir"
+	}
+}
+
+// Fungsi untuk mengirim tiket ke printer stasiun, atau menyimpannya ke folder tickets
+// jika stasiun tidak punya printer
+func writeKitchenTicket(orderID int, station Station, ticket []byte, settings KitchenTicketConfig) error {
+	path := settings.Printers[string(station)]
+	if path == "" {
+		dir := filepath.Join(currentConfig().DataDir, ticketsDir)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+		extension := ".txt"
+		if settings.Format == TicketESCPOS {
+			extension = ".bin"
+		}
+		path = filepath.Join(dir, fmt.Sprintf("tiket-%d-%s%s", orderID, station, extension))
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(ticket); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,376 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Jenis mutasi stok yang dicatat di buku besar inventaris
+type MovementKind string
+
+const (
+	MovementSale        MovementKind = "sale"
+	MovementReservation MovementKind = "reservation"
+	MovementRestock     MovementKind = "restock"
+	MovementAdjustment  MovementKind = "adjustment"
+	MovementReturn      MovementKind = "return"
+	MovementPurchase    MovementKind = "purchase"
+	MovementWaste       MovementKind = "waste"
+	MovementTransferOut MovementKind = "transfer_out"
+	MovementTransferIn  MovementKind = "transfer_in"
+	// Barang dari purchase order yang dikembalikan ke pemasok
+	MovementSupplierReturn MovementKind = "supplier_return"
+)
+
+// Jenis mutasi berbahasa Indonesia dari data lama
+var legacyMovementKinds = map[MovementKind]MovementKind{
+	"penjualan": MovementSale,
+	"reservasi": MovementReservation,
+	"koreksi":   MovementAdjustment,
+	"retur":     MovementReturn,
+	"pembelian": MovementPurchase,
+}
+
+// Fungsi untuk menampilkan jenis mutasi di layar
+func (k MovementKind) Label() string {
+	for legacy, kind := range legacyMovementKinds {
+		if kind == k {
+			return string(legacy)
+		}
+	}
+	return string(k)
+}
+
+// Fungsi untuk membaca jenis mutasi dari JSON, nilai lama ikut diterjemahkan
+func (k *MovementKind) UnmarshalJSON(data []byte) error {
+	return unmarshalLegacy(data, k, legacyMovementKinds)
+}
+
+// Struct untuk satu mutasi stok
+type StockMovement struct {
+	Time      time.Time    `json:"time"`
+	ItemName  string       `json:"item"`
+	Change    int          `json:"change"`
+	Kind      MovementKind `json:"kind"`
+	Reference string       `json:"reference"`
+	UnitCost  Money        `json:"unit_cost,omitempty"`
+}
+
+// Nama file buku besar inventaris di folder data, satu mutasi JSON per baris. Mutasi
+// hanya ditambahkan di akhir file sehingga riwayat lama tidak pernah ditulis ulang.
+const ledgerFile = "stock_ledger.jsonl"
+
+// Buku besar inventaris berisi semua mutasi stok, dibaca dari file saat pertama kali dipakai
+var ledger []StockMovement
+var ledgerLoaded bool
+var ledgerMutex sync.Mutex
+
+// Fungsi untuk membaca buku besar inventaris dari file jika belum dibaca. Pada storage
+// memory mutasi hanya ada selama program berjalan. Pemanggil harus memegang ledgerMutex.
+func ensureLedgerLoaded() {
+	if ledgerLoaded {
+		return
+	}
+	ledgerLoaded = true
+	if currentConfig().Storage == StorageMemory {
+		return
+	}
+	movements, err := readLedgerFile(filepath.Join(currentConfig().DataDir, ledgerFile))
+	if err != nil {
+		fmt.Println("Gagal membaca buku besar inventaris:", err)
+	}
+	ledger = append(movements, ledger...)
+}
+
+// Fungsi untuk membaca semua mutasi dari file buku besar, kosong jika file belum ada
+func readLedgerFile(path string) ([]StockMovement, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var movements []StockMovement
+	scanner := bufio.NewScanner(file)
+	line := 0
+	for scanner.Scan() {
+		line++
+		var movement StockMovement
+		if err := json.Unmarshal(scanner.Bytes(), &movement); err != nil {
+			return movements, fmt.Errorf("%s baris %d: %w", ledgerFile, line, err)
+		}
+		movements = append(movements, movement)
+	}
+	return movements, scanner.Err()
+}
+
+// Fungsi untuk menambahkan satu mutasi ke akhir file buku besar. Mutasi selama dry-run
+// tidak ditulis. Pemanggil harus memegang ledgerMutex.
+func appendLedgerFile(movement StockMovement) error {
+	if currentConfig().Storage == StorageMemory || dryRunActive() {
+		return nil
+	}
+	data, err := json.Marshal(movement)
+	if err != nil {
+		return err
+	}
+	dir := currentConfig().DataDir
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(filepath.Join(dir, ledgerFile), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Fungsi untuk mencatat mutasi stok, aman dipanggil sambil memegang menuMutex
+func recordMovement(itemName string, change int, kind MovementKind, reference string) {
+	recordCostedMovement(itemName, change, kind, reference, 0)
+}
+
+// Fungsi untuk mencatat mutasi stok beserta harga pokok per unit
+func recordCostedMovement(itemName string, change int, kind MovementKind, reference string, unitCost Money) {
+	ledgerMutex.Lock()
+	defer ledgerMutex.Unlock()
+
+	ensureLedgerLoaded()
+	movement := StockMovement{
+		Time:      time.Now(),
+		ItemName:  itemName,
+		Change:    change,
+		Kind:      kind,
+		Reference: reference,
+		UnitCost:  unitCost,
+	}
+	ledger = append(ledger, movement)
+	if err := appendLedgerFile(movement); err != nil {
+		fmt.Println("Gagal menyimpan mutasi stok:", err)
+	}
+}
+
+// Fungsi untuk membuat referensi mutasi dari ID pesanan
+func orderReference(orderID int) string {
+	return fmt.Sprintf("pesanan %d", orderID)
+}
+
+// Fungsi untuk menambah stok dari pemasok atau mengoreksi stok hasil hitung fisik
+func adjustStock(reader *bufio.Reader) {
+	fmt.Print("Jenis (1 = restock, 2 = koreksi): ")
+	kindInput, _ := reader.ReadString('\n')
+	var kind MovementKind
+	switch strings.TrimSpace(kindInput) {
+	case "1":
+		kind = MovementRestock
+	case "2":
+		kind = MovementAdjustment
+	default:
+		fmt.Println("Jenis tidak valid.")
+		return
+	}
+
+	fmt.Print("Masukkan nama item: ")
+	name, _ := reader.ReadString('\n')
+	name = strings.TrimSpace(name)
+
+	hint := describeUnitInput(name, false)
+	if hint == "" {
+		hint = " (misal 10 atau -2)"
+	}
+	fmt.Printf("Masukkan perubahan jumlah%s: ", hint)
+	changeInput, _ := reader.ReadString('\n')
+	change, err := parseUnitQuantity(name, changeInput, false)
+	if err != nil {
+		fmt.Println("Perubahan jumlah tidak valid:", err)
+		return
+	}
+	if change == 0 {
+		fmt.Println("Perubahan jumlah harus berupa angka selain nol.")
+		return
+	}
+	if kind == MovementRestock && change < 0 {
+		fmt.Println("Restock harus menambah stok.")
+		return
+	}
+
+	fmt.Print("Keterangan: ")
+	note, _ := reader.ReadString('\n')
+	note = strings.TrimSpace(note)
+	if kind == MovementAdjustment && note == "" {
+		fmt.Println("Koreksi stok wajib diberi keterangan.")
+		return
+	}
+
+	var expiresAt time.Time
+	if kind == MovementRestock {
+		var ok bool
+		expiresAt, ok = readOptionalDate(reader, "Tanggal kedaluwarsa (YYYY-MM-DD, kosongkan jika tidak ada): ")
+		if !ok {
+			return
+		}
+	}
+
+	menuMutex.Lock()
+	defer menuMutex.Unlock()
+
+	item := findMenuItem(name)
+	if item == nil {
+		fmt.Println("Item tidak ditemukan.")
+		return
+	}
+	if item.Quantity+change < 0 {
+		fmt.Println("Stok tidak boleh menjadi negatif.")
+		return
+	}
+
+	if change > 0 {
+		err = item.addStock(change, expiresAt)
+	} else {
+		err = item.removeStock(-change)
+	}
+	if err != nil {
+		fmt.Println("Gagal mengubah stok:", err)
+		return
+	}
+	recordMovement(item.Name, change, kind, note)
+	fmt.Printf("Stok %s sekarang %s.\n", item.Name, describeStockQuantity(item.Name, item.Quantity))
+}
+
+// Fungsi untuk menyaring mutasi berdasarkan item dan rentang tanggal (to bersifat eksklusif)
+func filterMovements(itemName string, from, to time.Time) []StockMovement {
+	ledgerMutex.Lock()
+	defer ledgerMutex.Unlock()
+
+	ensureLedgerLoaded()
+	var result []StockMovement
+	for _, movement := range ledger {
+		if itemName != "" && !strings.EqualFold(movement.ItemName, itemName) {
+			continue
+		}
+		if !from.IsZero() && movement.Time.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !movement.Time.Before(to) {
+			continue
+		}
+		result = append(result, movement)
+	}
+	return result
+}
+
+// Fungsi untuk membaca tanggal opsional dengan format YYYY-MM-DD
+func readOptionalDate(reader *bufio.Reader, prompt string) (time.Time, bool) {
+	fmt.Print(prompt)
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return time.Time{}, true
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", input, time.Local)
+	if err != nil {
+		fmt.Println("Format tanggal harus YYYY-MM-DD.")
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// Fungsi untuk mengekspor mutasi stok ke file CSV atau JSON untuk rekonsiliasi dengan pemasok
+func exportMovements(reader *bufio.Reader) {
+	fmt.Print("Format (csv/json): ")
+	formatInput, _ := reader.ReadString('\n')
+	format := strings.ToLower(strings.TrimSpace(formatInput))
+	if format != "csv" && format != "json" {
+		fmt.Println("Format harus csv atau json.")
+		return
+	}
+
+	fmt.Print("Filter nama item (kosongkan untuk semua): ")
+	itemName, _ := reader.ReadString('\n')
+	itemName = strings.TrimSpace(itemName)
+
+	from, ok := readOptionalDate(reader, "Dari tanggal (YYYY-MM-DD, kosongkan untuk semua): ")
+	if !ok {
+		return
+	}
+	to, ok := readOptionalDate(reader, "Sampai tanggal (YYYY-MM-DD, kosongkan untuk semua): ")
+	if !ok {
+		return
+	}
+	if !to.IsZero() {
+		// Tanggal akhir ikut dihitung sampai akhir hari
+		to = to.AddDate(0, 0, 1)
+	}
+
+	fmt.Printf("Nama file (default mutasi_stok.%s): ", format)
+	path, _ := reader.ReadString('\n')
+	path = strings.TrimSpace(path)
+	if path == "" {
+		path = "mutasi_stok." + format
+	}
+
+	movements := filterMovements(itemName, from, to)
+	var err error
+	if format == "csv" {
+		err = writeMovementsCSV(path, movements)
+	} else {
+		err = writeMovementsJSON(path, movements)
+	}
+	if err != nil {
+		fmt.Println("Gagal mengekspor mutasi stok:", err)
+		return
+	}
+
+	fmt.Printf("%d mutasi stok diekspor ke %s.\n", len(movements), path)
+}
+
+// Fungsi untuk menulis mutasi stok dalam format CSV
+func writeMovementsCSV(path string, movements []StockMovement) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	writer.Write([]string{"time", "item", "change", "kind", "reference", "unit_cost"})
+	for _, movement := range movements {
+		writer.Write([]string{
+			movement.Time.Format(time.RFC3339),
+			movement.ItemName,
+			strconv.Itoa(movement.Change),
+			string(movement.Kind),
+			movement.Reference,
+			formatMoneyPlain(movement.UnitCost),
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Fungsi untuk menulis mutasi stok dalam format JSON
+func writeMovementsJSON(path string, movements []StockMovement) error {
+	data, err := json.MarshalIndent(movements, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
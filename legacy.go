@@ -0,0 +1,25 @@
+package main
+
+import "encoding/json"
+
+// Nilai domain (status, metode bayar, jenis mutasi dan sebagainya) disimpan dan dikirim
+// lewat API dalam bahasa Inggris yang tetap, sedangkan teks di layar memakai Label().
+// Data lama masih berisi nilai berbahasa Indonesia, jadi nilai itu diterjemahkan saat dibaca.
+
+// Fungsi untuk menerjemahkan nilai lama ke nilai domain yang baru, nilai lain dikembalikan apa adanya
+func normalizeLegacy[T ~string](value T, legacy map[T]T) T {
+	if current, ok := legacy[value]; ok {
+		return current
+	}
+	return value
+}
+
+// Fungsi untuk membaca nilai domain dari JSON sambil menerjemahkan nilai lama
+func unmarshalLegacy[T ~string](data []byte, target *T, legacy map[T]T) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*target = normalizeLegacy(T(value), legacy)
+	return nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+)
+
+// Fungsi untuk mengambil batas jumlah per baris pesanan sebuah item. Batas item
+// (atau item induk untuk varian) didahulukan, 0 berarti tanpa batas.
+// Pemanggil harus memegang menuMutex.
+func lineQuantityLimit(item *MenuItem) int {
+	if item.MaxQuantity > 0 {
+		return item.MaxQuantity
+	}
+	if parent := variantParent(item); parent != nil && parent.MaxQuantity > 0 {
+		return parent.MaxQuantity
+	}
+	return currentConfig().MaxLineQuantity
+}
+
+// Fungsi untuk menolak jumlah di atas batas, dipakai pesanan cepat dan API yang
+// tidak bisa meminta PIN manajer. Pemanggil harus memegang menuMutex.
+func checkLineQuantity(item *MenuItem, quantity int) error {
+	if limit := lineQuantityLimit(item); limit > 0 && quantity > limit {
+		return fmt.Errorf("Jumlah %s (%d) melebihi batas %d per baris, buat lewat menu Buat Pesanan dengan PIN manajer.", item.Name, quantity, limit)
+	}
+	return nil
+}
+
+// Fungsi untuk meminta PIN manajer jika jumlah melebihi batas per baris. Pemanggil
+// tidak boleh memegang menuMutex karena PIN dibaca dari kasir.
+func approveLineQuantity(reader *bufio.Reader, name string, quantity int) bool {
+	menuMutex.Lock()
+	limit := 0
+	if item := findMenuItem(name); item != nil {
+		limit = lineQuantityLimit(item)
+	}
+	menuMutex.Unlock()
+	if limit == 0 || quantity <= limit {
+		return true
+	}
+	fmt.Printf("Jumlah %s (%d) melebihi batas %d per baris.\n", name, quantity, limit)
+	return requireAdminPIN(reader, fmt.Sprintf("jumlah %s x%d di atas batas %d", name, quantity, limit))
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Struct untuk aturan format angka dan tanggal satu locale
+type localeFormat struct {
+	Thousands string
+	Decimal   string
+	Date      string
+}
+
+// Locale yang didukung, id-ID memakai 1.234,56 dan DD/MM/YYYY
+var locales = map[string]localeFormat{
+	"id-ID": {Thousands: ".", Decimal: ",", Date: "02/01/2006"},
+	"en-US": {Thousands: ",", Decimal: ".", Date: "01/02/2006"},
+	"en-GB": {Thousands: ",", Decimal: ".", Date: "02/01/2006"},
+}
+
+// Fungsi untuk mengambil aturan format locale dari konfigurasi
+func currentLocale() localeFormat {
+	if format, ok := locales[currentConfig().Locale]; ok {
+		return format
+	}
+	return locales["id-ID"]
+}
+
+// Fungsi untuk menampilkan angka dengan pemisah ribuan dan desimal sesuai locale
+func formatNumber(value float64, decimals int) string {
+	format := currentLocale()
+	text := strconv.FormatFloat(math.Abs(value), 'f', decimals, 64)
+	whole, fraction, _ := strings.Cut(text, ".")
+
+	var b strings.Builder
+	if value < 0 && strings.Trim(text, "0.") != "" {
+		b.WriteByte('-')
+	}
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(format.Thousands)
+		}
+		b.WriteRune(digit)
+	}
+	if fraction != "" {
+		b.WriteString(format.Decimal)
+		b.WriteString(fraction)
+	}
+	return b.String()
+}
+
+// Fungsi untuk menampilkan nominal uang dengan mata uang dari konfigurasi
+func formatMoney(amount Money) string {
+	return currentConfig().Currency + " " + formatNumber(amount.Float(), 2)
+}
+
+// Fungsi untuk menampilkan selisih uang dengan tanda di depan mata uang, misalnya -Rp 6.000,00
+func formatMoneyChange(amount Money) string {
+	if amount < 0 {
+		return "-" + formatMoney(-amount)
+	}
+	return "+" + formatMoney(amount)
+}
+
+// Fungsi untuk menampilkan jumlah bulat seperti stok dengan pemisah ribuan
+func formatQuantity(quantity int) string {
+	return formatNumber(float64(quantity), 0)
+}
+
+// Fungsi untuk menampilkan persentase, misalnya tarif pajak
+func formatPercent(value float64) string {
+	return formatNumber(value, 1) + "%"
+}
+
+// Fungsi untuk menampilkan tanggal sesuai locale
+func formatDate(t time.Time) string {
+	return t.Local().Format(currentLocale().Date)
+}
+
+// Fungsi untuk menampilkan tanggal dan jam sesuai locale
+func formatDateTime(t time.Time) string {
+	return t.Local().Format(currentLocale().Date + " 15:04")
+}
+
+// Fungsi untuk membaca angka yang diketik kasir sesuai kebiasaan setempat, misalnya
+// "1.000" untuk seribu, "1,5" untuk satu setengah atau "Rp 15.000". Jika hanya ada satu
+// pemisah, pemisah ribuan locale yang diikuti tepat tiga angka dianggap ribuan dan selain
+// itu dianggap desimal. Hasilnya berbentuk "1000" atau "1.5".
+func normalizeNumberInput(input string) (string, error) {
+	text := strings.TrimSpace(input)
+	for _, prefix := range []string{currentConfig().Currency, "Rp"} {
+		if prefix != "" && len(text) >= len(prefix) && strings.EqualFold(text[:len(prefix)], prefix) {
+			text = text[len(prefix):]
+			break
+		}
+	}
+	text = strings.NewReplacer(" ", "", "_", "").Replace(text)
+	invalid := fmt.Errorf("angka %q tidak valid", strings.TrimSpace(input))
+
+	sign := ""
+	if strings.HasPrefix(text, "-") || strings.HasPrefix(text, "+") {
+		sign, text = strings.TrimPrefix(text[:1], "+"), text[1:]
+	}
+	if text == "" || strings.Trim(text, "0123456789.,") != "" {
+		return "", invalid
+	}
+
+	thousands, decimal := "", ""
+	lastDot, lastComma := strings.LastIndex(text, "."), strings.LastIndex(text, ",")
+	switch {
+	case lastDot >= 0 && lastComma >= 0:
+		thousands, decimal = ",", "."
+		if lastComma > lastDot {
+			thousands, decimal = ".", ","
+		}
+	case lastDot >= 0 || lastComma >= 0:
+		separator, index := ".", lastDot
+		if lastComma >= 0 {
+			separator, index = ",", lastComma
+		}
+		if strings.Count(text, separator) > 1 || (separator == currentLocale().Thousands && len(text)-index-1 == 3) {
+			thousands = separator
+		} else {
+			decimal = separator
+		}
+	}
+
+	whole, fraction := text, ""
+	if decimal != "" {
+		var found bool
+		whole, fraction, found = strings.Cut(text, decimal)
+		if !found || fraction == "" || strings.Trim(fraction, "0123456789") != "" {
+			return "", invalid
+		}
+	}
+	if thousands != "" {
+		groups := strings.Split(whole, thousands)
+		for i, group := range groups {
+			if group == "" || len(group) > 3 || (i > 0 && len(group) != 3) {
+				return "", invalid
+			}
+		}
+		whole = strings.Join(groups, "")
+	}
+	if whole == "" || strings.Trim(whole, "0123456789") != "" {
+		return "", invalid
+	}
+	if fraction != "" {
+		return sign + whole + "." + fraction, nil
+	}
+	return sign + whole, nil
+}
+
+// Fungsi untuk membaca nominal uang yang diketik kasir, misalnya "15.000" atau "Rp 2,5"
+func parseMoneyInput(input string) (Money, error) {
+	text, err := normalizeNumberInput(input)
+	if err != nil {
+		return 0, err
+	}
+	return parseMoney(text)
+}
+
+// Fungsi untuk membaca jumlah bulat yang diketik kasir, misalnya "1.000". Jumlah pecahan
+// seperti "1,5" ditolak karena stok dihitung per porsi.
+func parseQuantityInput(input string) (int, error) {
+	text, err := normalizeNumberInput(input)
+	if err != nil {
+		return 0, err
+	}
+	whole, fraction, _ := strings.Cut(text, ".")
+	if strings.Trim(fraction, "0") != "" {
+		return 0, fmt.Errorf("jumlah %q harus bilangan bulat", strings.TrimSpace(input))
+	}
+	return strconv.Atoi(whole)
+}
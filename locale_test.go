@@ -0,0 +1,152 @@
+package main
+
+import "testing"
+
+// Fungsi untuk memakai locale dan mata uang tertentu selama satu test
+func useLocale(t *testing.T, locale, currency string) {
+	t.Helper()
+	previous := currentConfig()
+	cfg := previous
+	cfg.Locale = locale
+	cfg.Currency = currency
+	configMutex.Lock()
+	config = cfg
+	configMutex.Unlock()
+	t.Cleanup(func() {
+		configMutex.Lock()
+		config = previous
+		configMutex.Unlock()
+	})
+}
+
+// Kasus input yang sama dipakai untuk semua locale: satu pemisah yang bukan pemisah
+// ribuan locale, atau yang tidak diikuti tepat tiga angka, dianggap desimal
+var normalizeNumberCases = []struct {
+	locale, currency string
+	input            string
+	want             string
+	wantErr          bool
+}{
+	{"id-ID", "Rp", "1.000", "1000", false},
+	{"id-ID", "Rp", "1,000", "1.000", false},
+	{"id-ID", "Rp", "1,5", "1.5", false},
+	{"id-ID", "Rp", "1.00", "1.00", false},
+	{"id-ID", "Rp", "1.234.567", "1234567", false},
+	{"id-ID", "Rp", "1.234,56", "1234.56", false},
+	{"id-ID", "Rp", "1,234.56", "1234.56", false},
+	{"id-ID", "Rp", "Rp 15.000", "15000", false},
+	{"id-ID", "Rp", "rp15.000,50", "15000.50", false},
+	{"id-ID", "Rp", "1 000", "1000", false},
+	{"id-ID", "Rp", "-2,5", "-2.5", false},
+	{"id-ID", "Rp", "+3", "3", false},
+	{"id-ID", "Rp", "", "", true},
+	{"id-ID", "Rp", "Rp", "", true},
+	{"id-ID", "Rp", "abc", "", true},
+	{"id-ID", "Rp", "12a", "", true},
+	{"id-ID", "Rp", "1.2.3", "", true},
+	{"id-ID", "Rp", "12.34.567", "", true},
+	{"id-ID", "Rp", "1.234,5,6", "", true},
+	{"id-ID", "Rp", ",5", "", true},
+	{"id-ID", "Rp", "1,", "", true},
+	{"id-ID", "Rp", "--1", "", true},
+
+	{"en-US", "$", "1.000", "1.000", false},
+	{"en-US", "$", "1,000", "1000", false},
+	{"en-US", "$", "1,5", "1.5", false},
+	{"en-US", "$", "1.5", "1.5", false},
+	{"en-US", "$", "1,234,567", "1234567", false},
+	{"en-US", "$", "1,234.56", "1234.56", false},
+	{"en-US", "$", "$1,000", "1000", false},
+	// Awalan Rp selalu dikenali, tetapi titiknya tetap mengikuti locale
+	{"en-US", "$", "Rp 5.000", "5.000", false},
+	{"en-US", "$", "1,2,3", "", true},
+	{"en-US", "$", "1,234.5.6", "", true},
+	{"en-US", "$", "$", "", true},
+
+	{"en-GB", "£", "1,000", "1000", false},
+	{"en-GB", "£", "1.000", "1.000", false},
+	{"en-GB", "£", "£2,500.75", "2500.75", false},
+	{"en-GB", "£", "2.500,75", "2500.75", false},
+}
+
+func TestNormalizeNumberInput(t *testing.T) {
+	for _, tt := range normalizeNumberCases {
+		t.Run(tt.locale+"/"+tt.input, func(t *testing.T) {
+			useLocale(t, tt.locale, tt.currency)
+			got, err := normalizeNumberInput(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("normalizeNumberInput(%q) galat = %v, ingin galat %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("normalizeNumberInput(%q) = %q, ingin %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQuantityInput(t *testing.T) {
+	tests := []struct {
+		locale  string
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{"id-ID", "12", 12, false},
+		{"id-ID", "1.000", 1000, false},
+		{"id-ID", "1,000", 1, false},
+		{"id-ID", "2,0", 2, false},
+		{"id-ID", "1,5", 0, true},
+		{"id-ID", "dua", 0, true},
+		{"en-US", "1,000", 1000, false},
+		{"en-US", "1.000", 1, false},
+		{"en-US", "1.5", 0, true},
+		{"en-GB", "12,345", 12345, false},
+		{"en-GB", "0.25", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.locale+"/"+tt.input, func(t *testing.T) {
+			useLocale(t, tt.locale, "Rp")
+			got, err := parseQuantityInput(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseQuantityInput(%q) galat = %v, ingin galat %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseQuantityInput(%q) = %d, ingin %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMoneyInput(t *testing.T) {
+	tests := []struct {
+		locale, currency string
+		input            string
+		want             Money
+		wantErr          bool
+	}{
+		{"id-ID", "Rp", "15.000", 1500000, false},
+		{"id-ID", "Rp", "Rp 15.000,50", 1500050, false},
+		{"id-ID", "Rp", "2,5", 250, false},
+		{"id-ID", "Rp", "1.234,565", 123457, false},
+		{"id-ID", "Rp", "1,000", 100, false},
+		{"id-ID", "Rp", "15.000,-", 0, true},
+		{"en-US", "$", "$1,234.5", 123450, false},
+		{"en-US", "$", "1.000", 100, false},
+		{"en-US", "$", "1,000", 100000, false},
+		{"en-US", "$", "12,34", 1234, false},
+		{"en-US", "$", "1,23,456", 0, true},
+		{"en-GB", "£", "£0.99", 99, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.locale+"/"+tt.input, func(t *testing.T) {
+			useLocale(t, tt.locale, tt.currency)
+			got, err := parseMoneyInput(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseMoneyInput(%q) galat = %v, ingin galat %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseMoneyInput(%q) = %d sen, ingin %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -3,9 +3,10 @@ package main
 import (
 	"bufio"
 	"encoding/base64"
+	"flag"
 	"fmt"
+	"io"
 	"os"
-	"regexp"
 	"strconv"
 	"strings"
 	"sync"
@@ -14,9 +15,30 @@ import (
 
 // Struct untuk merepresentasikan item menu
 type MenuItem struct {
-	Name     string
-	Price    float64
-	Quantity int
+	Name     string       `json:"name"`
+	Price    Money        `json:"price"`
+	Quantity int          `json:"quantity"`
+	Station  Station      `json:"station"`
+	Cost     Money        `json:"cost"`
+	Batches  []StockBatch `json:"batches,omitempty"`
+	Version  int          `json:"version"`
+	// Varian item (misalnya kecil/besar) dengan harga dan stok sendiri. Item yang
+	// punya varian tidak dipesan langsung, kasir memilih salah satu variannya.
+	Variants []MenuItem `json:"variants,omitempty"`
+	// Jenis pesanan yang tidak boleh memuat item ini, misalnya minuman draft tidak bisa diantar
+	Restricted []OrderType `json:"restricted,omitempty"`
+	// Batas jumlah per baris pesanan, 0 berarti memakai batas global dari konfigurasi
+	MaxQuantity int `json:"max_quantity,omitempty"`
+	// Masa musim item, di luar rentang ini item disembunyikan dari pemesanan kecuali
+	// dipaksa tampil. Tanggal kosong berarti tanpa batas di sisi itu.
+	SeasonStart time.Time `json:"season_start,omitzero"`
+	SeasonEnd   time.Time `json:"season_end,omitzero"`
+	ForceShow   bool      `json:"force_show,omitempty"`
+	// Course item (misalnya minuman, pembuka, utama) untuk jeda fire otomatis di course_delays
+	Course string `json:"course,omitempty"`
+	// Item di-86: sementara tidak bisa dipesan tanpa mengubah stok, misalnya wajan rusak
+	Unavailable       bool   `json:"unavailable,omitempty"`
+	UnavailableReason string `json:"unavailable_reason,omitempty"`
 }
 
 // Interface untuk mendefinisikan metode umum pesanan
@@ -24,13 +46,81 @@ type OrderProcessor interface {
 	ProcessOrder(order Order) error
 }
 
+// Struct untuk satu baris item di dalam pesanan
+type OrderLine struct {
+	No         int        `json:"no"`
+	ItemName   string     `json:"item_name"`
+	Quantity   int        `json:"quantity"`
+	Price      Money      `json:"price"`
+	TotalPrice Money      `json:"total_price"`
+	Status     LineStatus `json:"status"`
+	Returned   int        `json:"returned"`
+	Station    Station    `json:"station"`
+	// Waktu mulai diproses dan waktu di-bump siap, untuk menghitung waktu persiapan
+	StartedAt     time.Time     `json:"started_at"`
+	ReadyAt       time.Time     `json:"ready_at"`
+	EstimatedPrep time.Duration `json:"estimated_prep"`
+	// Harga menu sebelum diubah manual dan alasannya, kosong jika memakai harga menu
+	ListPrice   Money  `json:"list_price,omitempty"`
+	PriceReason string `json:"price_reason,omitempty"`
+	// Waktu baris yang ditahan dikirim otomatis ke dapur sesuai course, kosong jika dikirim manual
+	FireAt time.Time `json:"fire_at,omitzero"`
+	// Tambahan yang dipilih, harganya sudah termasuk di Price
+	Modifiers []LineModifier `json:"modifiers,omitempty"`
+}
+
 // Struct untuk pesanan
 type Order struct {
-	ID         int
-	ItemName   string
-	Quantity   int
-	Price      float64
-	TotalPrice float64
+	ID         int         `json:"id"`
+	Table      int         `json:"table"`
+	Lines      []OrderLine `json:"lines"`
+	TotalPrice Money       `json:"total_price"`
+	Paid       bool        `json:"paid"`
+	PickupAt   time.Time   `json:"pickup_at"`
+	Discount   Money       `json:"discount"`
+	Voided     bool        `json:"voided"`
+	CreatedAt  time.Time   `json:"created_at"`
+	// Email pelanggan yang dicatat saat pembayaran untuk pengiriman struk
+	CustomerEmail string `json:"customer_email,omitempty"`
+	// Waktu pembayaran, dipakai untuk menghitung uang tunai di laci
+	PaidAt time.Time `json:"paid_at"`
+	// Catatan pesanan untuk dapur, misalnya "tanpa sambal"
+	Note string `json:"note,omitempty"`
+	// Saluran asal pesanan: counter, kiosk, api atau bot
+	Source OrderSource `json:"source,omitempty"`
+	// Daftar harga dari price_lists yang dipakai pesanan ini, kosong berarti harga menu
+	PriceList string `json:"price_list,omitempty"`
+	// Isi kolom tambahan dari order_fields di konfigurasi, per kunci kolom
+	Fields map[string]string `json:"fields,omitempty"`
+	// Nomor ambil pendek yang diulang dari 1 setiap hari, misalnya A-17
+	PickupCode string `json:"pickup_code,omitempty"`
+	// Jumlah tamu (cover) untuk pesanan makan di tempat, 0 berarti tidak dicatat
+	Guests int `json:"guests,omitempty"`
+	// Metode pembayaran, kosong pada data lama dianggap tunai
+	PaymentMethod PaymentMethod `json:"payment_method,omitempty"`
+	// Uang tunai yang diterima kasir, kembalian dihitung dari tagihan
+	CashTendered Money `json:"cash_tendered,omitempty"`
+	// Kasir yang membuat pesanan, atau "api:<klien>" untuk pesanan dari API
+	Cashier string `json:"cashier,omitempty"`
+	// Pesanan diantar ke pelanggan, selalu tanpa nomor meja
+	Delivery bool `json:"delivery,omitempty"`
+	// Pesanan tujuan jika pesanan ini sudah digabung, baris dan nilainya sudah dipindah
+	MergedInto int `json:"merged_into,omitempty"`
+	// Pesanan-pesanan yang digabung ke pesanan ini
+	MergedFrom []int `json:"merged_from,omitempty"`
+	// Pesanan belum dikonfirmasi dapur, pendapatannya belum masuk total
+	PendingAck bool `json:"pending_ack,omitempty"`
+	// Waktu dapur mengonfirmasi pesanan, manual atau otomatis setelah batas waktu
+	AcknowledgedAt time.Time `json:"acknowledged_at"`
+	// Nama pelanggan jika pesanan masuk tab yang ditagih sekaligus saat ditutup
+	Tab string `json:"tab,omitempty"`
+	// Pembayaran sebagian dari tab atau dari tagihan yang dibayar dengan beberapa metode
+	TabPayments []TabPayment `json:"tab_payments,omitempty"`
+	// Persetujuan manajer untuk diskon di atas batas persentase
+	DiscountApproval DiscountApproval `json:"discount_approval,omitzero"`
+	// Biaya cover dan top-up minimum belanja meja, dihitung saat meja dibayar
+	CoverCharge       Money `json:"cover_charge,omitempty"`
+	MinimumSpendTopUp Money `json:"minimum_spend_top_up,omitempty"`
 }
 
 // Interface kosong untuk menangani berbagai tipe data
@@ -39,25 +129,76 @@ type Any interface{}
 // Mutex untuk menghindari race condition saat mengakses menu
 var menuMutex sync.Mutex
 
-// Menu slice untuk menyimpan item menu
-var menu = []MenuItem{
-	{Name: "Nasi Goreng", Price: 15000, Quantity: 10},
-	{Name: "Mie Ayam", Price: 12000, Quantity: 8},
-	{Name: "Sate Ayam", Price: 20000, Quantity: 5},
-	{Name: "Es Teh", Price: 5000, Quantity: 20},
+// Menu slice untuk menyimpan item menu, berisi menu awal sampai ada menu tersimpan
+var menu []MenuItem
+
+// Pilihan menu utama, nomor opsi sesuai urutan di slice ini
+var mainMenuOptions = []string{
+	"Tampilkan Menu",
+	"Buat Pesanan",
+	"Tampilkan Total Semua Pesanan",
+	"Keluar",
+	"Tampilkan Antrian Dapur",
+	"Kirim Item Tertahan ke Dapur",
+	"Ulangi Pesanan Sebelumnya",
+	"Bayar Pesanan",
+	"Retur Item Pesanan",
+	"Buat Pesanan Terjadwal",
+	"Tampilan Stasiun Dapur",
+	"Restock / Koreksi Stok",
+	"Ekspor Mutasi Stok",
+	"Pemasok & Purchase Order",
+	"Laporan Stok Hampir Kedaluwarsa",
+	"Catat Waste",
+	"Laporan Waste",
+	"Ubah Item Menu",
+	"Batalkan Pesanan (Void)",
+	"Hapus Item Menu",
+	"Beri Diskon Pesanan",
+	"Tutup Hari",
+	"Ganti Kasir",
+	"Ekspor Log Aktivitas",
+	"Kirim Struk via Email",
+	"Laporan Kinerja Dapur",
+	"Laporan Jam Sibuk",
+	"Kelola Varian Item",
+	"Saran Reorder",
+	"Kas & Petty Cash",
+	"Biaya & Laba Rugi",
+	"Laporan Cover Tamu",
+	"Matriks Menu Engineering",
+	"Laporan Kustom",
+	"Laporan Kinerja Kasir",
+	"Gabung Pesanan / Meja",
+	"Pindah Meja",
+	"Tab Pelanggan",
+	"Ubah Harga Massal",
+	"Transfer Stok Cabang",
+	"Cetak Ulang Struk",
+	"Status Sinkronisasi",
+	"Periksa Integritas Data",
+	"Persetujuan Diskon",
+	"Tandai Item Tidak Tersedia (86)",
+	"Batal Fire Otomatis Course",
+	"Spesial Hari Ini",
+	"Ekspor Jurnal Akuntansi",
+	"Panggil Pesanan Parkir",
+	"Snapshot Stok Shift",
+	"Ekspor Data Anonim",
+	"Cetak Tagihan Sementara",
 }
 
 // WaitGroup untuk menunggu semua goroutine selesai
 var wg sync.WaitGroup
 
-// Channel untuk komunikasi antara goroutine dan proses utama
-var orderChan = make(chan Order, 10)
+// Antrian tiket antara kasir dan goroutine dapur
+var orderQueue = newKitchenQueue()
 
 // Timeout duration untuk pemrosesan pesanan
 const timeoutDuration = 5 * time.Second
 
 // Variable untuk menyimpan total semua pesanan
-var totalAllOrders float64
+var totalAllOrders Money
 var totalMutex sync.Mutex
 
 func main() {
@@ -66,50 +207,379 @@ func main() {
 		if r := recover(); r != nil {
 			fmt.Println("Recovered from error:", r)
 		}
-		fmt.Println("Program selesai")
+		if !silentExit {
+			fmt.Println("Program selesai")
+		}
 	}()
 
+	quickOrderFlag := flag.String("order", "", `buat satu pesanan lalu keluar, misalnya -order '2 Nasi Goreng, 1 Es Teh meja 4 catatan "tanpa sambal"'`)
+	serveFlag := flag.String("serve", "", "jalankan server API HTTP di alamat ini, misalnya :8080")
+	tutorialFlag := flag.Bool("tutorial", false, "jalankan tutorial kasir baru dengan data contoh yang tidak disimpan")
+	dryRunFlag := flag.Bool("dry-run", false, "bersama -order, tampilkan perubahan stok dan total tanpa menyimpan pesanan")
+	openAPIFlag := flag.String("openapi", "", "tulis dokumen OpenAPI server API ke file ini lalu keluar, - untuk layar")
+	scriptFlag := flag.String("script", "", "jalankan input kasir dari file ini baris demi baris dengan data sementara lalu keluar, misalnya -script orders.txt")
+	daemonFlag := flag.String("daemon", "", "jalankan daemon yang memegang data untuk beberapa terminal kasir di socket ini, misalnya -daemon data/kasir.sock atau -daemon 127.0.0.1:7070")
+	attachFlag := flag.String("attach", "", "sambungkan terminal kasir ke daemon di socket ini, misalnya -attach data/kasir.sock")
+	flag.Parse()
+	if args := flag.Args(); len(args) >= 1 && args[0] == "completion" {
+		runCompletion(args[1:])
+		return
+	}
+	if *attachFlag != "" {
+		runAttach(*attachFlag)
+		return
+	}
+
+	reader := newInputReader(os.Stdin)
+	if *scriptFlag != "" {
+		source, file, err := openScript(*scriptFlag)
+		if err != nil {
+			fmt.Println("Gagal membuka skrip:", err)
+			return
+		}
+		defer file.Close()
+		reader = newInputReader(source)
+		// Dapur langsung memproses dan penjadwal tidak berjalan agar hasil skrip selalu sama
+		kitchenDelay = 0
+	}
+
+	if *scriptFlag != "" {
+		cleanup, err := prepareScriptSandbox()
+		if err != nil {
+			fmt.Println("Gagal menyiapkan folder skrip:", err)
+			return
+		}
+		defer cleanup()
+	} else if err := loadConfig(configFile); err != nil {
+		fmt.Println("Gagal membaca konfigurasi, memakai pengaturan bawaan:", err)
+	}
+	if *tutorialFlag {
+		runTutorial(reader)
+		return
+	}
+	if args := flag.Args(); len(args) >= 2 && args[0] == "menu" && args[1] == "diff" {
+		runMenuDiff(args[2:])
+		return
+	}
+	if args := flag.Args(); len(args) >= 1 && args[0] == "fsck" {
+		runFsck(args[1:])
+		return
+	}
+	if args := flag.Args(); len(args) >= 1 && args[0] == "events" {
+		runEventLog(args[1:])
+		return
+	}
+	if *openAPIFlag != "" {
+		if err := writeOpenAPISpec(*openAPIFlag); err != nil {
+			fmt.Println("Gagal menulis dokumen OpenAPI:", err)
+		}
+		return
+	}
+
+	seedMenu := true
+	if *scriptFlag == "" && isFirstRun() {
+		seedMenu = runSetupWizard(reader)
+	}
+	if seedMenu {
+		items, err := loadDefaultMenu(currentConfig().DefaultMenuFile)
+		if err != nil {
+			fmt.Println("Gagal membaca menu awal, memakai menu bawaan:", err)
+			items, _ = loadDefaultMenu("")
+		}
+		menu = items
+	}
+
+	if err := openStorage(currentConfig()); err != nil {
+		fmt.Println("Gagal membuka penyimpanan:", err)
+		return
+	}
+	if err := loadStateWithRecovery(reader); err != nil {
+		fmt.Println("Gagal memuat data:", err)
+		return
+	}
+	restoreArchivedOrderID()
+	if count, err := archiveOldOrders(time.Now()); err != nil {
+		fmt.Println("Gagal mengarsipkan pesanan lama:", err)
+	} else if count > 0 {
+		fmt.Printf("%d pesanan lama dipindahkan ke arsip.\n", count)
+	}
+
+	if !seedMenu {
+		menuMutex.Lock()
+		menu = []MenuItem{}
+		menuMutex.Unlock()
+		saveState()
+	}
+
+	logActivity("mulai sesi")
+	defer logActivity("akhir sesi")
+	startShift()
+
+	// Keluaran daemon dialihkan sebelum goroutine lain mulai mencetak
+	var router *outputRouter
+	if *daemonFlag != "" {
+		var err error
+		if router, err = newOutputRouter(); err != nil {
+			fmt.Println("Gagal menyiapkan daemon:", err)
+			return
+		}
+	}
+
 	// Mulai pemrosesan pesanan
 	go processOrders()
+	stopScheduler := func() {}
+	if *scriptFlag == "" {
+		stopScheduler = startScheduler()
+	}
+	resumeQueuedOrders()
+	resumeCourseTimers()
+
+	// Pesanan dari argumen baris perintah diproses lalu program langsung selesai
+	if *quickOrderFlag != "" {
+		logActivity("pesanan cepat dari argumen")
+		var snapshot dryRunSnapshot
+		if *dryRunFlag {
+			snapshot = beginDryRun()
+		}
+		if err := checkKitchenCapacity(); err != nil {
+			fmt.Printf("Pesanan tidak bisa dibuat: %v.\n", err)
+		} else if orderID, ok := newOrderID(); ok {
+			if order := createQuickOrder(orderID, *quickOrderFlag); order != nil {
+				submitOrder(order)
+			}
+		}
+		if *dryRunFlag {
+			endDryRun(snapshot)
+			stopScheduler()
+			return
+		}
+		shutdown(stopScheduler)
+		return
+	}
 
-	reader := bufio.NewReader(os.Stdin)
-	orderID := 1
+	if *serveFlag != "" {
+		server := startAPIServer(*serveFlag)
+		defer server.Close()
+	}
 
+	rememberWatchedFiles()
+	if *daemonFlag != "" {
+		runDaemon(*daemonFlag, router, stopScheduler)
+		return
+	}
 	for {
-		fmt.Println("\n===== Sistem Manajemen Pesanan Restoran =====")
-		fmt.Println("1. Tampilkan Menu")
-		fmt.Println("2. Buat Pesanan")
-		fmt.Println("3. Tampilkan Total Semua Pesanan")
-		fmt.Println("4. Keluar")
-		fmt.Print("Pilih opsi: ")
-
-		input, _ := reader.ReadString('\n')
-		option := strings.TrimSpace(input)
-
-		switch option {
-		case "1":
-			displayMenu()
-		case "2":
-			order := createOrder(reader, orderID)
-			if order != nil {
-				wg.Add(1)
-				orderChan <- *order
-				orderID++
+		refreshSharedStock()
+
+		printMainMenu(os.Stdout)
+
+		// Input habis (pipe atau skrip selesai) atau gagal dibaca: simpan data lalu keluar
+		input, err := reader.ReadString('\n')
+		if err != nil && strings.TrimSpace(input) == "" {
+			switch {
+			case err != io.EOF:
+				fmt.Println("\nGagal membaca input:", err)
+			case *scriptFlag != "":
+				fmt.Println("\nSkrip selesai.")
+			default:
+				fmt.Println("\nInput selesai.")
+			}
+			shutdown(stopScheduler)
+			return
+		}
+		option, args := resolveCommand(input)
+		args, dry := cutDryRunFlag(args)
+		if dry && !dryRunOptions[option] {
+			fmt.Println("--dry-run hanya tersedia untuk pesanan, restock, purchase order dan perubahan menu.")
+			continue
+		}
+		if dry && *serveFlag != "" {
+			fmt.Println("--dry-run tidak tersedia selama server API berjalan.")
+			continue
+		}
+		// File yang diedit selama menunggu input diterapkan sebelum perintah dijalankan
+		reloadChangedFiles()
+		logCommand(option)
+
+		var snapshot dryRunSnapshot
+		if dry {
+			snapshot = beginDryRun()
+		}
+
+		if option == "4" {
+			if confirmExit(reader) {
+				shutdown(stopScheduler)
+				return
 			}
-		case "3":
-			displayTotalAllOrders()
-		case "4":
-			close(orderChan)
+		} else {
+			runMenuOption(reader, option, args)
+		}
+
+		// Pada skrip, pesanan ditunggu selesai diproses sebelum perintah berikutnya
+		if *scriptFlag != "" {
 			wg.Wait()
+		}
+		if dry {
+			endDryRun(snapshot)
+			continue
+		}
+		saveState()
+	}
+}
+
+// Fungsi untuk menjalankan satu opsi menu utama selain keluar (opsi 4), dipakai
+// terminal lokal dan terminal yang terhubung ke daemon
+func runMenuOption(reader *bufio.Reader, option, args string) {
+	switch option {
+	case "1":
+		displayMenu()
+	case "2":
+		if err := checkKitchenCapacity(); err != nil {
+			fmt.Printf("Pesanan tidak bisa dibuat: %v.\n", err)
 			return
-		default:
-			fmt.Println("Opsi tidak valid. Silakan coba lagi.")
 		}
+		if orderID, ok := newOrderID(); ok {
+			var order *Order
+			if args != "" {
+				order = createQuickOrder(orderID, args)
+			} else {
+				order = createOrder(reader, orderID)
+			}
+			if order != nil {
+				submitOrder(order)
+			}
+		}
+	case "3":
+		displayTotalAllOrders()
+	case "5":
+		displayKitchenQueue()
+	case "6":
+		fireHeldLines(reader)
+	case "7":
+		if err := checkKitchenCapacity(); err != nil {
+			fmt.Printf("Pesanan tidak bisa dibuat: %v.\n", err)
+			return
+		}
+		if orderID, ok := newOrderID(); ok {
+			order := duplicateOrder(reader, orderID)
+			if order != nil {
+				submitOrder(order)
+			}
+		}
+	case "8":
+		payOrder(reader)
+	case "9":
+		returnOrderLine(reader)
+	case "10":
+		if orderID, ok := newOrderID(); ok {
+			order := createScheduledOrder(reader, orderID)
+			if order != nil {
+				recordOrder(order)
+			}
+		}
+	case "11":
+		stationView(reader)
+	case "12":
+		adjustStock(reader)
+	case "13":
+		exportMovements(reader)
+	case "14":
+		purchasingMenu(reader)
+	case "15":
+		displayExpiringBatches()
+	case "16":
+		logWaste(reader)
+	case "17":
+		displayWasteReport(reader)
+	case "18":
+		editMenuItem(reader)
+	case "19":
+		voidOrder(reader)
+	case "20":
+		deleteMenuItem(reader)
+	case "21":
+		discountOrder(reader)
+	case "22":
+		closeDay(reader)
+	case "23":
+		switchCashier(reader)
+	case "24":
+		exportActivityLog(reader)
+	case "25":
+		resendReceipt(reader)
+	case "26":
+		displayKitchenPerformance()
+	case "27":
+		displayPeakHours(reader)
+	case "28":
+		manageVariants(reader)
+	case "29":
+		displayReorderSuggestions()
+	case "30":
+		cashMenu(reader)
+	case "31":
+		expensesMenu(reader)
+	case "32":
+		displayCoverReport(reader)
+	case "33":
+		displayMenuEngineering(reader)
+	case "34":
+		customReport(reader, args)
+	case "35":
+		displayCashierReport(reader)
+	case "36":
+		mergeOrders(reader)
+	case "37":
+		displayOccupiedTables()
+		transferTable(reader)
+	case "38":
+		tabMenu(reader)
+	case "39":
+		repriceMenu(reader, args)
+	case "40":
+		transferMenu(reader)
+	case "41":
+		reprintReceipt(reader, args)
+	case "42":
+		syncStatus(reader)
+	case "43":
+		integrityCheckMenu(reader, args)
+	case "44":
+		discountApprovalMenu(reader)
+	case "45":
+		toggleUnavailable(reader, args)
+	case "46":
+		cancelCourseFire(reader, args)
+	case "47":
+		specialsMenu(reader)
+	case "48":
+		exportAccounting(reader)
+	case "49":
+		if order := recallParkedOrder(reader, args); order != nil {
+			submitOrder(order)
+		}
+	case "50":
+		displayStockSnapshots(reader)
+	case "51":
+		exportAnonymized(reader)
+	case "52":
+		printBill(reader, args)
+	default:
+		fmt.Println("Opsi tidak valid. Silakan coba lagi.")
+	}
+}
+
+// Fungsi untuk menampilkan menu utama beserta prompt pilihan opsi
+func printMainMenu(w io.Writer) {
+	fmt.Fprintf(w, "\n===== %s =====\n", currentConfig().RestaurantName)
+	for i, label := range mainMenuOptions {
+		fmt.Fprintf(w, "%d. %s\n", i+1, label)
 	}
+	fmt.Fprint(w, "Pilih opsi (atau alias, misal m / o 2x mie ayam): ")
 }
 
 // Fungsi untuk menampilkan menu
 func displayMenu() {
+	list := currentSpecials()
 	menuMutex.Lock()
 	defer menuMutex.Unlock()
 
@@ -118,12 +588,80 @@ func displayMenu() {
 		return
 	}
 
+	now := time.Now()
+	displaySpecials(list)
 	fmt.Println("\n===== Menu =====")
 	for _, item := range menu {
-		fmt.Printf("Nama: %s | Harga: %.2f | Stok: %d\n", item.Name, item.Price, item.Quantity)
+		if len(item.Variants) > 0 {
+			fmt.Printf("Nama: %s | Stasiun: %s%s%s%s%s | Varian:\n", item.Name, item.Station, describeRestriction(item.Restricted), describeCourse(item.Course), describeSeason(&item, now), describeUnavailable(&item))
+			for _, variant := range item.Variants {
+				fmt.Printf("  - %s | Harga: %s | Stok: %s%s%s\n", variant.Name, formatMoney(variant.Price), formatQuantity(variant.Quantity), describeSeason(&variant, now), describeUnavailable(&variant))
+			}
+			continue
+		}
+		fmt.Printf("Nama: %s | Harga: %s | Stok: %s | Stasiun: %s%s%s%s%s\n", item.Name, formatMoney(item.Price), describeStockQuantity(item.Name, item.Quantity), item.Station, describeCourse(item.Course), describeRestriction(item.Restricted), describeSeason(&item, now), describeUnavailable(&item))
 	}
 }
 
+// Fungsi untuk menampilkan item yang bisa dipesan dengan nomor urut, varian ditampilkan
+// sebagai item sendiri agar kasir cukup mengetik nomornya. Item musiman di luar
+// musimnya dan item yang di-86 tidak ditampilkan.
+func displayNumberedMenu() {
+	list := currentSpecials()
+	menuMutex.Lock()
+	defer menuMutex.Unlock()
+
+	fmt.Println("\n===== Pilih Item =====")
+	for i, item := range orderableItems(time.Now()) {
+		fmt.Printf("%2d. %s | %s | Stok: %d%s\n", i+1, item.Name, formatMoney(item.Price), item.Quantity, describeSpecial(list, item.Name))
+	}
+}
+
+// Fungsi untuk membaca pilihan berupa nomor item, boleh diikuti jumlah seperti "3x2".
+// Jumlah 0 berarti belum ditulis.
+func parseMenuSelection(input string) (number, quantity int, ok bool) {
+	numberText, quantityText, hasQuantity := strings.Cut(strings.ToLower(input), "x")
+	number, err := strconv.Atoi(numberText)
+	if err != nil {
+		return 0, 0, false
+	}
+	if !hasQuantity {
+		return number, 0, true
+	}
+	quantity, err = strconv.Atoi(quantityText)
+	if err != nil || quantity <= 0 {
+		return 0, 0, false
+	}
+	return number, quantity, true
+}
+
+// Fungsi untuk menghentikan penjadwal, menunggu dapur selesai lalu menyimpan data
+func shutdown(stopScheduler func()) {
+	stopScheduler()
+	stopCourseTimers()
+	orderQueue.close()
+	wg.Wait()
+	hookWG.Wait()
+	saveState()
+	flushAsyncOutput()
+}
+
+// Fungsi untuk mencatat pesanan baru dan mengirimnya ke dapur. Saat dry-run pesanan
+// hanya ditampilkan.
+func submitOrder(order *Order) {
+	if dryRunActive() {
+		describeDryRunOrder(order)
+		return
+	}
+	recordOrder(order)
+	dispatchOrder(order, false)
+}
+
+// Fungsi untuk mencari item menu atau varian berdasarkan nama, pemanggil harus memegang menuMutex
+func findMenuItem(name string) *MenuItem {
+	return findMenuItemIn(menu, name)
+}
+
 // Fungsi untuk membuat pesanan
 func createOrder(reader *bufio.Reader, orderID int) *Order {
 	defer func() {
@@ -132,67 +670,222 @@ func createOrder(reader *bufio.Reader, orderID int) *Order {
 		}
 	}()
 
-	fmt.Print("Masukkan nama item yang dipesan: ")
-	name, _ := reader.ReadString('\n')
-	name = strings.TrimSpace(name)
+	table, delivery, ok := readTableNumber(reader)
+	if !ok {
+		return nil
+	}
 
-	menuMutex.Lock()
-	defer menuMutex.Unlock()
+	guests, ok := readGuestCount(reader, table)
+	if !ok {
+		return nil
+	}
 
-	var selectedItem *MenuItem
-	for i, item := range menu {
-		if strings.EqualFold(item.Name, name) {
-			selectedItem = &menu[i]
+	order := &Order{ID: orderID, Table: table, Guests: guests, Delivery: delivery}
+	if order.Fields, ok = readOrderFields(reader, order.Type()); !ok {
+		return nil
+	}
+	if order.PriceList, ok = readPriceList(reader, order.Type()); !ok {
+		return nil
+	}
+	readOrderLines(reader, order, true)
+
+	if len(order.Lines) == 0 || !confirmOrder(reader, order, true) {
+		return nil
+	}
+
+	return order
+}
+
+// Fungsi untuk membaca baris-baris item sampai kasir selesai menambah item.
+// Stok belum dikurangi sampai pesanan dikonfirmasi.
+func readOrderLines(reader *bufio.Reader, order *Order, reserve bool) {
+	displayNumberedMenu()
+	for {
+		line := createOrderLine(reader, order.Type(), order.PriceList, reserve)
+		if line != nil {
+			line.No = len(order.Lines) + 1
+			order.Lines = append(order.Lines, *line)
+			order.TotalPrice += line.TotalPrice
+		}
+
+		fmt.Print("Tambah item lain? (y/n): ")
+		more, _ := reader.ReadString('\n')
+		if !strings.EqualFold(strings.TrimSpace(more), "y") {
 			break
 		}
 	}
+}
 
-	if selectedItem == nil {
-		fmt.Println("Item tidak ditemukan.")
+// Fungsi untuk membaca satu baris item pesanan. Jika reserve bernilai true stok
+// dicek dan item bisa ditahan, pengurangan stok dilakukan saat konfirmasi.
+// Item yang tidak tersedia untuk jenis pesanan ini ditolak. Harga diambil dari daftar
+// harga pesanan.
+func createOrderLine(reader *bufio.Reader, orderType OrderType, priceList string, reserve bool) (line *OrderLine) {
+	// Panic di sini hanya membatalkan baris ini, bukan baris yang sudah mengurangi stok
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Println("Terjadi kesalahan:", r)
+			line = nil
+		}
+	}()
+
+	fmt.Print("Masukkan nomor atau nama item (misal 3x2 untuk item 3 sebanyak 2): ")
+	name, _ := reader.ReadString('\n')
+	name = strings.TrimSpace(name)
+
+	// menuMutex hanya dipegang sebentar di antara pertanyaan ke kasir, jadi item dicari
+	// ulang menurut nama karena menu bisa berubah selama kasir mengetik
+	quantityInput := ""
+	if number, quantity, ok := parseMenuSelection(name); ok {
+		var count int
+		if name, count = orderableItemName(number); name == "" {
+			fmt.Printf("Nomor item harus antara 1 dan %d.\n", count)
+			return nil
+		}
+		fmt.Printf("Item: %s\n", name)
+		if quantity > 0 {
+			quantityInput = strconv.Itoa(quantity)
+		}
+	} else {
+		if name = findMenuItemOrSuggest(reader, name); name == "" {
+			return nil
+		}
+		if name = selectVariant(reader, name); name == "" {
+			return nil
+		}
+	}
+
+	selectedItem, basePrice, err := orderableItem(name, orderType, priceList)
+	if err != nil {
+		fmt.Println(err)
 		return nil
 	}
 
-	fmt.Print("Masukkan jumlah: ")
-	quantityInput, _ := reader.ReadString('\n')
-	quantityInput = strings.TrimSpace(quantityInput)
+	if quantityInput == "" {
+		fmt.Print("Masukkan jumlah: ")
+		quantityInput, _ = reader.ReadString('\n')
+		quantityInput = strings.TrimSpace(quantityInput)
+	}
 
-	// Validasi jumlah menggunakan regexp
-	matched, err := regexp.MatchString(`^\d+$`, quantityInput)
+	// Jumlah boleh ditulis dengan pemisah ribuan, misalnya 1.000
+	quantity, err := parseQuantityInput(quantityInput)
 	if err != nil {
-		panic(err)
+		panic("Jumlah harus berupa angka bulat")
 	}
-	if !matched {
-		panic("Jumlah harus berupa angka")
+	if quantity <= 0 {
+		panic("Jumlah harus berupa angka positif")
+	}
+	if !approveLineQuantity(reader, selectedItem.Name, quantity) {
+		return nil
+	}
+	price, reason, ok := readPriceOverride(reader, selectedItem.Name, basePrice, quantity)
+	if !ok {
+		return nil
+	}
+	var listPrice Money
+	if reason != "" {
+		listPrice = basePrice
+	}
+	modifiers, ok := readModifiers(reader, quantity, reserve)
+	if !ok {
+		return nil
+	}
+	price += modifiersPrice(modifiers)
+	if reason != "" {
+		listPrice += modifiersPrice(modifiers)
 	}
 
-	quantity, err := strconv.Atoi(quantityInput)
-	if err != nil || quantity <= 0 {
-		panic("Jumlah harus berupa angka positif")
+	// Pesanan terjadwal baru memesan stok menjelang waktu ambil
+	if !reserve {
+		return &OrderLine{
+			ItemName:    selectedItem.Name,
+			Quantity:    quantity,
+			Price:       price,
+			TotalPrice:  price.Times(quantity),
+			Status:      LineScheduled,
+			Station:     selectedItem.Station,
+			ListPrice:   listPrice,
+			PriceReason: reason,
+			Modifiers:   modifiers,
+		}
 	}
 
-	if quantity > selectedItem.Quantity {
+	if quantity > menuItemQuantity(selectedItem.Name) {
 		fmt.Println("Jumlah melebihi stok yang tersedia.")
 		return nil
 	}
 
-	selectedItem.Quantity -= quantity
+	// Item yang ditahan (misalnya hidangan utama) baru dikirim ke dapur saat di-fire
+	fmt.Print("Tahan item ini untuk dikirim nanti? (y/n): ")
+	holdInput, _ := reader.ReadString('\n')
+	status := LineQueued
+	if strings.EqualFold(strings.TrimSpace(holdInput), "y") {
+		status = LineHeld
+	}
+
+	return &OrderLine{
+		ItemName:    selectedItem.Name,
+		Quantity:    quantity,
+		Price:       price,
+		TotalPrice:  price.Times(quantity),
+		Status:      status,
+		Station:     selectedItem.Station,
+		ListPrice:   listPrice,
+		PriceReason: reason,
+		Modifiers:   modifiers,
+	}
+}
 
-	order := &Order{
-		ID:         orderID,
-		ItemName:   selectedItem.Name,
-		Quantity:   quantity,
-		Price:      selectedItem.Price,
-		TotalPrice: float64(quantity) * selectedItem.Price,
+// Fungsi untuk mengambil nama item bernomor dari daftar item yang bisa dipesan saat ini.
+// Mengembalikan nama kosong dan jumlah item jika nomor di luar daftar.
+func orderableItemName(number int) (string, int) {
+	menuMutex.Lock()
+	defer menuMutex.Unlock()
+	items := orderableItems(time.Now())
+	if number < 1 || number > len(items) {
+		return "", len(items)
 	}
+	return items[number-1].Name, len(items)
+}
 
-	return order
+// Fungsi untuk memeriksa bahwa item masih ada dan bisa dipesan untuk jenis pesanan ini,
+// lalu mengembalikan salinan item beserta harganya menurut daftar harga pesanan
+func orderableItem(name string, orderType OrderType, priceList string) (MenuItem, Money, error) {
+	menuMutex.Lock()
+	defer menuMutex.Unlock()
+	item := findMenuItem(name)
+	if item == nil {
+		return MenuItem{}, 0, fmt.Errorf("Item %s tidak ditemukan.", name)
+	}
+	if err := checkOrderType(item, orderType); err != nil {
+		return MenuItem{}, 0, err
+	}
+	if err := checkAvailable(item, time.Now()); err != nil {
+		return MenuItem{}, 0, err
+	}
+	return *item, channelPrice(item, priceList), nil
+}
+
+// Fungsi untuk membaca stok item saat ini, 0 jika item sudah dihapus
+func menuItemQuantity(name string) int {
+	menuMutex.Lock()
+	defer menuMutex.Unlock()
+	if item := findMenuItem(name); item != nil {
+		return item.Quantity
+	}
+	return 0
 }
 
 // Fungsi untuk memproses pesanan menggunakan goroutine dan channel
 func processOrders() {
-	for order := range orderChan {
+	for {
+		order, ok := orderQueue.pop()
+		if !ok {
+			return
+		}
 		go func(ord Order) {
 			defer wg.Done()
+			defer orderQueue.done()
 			processOrder(ord)
 		}(order)
 	}
@@ -201,11 +894,14 @@ func processOrders() {
 // Implementasi dari interface OrderProcessor
 func (op *OrderProcessorImpl) ProcessOrder(order Order) error {
 	// Simulasi pemrosesan pesanan
-	time.Sleep(2 * time.Second)
-	fmt.Printf("Pesanan ID %d: %s x%d telah diproses.\n", order.ID, order.ItemName, order.Quantity)
+	time.Sleep(kitchenDelay)
+	processingPrintf("Pesanan ID %d: %s telah diproses.\n", order.ID, describeLines(order.Lines))
 	return nil
 }
 
+// Lama simulasi pemrosesan satu pesanan di dapur
+var kitchenDelay = 2 * time.Second
+
 // Struct implementasi OrderProcessor
 type OrderProcessorImpl struct{}
 
@@ -213,24 +909,28 @@ type OrderProcessorImpl struct{}
 func processOrder(order Order) {
 	defer func() {
 		if r := recover(); r != nil {
-			fmt.Println("Recovered in goroutine:", r)
-		} 
+			asyncPrintf("Recovered in goroutine: %v\n", r)
+			notify("Pesanan gagal diproses", fmt.Sprintf("pesanan ID %d: %v", order.ID, r))
+		}
 	}()
 
+	revenue := setLineStatus(order, LinePreparing)
+
 	op := &OrderProcessorImpl{}
 	err := op.ProcessOrder(order)
 	if err != nil {
 		panic(err)
 	}
 
+	// Baris tetap berstatus diproses sampai di-bump dari tampilan stasiun
 	// Encode detail pesanan menggunakan base64
-	orderDetails := fmt.Sprintf("ID:%d,Item:%s,Quantity:%d,TotalPrice:%.2f", order.ID, order.ItemName, order.Quantity, order.TotalPrice)
+	orderDetails := fmt.Sprintf("ID:%d,Items:%s,TotalPrice:%s", order.ID, describeLines(order.Lines), formatMoneyPlain(order.TotalPrice))
 	encoded := base64.StdEncoding.EncodeToString([]byte(orderDetails))
-	fmt.Printf("Detail Pesanan Terencode: %s\n", encoded)
+	processingPrintf("Detail Pesanan Terencode: %s\n", encoded)
 
-	// Update total semua pesanan
+	// Update total semua pesanan, baris yang dibatalkan sebelum diproses tidak dihitung
 	totalMutex.Lock()
-	totalAllOrders += order.TotalPrice
+	totalAllOrders += revenue
 	totalMutex.Unlock()
 }
 
@@ -239,5 +939,6 @@ func displayTotalAllOrders() {
 	totalMutex.Lock()
 	defer totalMutex.Unlock()
 
-	fmt.Printf("Total Semua Pesanan: %.2f\n", totalAllOrders)
+	fmt.Printf("Total Semua Pesanan: %s\n", formatMoney(totalAllOrders))
+	fmt.Printf("Total Refund: %s\n", formatMoney(totalRefunds()))
 }
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Fungsi untuk mengubah harga dan stasiun item menu dengan pemeriksaan versi,
+// supaya perubahan dari proses lain tidak hilang tanpa disadari
+func editMenuItem(reader *bufio.Reader) {
+	fmt.Print("Masukkan nama item: ")
+	name, _ := reader.ReadString('\n')
+	name = strings.TrimSpace(name)
+
+	menuMutex.Lock()
+	item := findMenuItem(name)
+	var edited MenuItem
+	if item != nil {
+		edited = copyMenuItem(*item)
+	}
+	menuMutex.Unlock()
+	if item == nil {
+		fmt.Println("Item tidak ditemukan.")
+		return
+	}
+
+	fmt.Printf("Harga baru (kosongkan untuk tetap %s): ", formatMoney(edited.Price))
+	priceInput, _ := reader.ReadString('\n')
+	if priceInput = strings.TrimSpace(priceInput); priceInput != "" {
+		price, err := parseMoneyInput(priceInput)
+		if err != nil || price <= 0 {
+			fmt.Println("Harga harus berupa angka positif.")
+			return
+		}
+		edited.Price = price
+	}
+
+	fmt.Printf("Stasiun baru (grill/wok/bar, kosongkan untuk tetap %s): ", edited.Station)
+	stationInput, _ := reader.ReadString('\n')
+	if strings.TrimSpace(stationInput) != "" {
+		station, ok := parseStation(stationInput)
+		if !ok {
+			fmt.Println("Stasiun tidak dikenal.")
+			return
+		}
+		edited.Station = station
+	}
+
+	restricted, ok := readRestrictedOrderTypes(reader, edited.Restricted)
+	if !ok {
+		return
+	}
+	edited.Restricted = restricted
+
+	if !readSeason(reader, &edited) {
+		return
+	}
+
+	currentCourse := edited.Course
+	if currentCourse == "" {
+		currentCourse = "tanpa course"
+	}
+	fmt.Printf("Course (misal minuman/pembuka/utama, - untuk hapus, kosongkan untuk tetap %s): ", currentCourse)
+	courseInput, _ := reader.ReadString('\n')
+	switch courseInput = strings.ToLower(strings.TrimSpace(courseInput)); courseInput {
+	case "":
+	case "-":
+		edited.Course = ""
+	default:
+		edited.Course = courseInput
+	}
+
+	fmt.Printf("Batas jumlah per baris (0 = pakai batas global, kosongkan untuk tetap %d): ", edited.MaxQuantity)
+	limitInput, _ := reader.ReadString('\n')
+	if limitInput = strings.TrimSpace(limitInput); limitInput != "" {
+		limit, err := strconv.Atoi(limitInput)
+		if err != nil || limit < 0 {
+			fmt.Println("Batas jumlah harus berupa angka 0 atau lebih.")
+			return
+		}
+		edited.MaxQuantity = limit
+	}
+
+	for {
+		updated, err := activeMenuRepo().UpdateMenuItem(edited)
+		var conflict *VersionConflictError
+		if errors.As(err, &conflict) {
+			current := conflict.Current
+			fmt.Printf("Item %s sudah diubah pihak lain: Harga %s | Stasiun: %s (versi %d).\n", current.Name, formatMoney(current.Price), current.Station, current.Version)
+			fmt.Printf("Perubahan Anda: Harga %s | Stasiun: %s.\n", formatMoney(edited.Price), edited.Station)
+			fmt.Print("1 = pakai data terbaru, 2 = timpa dengan perubahan saya, lainnya = batal: ")
+			choice, _ := reader.ReadString('\n')
+			switch strings.TrimSpace(choice) {
+			case "1":
+				applyMenuItemEdit(current)
+				fmt.Println("Data terbaru dipakai.")
+				return
+			case "2":
+				edited.Version = current.Version
+				continue
+			default:
+				fmt.Println("Perubahan dibatalkan.")
+				return
+			}
+		}
+		if err != nil {
+			fmt.Println("Gagal menyimpan item:", err)
+			return
+		}
+
+		applyMenuItemEdit(updated)
+		fmt.Printf("Item %s diperbarui: Harga %s | Stasiun: %s | Tidak untuk: %s.\n", updated.Name, formatMoney(updated.Price), updated.Station, describeOrderTypes(updated.Restricted))
+		return
+	}
+}
+
+// Fungsi untuk menyalin harga, stasiun, pembatasan, batas jumlah, musim dan versi hasil penyimpanan ke menu lokal
+func applyMenuItemEdit(updated MenuItem) {
+	menuMutex.Lock()
+	defer menuMutex.Unlock()
+
+	if item := findMenuItem(updated.Name); item != nil {
+		item.Price = updated.Price
+		item.Station = updated.Station
+		item.Restricted = updated.Restricted
+		item.MaxQuantity = updated.MaxQuantity
+		item.SeasonStart = updated.SeasonStart
+		item.SeasonEnd = updated.SeasonEnd
+		item.ForceShow = updated.ForceShow
+		item.Unavailable = updated.Unavailable
+		item.Course = updated.Course
+		item.UnavailableReason = updated.UnavailableReason
+		item.Version = updated.Version
+		invalidateMenuCache()
+	}
+}
+
+// Fungsi untuk menghapus item dari menu, perlu PIN admin
+func deleteMenuItem(reader *bufio.Reader) {
+	fmt.Print("Masukkan nama item yang dihapus: ")
+	name, _ := reader.ReadString('\n')
+	name = strings.TrimSpace(name)
+
+	menuMutex.Lock()
+	item := findMenuItem(name)
+	if item != nil {
+		name = item.Name
+	}
+	menuMutex.Unlock()
+	if item == nil {
+		fmt.Println("Item tidak ditemukan.")
+		return
+	}
+
+	if !requireAdminPIN(reader, "hapus item "+name) {
+		return
+	}
+
+	if err := activeMenuRepo().DeleteMenuItem(name); err != nil {
+		fmt.Println("Gagal menghapus item:", err)
+		return
+	}
+
+	menuMutex.Lock()
+	menu = removeMenuItem(menu, name)
+	invalidateMenuCache()
+	menuMutex.Unlock()
+
+	fmt.Printf("Item %s dihapus dari menu.\n", name)
+}
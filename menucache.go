@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Event yang dikirim ke pelanggan /events saat menu berubah setelah terakhir dibaca,
+// kiosk bisa memuat ulang menu tanpa harus bertanya terus-menerus
+const EventMenuChanged = "menu_changed"
+
+// Penanda proses untuk ETag menu, generasi dimulai dari nol lagi setiap program dimulai
+var menuCacheEpoch = strconv.FormatInt(time.Now().UnixNano(), 36)
+
+// Cache jawaban GET /menu yang sudah di-encode. Setiap perubahan menu menaikkan
+// generasi sehingga jawaban yang disusun dari menu lama tidak pernah disimpan.
+var menuCache struct {
+	mu         sync.Mutex
+	generation int
+	body       []byte
+	builtAt    time.Time
+}
+
+// Fungsi untuk membuang cache menu setelah menu, stok atau spesial berubah. Event
+// menu_changed hanya dikirim sekali sampai menu dibaca lagi agar penjualan beruntun
+// tidak membanjiri pelanggan event. Boleh dipanggil sambil memegang menuMutex.
+func invalidateMenuCache() {
+	menuCache.mu.Lock()
+	cached := menuCache.body != nil
+	menuCache.generation++
+	menuCache.body = nil
+	menuCache.mu.Unlock()
+
+	if cached {
+		publishOrderEvent(OrderEvent{Type: EventMenuChanged})
+	}
+}
+
+// Fungsi untuk mengambil jawaban GET /menu dari cache, atau menyusunnya dari menu jika
+// cache kosong atau sudah lebih lama dari api_menu_cache_seconds. Mengembalikan isi
+// jawaban dan generasinya untuk ETag.
+func cachedMenuResponse() ([]byte, int, error) {
+	ttl := time.Duration(currentConfig().APIMenuCacheSeconds) * time.Second
+	menuCache.mu.Lock()
+	if menuCache.body != nil && time.Since(menuCache.builtAt) < ttl {
+		body, generation := menuCache.body, menuCache.generation
+		menuCache.mu.Unlock()
+		return body, generation, nil
+	}
+	menuCache.mu.Unlock()
+
+	// Generasi dibaca bersama salinan menu di dalam menuMutex, perubahan setelahnya
+	// menaikkan generasi sehingga hasil ini tidak disimpan
+	list := currentSpecials()
+	menuMutex.Lock()
+	menuCache.mu.Lock()
+	generation := menuCache.generation
+	menuCache.mu.Unlock()
+	items := specialsFirst(list)
+	menuMutex.Unlock()
+
+	var b bytes.Buffer
+	if err := json.NewEncoder(&b).Encode(items); err != nil {
+		return nil, 0, err
+	}
+	body := b.Bytes()
+
+	menuCache.mu.Lock()
+	if ttl > 0 && menuCache.generation == generation {
+		menuCache.body = body
+		menuCache.builtAt = time.Now()
+	}
+	menuCache.mu.Unlock()
+	return body, generation, nil
+}
+
+// Fungsi untuk mengurutkan menu dengan item spesial hari ini di depan. Pemanggil harus
+// memegang menuMutex.
+func specialsFirst(list []DailySpecial) []MenuItem {
+	items := []MenuItem{}
+	var rest []MenuItem
+	for _, item := range menu {
+		special := findSpecial(list, item.Name) != nil
+		for _, variant := range item.Variants {
+			special = special || findSpecial(list, variant.Name) != nil
+		}
+		if special {
+			items = append(items, copyMenuItem(item))
+		} else {
+			rest = append(rest, copyMenuItem(item))
+		}
+	}
+	return append(items, rest...)
+}
+
+// GET /menu mengembalikan seluruh item menu beserta varian dan stoknya, spesial hari
+// ini di urutan teratas. Jawaban diambil dari cache, dan dijawab 304 jika ETag klien
+// masih sama.
+func handleGetMenu(w http.ResponseWriter, r *http.Request) {
+	body, generation, err := cachedMenuResponse()
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	etag := `"menu-` + menuCacheEpoch + "-" + strconv.Itoa(generation) + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Struct untuk perubahan satu item menu di antara dua snapshot
+type menuChange struct {
+	Name    string
+	Details []string
+}
+
+// Struct untuk hasil perbandingan dua snapshot menu
+type menuDiff struct {
+	Added   []MenuItem
+	Removed []MenuItem
+	Changed []menuChange
+}
+
+// Fungsi untuk meratakan menu beserta variannya menjadi peta nama (huruf kecil) ke item
+func flattenMenu(items []MenuItem) map[string]MenuItem {
+	result := map[string]MenuItem{}
+	for _, item := range items {
+		result[strings.ToLower(item.Name)] = item
+		for name, variant := range flattenMenu(item.Variants) {
+			result[name] = variant
+		}
+	}
+	return result
+}
+
+// Fungsi untuk membandingkan dua snapshot menu. Item dicocokkan berdasarkan nama
+// tanpa membedakan huruf besar kecil, termasuk varian.
+func diffMenus(before, after []MenuItem) menuDiff {
+	var diff menuDiff
+	oldItems := flattenMenu(before)
+	newItems := flattenMenu(after)
+
+	for key, item := range newItems {
+		old, ok := oldItems[key]
+		if !ok {
+			diff.Added = append(diff.Added, item)
+			continue
+		}
+
+		var details []string
+		if old.Price != item.Price {
+			details = append(details, fmt.Sprintf("harga %s -> %s", formatMoney(old.Price), formatMoney(item.Price)))
+		}
+		if old.Quantity != item.Quantity {
+			details = append(details, fmt.Sprintf("stok %d -> %d (%+d)", old.Quantity, item.Quantity, item.Quantity-old.Quantity))
+		}
+		if old.Cost != item.Cost {
+			details = append(details, fmt.Sprintf("biaya %s -> %s", formatMoney(old.Cost), formatMoney(item.Cost)))
+		}
+		if old.Station != item.Station {
+			details = append(details, fmt.Sprintf("stasiun %s -> %s", old.Station, item.Station))
+		}
+		if len(details) > 0 {
+			diff.Changed = append(diff.Changed, menuChange{Name: item.Name, Details: details})
+		}
+	}
+	for key, item := range oldItems {
+		if _, ok := newItems[key]; !ok {
+			diff.Removed = append(diff.Removed, item)
+		}
+	}
+
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].Name < diff.Added[j].Name })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].Name < diff.Removed[j].Name })
+	sort.Slice(diff.Changed, func(i, j int) bool { return diff.Changed[i].Name < diff.Changed[j].Name })
+	return diff
+}
+
+// Fungsi untuk menjalankan perintah `menu diff fileA fileB`. File boleh berupa menu.json
+// dari folder data atau CSV menu awal.
+func runMenuDiff(args []string) {
+	if len(args) != 2 {
+		fmt.Println("Pemakaian: menu diff fileA fileB")
+		return
+	}
+
+	before, err := loadDefaultMenu(args[0])
+	if err != nil {
+		fmt.Println("Gagal membaca menu:", err)
+		return
+	}
+	after, err := loadDefaultMenu(args[1])
+	if err != nil {
+		fmt.Println("Gagal membaca menu:", err)
+		return
+	}
+
+	diff := diffMenus(before, after)
+	if len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Changed) == 0 {
+		fmt.Printf("Tidak ada perbedaan antara %s dan %s.\n", args[0], args[1])
+		return
+	}
+
+	fmt.Printf("--- %s\n+++ %s\n", args[0], args[1])
+	printMenuDiff(diff)
+}
+
+// Fungsi untuk menampilkan hasil perbandingan menu
+func printMenuDiff(diff menuDiff) {
+	for _, item := range diff.Added {
+		fmt.Printf("+ %s: %s, stok %d\n", item.Name, formatMoney(item.Price), item.Quantity)
+	}
+	for _, item := range diff.Removed {
+		fmt.Printf("- %s: %s, stok %d\n", item.Name, formatMoney(item.Price), item.Quantity)
+	}
+	for _, change := range diff.Changed {
+		fmt.Printf("~ %s: %s\n", change.Name, strings.Join(change.Details, ", "))
+	}
+	fmt.Printf("%d ditambah, %d dihapus, %d berubah.\n", len(diff.Added), len(diff.Removed), len(diff.Changed))
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fungsi untuk mencari pesanan dari input berupa ID atau "meja <nomor>" (pesanan
+// terakhir meja tersebut). Isi QR struk hasil pindai juga diterima, misalnya
+// "PESANAN 12" atau alamat ".../orders/12". Pemanggil harus memegang ordersMutex.
+func findOrderRef(input string) (*Order, error) {
+	input = strings.TrimSpace(input)
+	if rest, ok := strings.CutPrefix(strings.ToLower(input), "pesanan"); ok {
+		input = strings.TrimSpace(rest)
+	} else if i := strings.LastIndex(input, "/orders/"); i >= 0 {
+		input = input[i+len("/orders/"):]
+	}
+	if rest, ok := strings.CutPrefix(strings.ToLower(input), "meja"); ok {
+		table, valid := parseTableNumber(rest)
+		if !valid || table == 0 {
+			return nil, errors.New("Nomor meja harus berupa angka positif.")
+		}
+		if order := findLastOrderForTable(table); order != nil {
+			return order, nil
+		}
+		return nil, errors.New("Pesanan tidak ditemukan.")
+	}
+
+	id, err := strconv.Atoi(input)
+	if err != nil {
+		return nil, errors.New("ID pesanan harus berupa angka.")
+	}
+	if order := findOrder(id); order != nil {
+		return order, nil
+	}
+	return nil, errors.New("Pesanan tidak ditemukan.")
+}
+
+// Fungsi untuk mengikuti pesanan yang sudah digabung ke pesanan lain, sehingga ID
+// lama tetap bisa dipakai untuk bayar, void, diskon atau retur.
+// Pemanggil harus memegang ordersMutex.
+func followMergedOrder(order *Order) *Order {
+	for order != nil && order.MergedInto != 0 {
+		fmt.Printf("Pesanan ID %d sudah digabung ke pesanan ID %d, memakai pesanan ID %d.\n", order.ID, order.MergedInto, order.MergedInto)
+		order = findOrder(order.MergedInto)
+	}
+	return order
+}
+
+// Fungsi untuk memeriksa apakah pesanan masih bisa digabung
+func checkMergeable(order *Order) error {
+	switch {
+	case order.Voided:
+		return fmt.Errorf("pesanan ID %d sudah dibatalkan", order.ID)
+	case order.Paid:
+		return fmt.Errorf("pesanan ID %d sudah dibayar", order.ID)
+	case order.MergedInto != 0:
+		return fmt.Errorf("pesanan ID %d sudah digabung ke pesanan ID %d", order.ID, order.MergedInto)
+	}
+	// Tiket yang sedang dikirim atau menunggu jadwal masih memakai ID pesanan lama
+	for _, line := range order.Lines {
+		if line.Status == LineQueued || line.Status == LineScheduled {
+			return fmt.Errorf("pesanan ID %d masih punya item %s yang sedang dikirim ke dapur atau terjadwal, coba lagi nanti", order.ID, line.ItemName)
+		}
+	}
+	return nil
+}
+
+// Fungsi untuk menggabungkan dua pesanan yang belum dibayar menjadi satu tagihan,
+// misalnya saat tamu pindah bergabung ke meja lain. Baris, diskon, jumlah tamu dan
+// catatan dipindah ke pesanan tujuan, pesanan asal ditandai sudah digabung.
+func mergeOrders(reader *bufio.Reader) {
+	fmt.Print("Pesanan tujuan (ID atau \"meja <nomor>\"): ")
+	targetInput, _ := reader.ReadString('\n')
+	fmt.Print("Pesanan yang digabungkan (ID atau \"meja <nomor>\"): ")
+	sourceInput, _ := reader.ReadString('\n')
+
+	ordersMutex.Lock()
+	target, err := findOrderRef(targetInput)
+	if err != nil {
+		ordersMutex.Unlock()
+		fmt.Println(err)
+		return
+	}
+	source, err := findOrderRef(sourceInput)
+	if err != nil {
+		ordersMutex.Unlock()
+		fmt.Println(err)
+		return
+	}
+	if source == target {
+		ordersMutex.Unlock()
+		fmt.Println("Pesanan tujuan dan asal tidak boleh sama.")
+		return
+	}
+	for _, order := range []*Order{target, source} {
+		if err := checkMergeable(order); err != nil {
+			ordersMutex.Unlock()
+			fmt.Println("Tidak bisa menggabungkan:", err)
+			return
+		}
+	}
+
+	// Pendapatan kedua pesanan harus diakui dengan cara yang sama sebelum digabung
+	var revenue Money
+	if target.PendingAck != source.PendingAck {
+		for _, order := range []*Order{target, source} {
+			amount, _ := acknowledgeOrder(order, time.Now())
+			revenue += amount
+		}
+	}
+
+	for _, line := range source.Lines {
+		line.No = len(target.Lines) + 1
+		target.Lines = append(target.Lines, line)
+	}
+	target.TotalPrice += source.TotalPrice
+	target.Discount += source.Discount
+	if target.DiscountApproval.ApprovedAt.IsZero() {
+		target.DiscountApproval = source.DiscountApproval
+	}
+	target.Guests += source.Guests
+	if source.Note != "" {
+		target.Note = strings.TrimPrefix(target.Note+"; "+source.Note, "; ")
+	}
+	target.MergedFrom = append(target.MergedFrom, source.ID)
+
+	// Pesanan asal tetap disimpan sebagai jejak audit tanpa baris dan nilai
+	source.Lines = nil
+	source.TotalPrice = 0
+	source.Discount = 0
+	source.Guests = 0
+	source.MergedInto = target.ID
+
+	publishOrderEvent(OrderEvent{Type: EventOrderMerged, OrderID: source.ID, PickupCode: source.PickupCode, Table: source.Table})
+	summary := fmt.Sprintf("Pesanan ID %d%s digabung ke pesanan ID %d%s: %s | Total: %s",
+		source.ID, describeTable(source.Table, source.Delivery), target.ID, describeTable(target.Table, target.Delivery),
+		describeLines(target.Lines), formatMoney(target.TotalPrice))
+	sourceID, targetID := source.ID, target.ID
+	ordersMutex.Unlock()
+	recognizeRevenue(revenue)
+
+	logActivity(fmt.Sprintf("gabung pesanan %d ke pesanan %d", sourceID, targetID))
+	fmt.Println(summary)
+}
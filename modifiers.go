@@ -0,0 +1,240 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Struct untuk tambahan pesanan di konfigurasi, misalnya telur ekstra yang memakai stok item Telur
+type ModifierConfig struct {
+	// Harga tambahan per porsi
+	Price Money `json:"price"`
+	// Item menu yang stoknya dipakai, kosong berarti tambahan tanpa stok (misalnya ekstra pedas)
+	Stock string `json:"stock"`
+	// Jumlah stok yang dipakai per porsi, 0 dianggap 1
+	Quantity int `json:"quantity"`
+}
+
+// Struct untuk tambahan yang dipilih pada satu baris pesanan. Harga dan bahan disalin
+// dari konfigurasi saat dipesan agar perubahan konfigurasi tidak mengubah pesanan lama.
+type LineModifier struct {
+	Name     string `json:"name"`
+	Price    Money  `json:"price"`
+	Stock    string `json:"stock,omitempty"`
+	Quantity int    `json:"quantity,omitempty"`
+}
+
+// Struct untuk jumlah stok satu item bahan yang dipakai tambahan
+type modifierStockUse struct {
+	Name     string
+	Quantity int
+}
+
+// Fungsi untuk mencari tambahan di konfigurasi tanpa membedakan huruf besar kecil
+func findModifier(name string) (LineModifier, bool) {
+	name = strings.TrimSpace(name)
+	for key, modifier := range currentConfig().Modifiers {
+		if !strings.EqualFold(key, name) {
+			continue
+		}
+		quantity := max(modifier.Quantity, 1)
+		if modifier.Stock == "" {
+			quantity = 0
+		}
+		return LineModifier{Name: key, Price: modifier.Price, Stock: modifier.Stock, Quantity: quantity}, true
+	}
+	return LineModifier{}, false
+}
+
+// Fungsi untuk mengubah daftar nama tambahan menjadi tambahan baris pesanan
+func resolveModifiers(names []string) ([]LineModifier, error) {
+	var modifiers []LineModifier
+	for _, name := range names {
+		modifier, ok := findModifier(name)
+		if !ok {
+			return nil, fmt.Errorf("Tambahan %q tidak dikenal, pilih: %s.", name, strings.Join(modifierNames(), ", "))
+		}
+		modifiers = append(modifiers, modifier)
+	}
+	return modifiers, nil
+}
+
+// Fungsi untuk mengambil nama semua tambahan di konfigurasi, urut abjad
+func modifierNames() []string {
+	var names []string
+	for name := range currentConfig().Modifiers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Fungsi untuk menjumlahkan harga tambahan per porsi
+func modifiersPrice(modifiers []LineModifier) Money {
+	var total Money
+	for _, modifier := range modifiers {
+		total += modifier.Price
+	}
+	return total
+}
+
+// Fungsi untuk menampilkan tambahan baris pesanan, misalnya "+telur +keju"
+func describeModifiers(modifiers []LineModifier) string {
+	parts := make([]string, len(modifiers))
+	for i, modifier := range modifiers {
+		parts[i] = "+" + modifier.Name
+	}
+	return strings.Join(parts, " ")
+}
+
+// Fungsi untuk menghitung stok bahan yang dipakai tambahan untuk sejumlah porsi.
+// Tambahan yang memakai bahan yang sama dijumlahkan.
+func modifierStock(modifiers []LineModifier, portions int) []modifierStockUse {
+	var uses []modifierStockUse
+	for _, modifier := range modifiers {
+		if modifier.Stock == "" || modifier.Quantity == 0 {
+			continue
+		}
+		found := false
+		for i := range uses {
+			if strings.EqualFold(uses[i].Name, modifier.Stock) {
+				uses[i].Quantity += modifier.Quantity * portions
+				found = true
+				break
+			}
+		}
+		if !found {
+			uses = append(uses, modifierStockUse{Name: modifier.Stock, Quantity: modifier.Quantity * portions})
+		}
+	}
+	return uses
+}
+
+// Fungsi untuk memeriksa stok bahan tambahan tanpa menguranginya. Tambahan yang bahannya
+// habis atau tidak ada di menu ditolak. Pemanggil harus memegang menuMutex.
+func checkModifierStock(modifiers []LineModifier, portions int) error {
+	for _, use := range modifierStock(modifiers, portions) {
+		item := findMenuItem(use.Name)
+		if item == nil {
+			return fmt.Errorf("Bahan %s untuk tambahan tidak ada di menu.", use.Name)
+		}
+		if use.Quantity > item.Quantity {
+			return fmt.Errorf("Tambahan dengan bahan %s tidak tersedia, stok tersisa %d dari %d yang dibutuhkan.", item.Name, item.Quantity, use.Quantity)
+		}
+	}
+	return nil
+}
+
+// Fungsi untuk mengurangi stok bahan tambahan. Semua bahan diperiksa lebih dulu dan
+// stok yang sudah dikurangi dikembalikan jika salah satu gagal, sehingga stok tidak
+// berubah sebagian. Pemanggil harus memegang menuMutex.
+func reserveModifierStock(modifiers []LineModifier, portions int) error {
+	if err := checkModifierStock(modifiers, portions); err != nil {
+		return err
+	}
+	// Nama bahan disamakan dengan nama di menu agar buku stok konsisten
+	for i := range modifiers {
+		if item := findMenuItem(modifiers[i].Stock); modifiers[i].Stock != "" && item != nil {
+			modifiers[i].Stock = item.Name
+		}
+	}
+	uses := modifierStock(modifiers, portions)
+	for i, use := range uses {
+		if err := findMenuItem(use.Name).removeStock(use.Quantity); err != nil {
+			for _, taken := range uses[:i] {
+				findMenuItem(taken.Name).addStock(taken.Quantity, time.Time{})
+			}
+			return fmt.Errorf("Gagal mengurangi stok %s: %w", use.Name, err)
+		}
+	}
+	return nil
+}
+
+// Fungsi untuk mengembalikan stok bahan tambahan, misalnya saat pesanan dibatalkan.
+// Reference kosong berarti membatalkan pengurangan yang belum dicatat di buku stok.
+// Pemanggil harus memegang menuMutex.
+func releaseModifierStock(modifiers []LineModifier, portions int, reference string) {
+	for _, use := range modifierStock(modifiers, portions) {
+		item := findMenuItem(use.Name)
+		if item == nil {
+			continue
+		}
+		if err := item.addStock(use.Quantity, time.Time{}); err != nil {
+			fmt.Printf("Gagal mengembalikan stok %s: %v\n", item.Name, err)
+			continue
+		}
+		if reference != "" {
+			recordMovement(item.Name, use.Quantity, MovementReturn, reference)
+		}
+	}
+}
+
+// Fungsi untuk mencatat pemakaian stok bahan tambahan ke buku stok
+func recordModifierSale(modifiers []LineModifier, portions int, kind MovementKind, reference string) {
+	for _, use := range modifierStock(modifiers, portions) {
+		recordMovement(use.Name, -use.Quantity, kind, reference)
+	}
+}
+
+// Fungsi untuk menampilkan pilihan tambahan beserta harga dan tandanya jika bahannya
+// habis. Pemanggil harus memegang menuMutex.
+func describeModifierChoices() string {
+	var parts []string
+	for _, name := range modifierNames() {
+		modifier, _ := findModifier(name)
+		text := fmt.Sprintf("%s (%s)", name, formatMoney(modifier.Price))
+		if checkModifierStock([]LineModifier{modifier}, 1) != nil {
+			text += " [HABIS]"
+		}
+		parts = append(parts, text)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Fungsi untuk membaca tambahan satu baris pesanan dari kasir, dipisah koma. Tambahan
+// yang bahannya tidak cukup untuk semua porsi ditolak. Pemanggil tidak boleh memegang
+// menuMutex karena tambahan dibaca dari kasir.
+func readModifiers(reader *bufio.Reader, portions int, checkStock bool) ([]LineModifier, bool) {
+	if len(currentConfig().Modifiers) == 0 {
+		return nil, true
+	}
+	menuMutex.Lock()
+	choices := describeModifierChoices()
+	menuMutex.Unlock()
+	fmt.Printf("Tambahan: %s\n", choices)
+	fmt.Print("Pilih tambahan (pisahkan koma, kosongkan jika tidak ada): ")
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, true
+	}
+
+	var names []string
+	for _, name := range strings.Split(input, ",") {
+		if name = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(name), "+")); name != "" {
+			names = append(names, name)
+		}
+	}
+	modifiers, err := resolveModifiers(names)
+	if err == nil && checkStock {
+		menuMutex.Lock()
+		err = checkModifierStock(modifiers, portions)
+		menuMutex.Unlock()
+	}
+	if err != nil {
+		fmt.Println(err)
+		return nil, false
+	}
+	return modifiers, true
+}
+
+// Fungsi untuk menampilkan nama item beserta tambahannya, misalnya "Nasi Goreng (+telur)"
+func lineLabel(line OrderLine) string {
+	if len(line.Modifiers) == 0 {
+		return line.ItemName
+	}
+	return fmt.Sprintf("%s (%s)", line.ItemName, describeModifiers(line.Modifiers))
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// Nilai uang dalam satuan terkecil (sen, 1/100 rupiah). Disimpan sebagai bilangan bulat
+// agar penjumlahan ribuan pesanan tidak menumpuk galat pembulatan seperti float64.
+// Pembulatan hanya terjadi di batas yang jelas: saat membaca input atau file, saat
+// dikalikan tarif (pajak, diskon persen) dan saat dibagi (rata-rata).
+type Money int64
+
+// Jumlah satuan terkecil dalam satu rupiah
+const moneyScale = 100
+
+// Jumlah nilai uang di file data yang dibulatkan ke sen saat dibaca. Jika lebih dari
+// nol setelah pemuatan, data disimpan ulang agar file ikut bermigrasi.
+var moneyNormalized atomic.Int64
+
+// Fungsi untuk membuat Money dari rupiah, dibulatkan ke sen terdekat
+func moneyFromFloat(amount float64) Money {
+	return Money(math.Round(amount * moneyScale))
+}
+
+// Fungsi untuk membaca nominal uang dari teks seperti "15000" atau "12500.5" tanpa
+// melewati float64, angka di bawah sen dibulatkan setengah ke atas
+func parseMoney(text string) (Money, error) {
+	value, ok := new(big.Rat).SetString(text)
+	if !ok {
+		return 0, fmt.Errorf("nominal %q tidak valid", text)
+	}
+	money, _, err := moneyFromRat(value)
+	return money, err
+}
+
+// Fungsi untuk mengubah pecahan rupiah menjadi sen, mengembalikan false jika perlu dibulatkan
+func moneyFromRat(value *big.Rat) (Money, bool, error) {
+	cents := new(big.Rat).Mul(value, big.NewRat(moneyScale, 1))
+	quotient, remainder := new(big.Int).QuoRem(cents.Num(), cents.Denom(), new(big.Int))
+	if remainder.Sign() != 0 {
+		// Setengah sen atau lebih dibulatkan menjauhi nol
+		twice := new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2))
+		if twice.Cmp(cents.Denom()) >= 0 {
+			quotient.Add(quotient, big.NewInt(int64(cents.Num().Sign())))
+		}
+	}
+	if !quotient.IsInt64() {
+		return 0, false, errors.New("nominal terlalu besar")
+	}
+	return Money(quotient.Int64()), remainder.Sign() == 0, nil
+}
+
+// Fungsi untuk mengubah Money ke rupiah, hanya untuk rasio dan persentase
+func (m Money) Float() float64 {
+	return float64(m) / moneyScale
+}
+
+// Fungsi untuk mengalikan harga satuan dengan jumlah
+func (m Money) Times(quantity int) Money {
+	return m * Money(quantity)
+}
+
+// Fungsi untuk mengalikan nilai dengan tarif seperti 0.11 untuk pajak, hasilnya
+// dibulatkan ke sen terdekat
+func (m Money) MulRate(rate float64) Money {
+	return Money(math.Round(float64(m) * rate))
+}
+
+// Fungsi untuk membagi nilai rata dengan n bagian, misalnya rata-rata per pesanan.
+// Hasilnya dibulatkan ke sen terdekat, n harus lebih dari nol.
+func (m Money) Div(n int) Money {
+	return Money(math.Round(float64(m) / float64(n)))
+}
+
+// Fungsi untuk menulis nilai sebagai angka rupiah tanpa pemisah ribuan, misalnya
+// 12500 atau 12500.50, dipakai di JSON dan CSV
+func (m Money) String() string {
+	sign := ""
+	value := int64(m)
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+	whole := strconv.FormatInt(value/moneyScale, 10)
+	if cents := value % moneyScale; cents != 0 {
+		return fmt.Sprintf("%s%s.%02d", sign, whole, cents)
+	}
+	return sign + whole
+}
+
+// Fungsi untuk menulis nilai dengan tepat dua desimal tanpa mata uang, untuk kolom
+// jumlah di ekspor CSV
+func formatMoneyPlain(amount Money) string {
+	text := amount.String()
+	if !strings.Contains(text, ".") {
+		text += ".00"
+	}
+	return text
+}
+
+// Uang disimpan di JSON sebagai angka rupiah agar file lama dan klien API tetap cocok
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	// Angka dibaca sebagai teks agar 0.1 tetap tepat 10 sen
+	value, ok := new(big.Rat).SetString(string(data))
+	if !ok {
+		return &json.UnmarshalTypeError{Value: "string", Type: reflect.TypeFor[Money]()}
+	}
+	money, exact, err := moneyFromRat(value)
+	if err != nil {
+		return err
+	}
+	if !exact {
+		moneyNormalized.Add(1)
+	}
+	*m = money
+	return nil
+}
+
+// Kolom uang di database berupa BIGINT berisi sen, jadi nilainya tersimpan tepat
+func (m Money) Value() (driver.Value, error) {
+	return int64(m), nil
+}
+
+func (m *Money) Scan(src any) error {
+	switch value := src.(type) {
+	case int64:
+		*m = Money(value)
+	case float64:
+		// Kolom SQLite yang dimigrasi dari DOUBLE PRECISION tetap REAL berisi sen
+		*m = Money(math.Round(value))
+		if float64(*m) != value {
+			moneyNormalized.Add(1)
+		}
+	case []byte:
+		return m.scanText(string(value))
+	case string:
+		return m.scanText(value)
+	case nil:
+		*m = 0
+	default:
+		return fmt.Errorf("kolom uang bertipe %T tidak didukung", src)
+	}
+	return nil
+}
+
+// Fungsi untuk membaca kolom uang yang dikirim driver sebagai teks berisi sen
+func (m *Money) scanText(text string) error {
+	cents, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		return fmt.Errorf("kolom uang %q tidak valid", text)
+	}
+	*m = Money(cents)
+	return nil
+}
+
+// Fungsi untuk menulis ulang data yang nilai uangnya dibulatkan ke sen saat dimuat.
+// Data dari versi lama yang masih memakai float64 bisa berisi galat seperti
+// 0.30000000000000004, setelah disimpan ulang semua nilai tepat dalam sen.
+func migrateStoredMoney() {
+	count := moneyNormalized.Swap(0)
+	if count == 0 || dryRunActive() {
+		return
+	}
+	// Database hanya menulis baris yang berubah, sidik lama dilupakan agar semua ditulis ulang
+	if store, ok := orderRepo.(*sqlStore); ok {
+		store.savedItems = map[string]string{}
+		store.savedOrders = map[int]string{}
+	}
+	saveState()
+	logActivity(fmt.Sprintf("migrasi uang: %d nilai dibulatkan ke sen dan disimpan ulang", count))
+	fmt.Printf("%d nilai uang lama dibulatkan ke sen dan data disimpan ulang.\n", count)
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    Money
+		wantErr bool
+	}{
+		{"15000", 1500000, false},
+		{"12500.5", 1250050, false},
+		{"0.1", 10, false},
+		{"0.105", 11, false},
+		{"0.104", 10, false},
+		{"-2.345", -235, false},
+		{"1/3", 33, false},
+		{"", 0, true},
+		{"abc", 0, true},
+		{"1.000,50", 0, true},
+		{"100000000000000000000", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseMoney(tt.text)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseMoney(%q) galat = %v, ingin galat %v", tt.text, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseMoney(%q) = %d sen, ingin %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyFromRatRoundsHalfUp(t *testing.T) {
+	tests := []struct {
+		rat       string
+		want      Money
+		wantExact bool
+	}{
+		{"1.23", 123, true},
+		{"1.234", 123, false},
+		{"1.235", 124, false},
+		{"1.2349999", 123, false},
+		// Setengah sen dibulatkan menjauhi nol, juga untuk nilai negatif
+		{"0.005", 1, false},
+		{"-0.005", -1, false},
+		{"-1.234", -123, false},
+		{"-1.235", -124, false},
+		{"0", 0, true},
+	}
+	for _, tt := range tests {
+		value, ok := new(big.Rat).SetString(tt.rat)
+		if !ok {
+			t.Fatalf("pecahan uji %q tidak valid", tt.rat)
+		}
+		got, exact, err := moneyFromRat(value)
+		if err != nil {
+			t.Errorf("moneyFromRat(%s): %v", tt.rat, err)
+			continue
+		}
+		if got != tt.want || exact != tt.wantExact {
+			t.Errorf("moneyFromRat(%s) = %d, %v; ingin %d, %v", tt.rat, got, exact, tt.want, tt.wantExact)
+		}
+	}
+}
+
+func TestMoneyMulRate(t *testing.T) {
+	tests := []struct {
+		amount Money
+		rate   float64
+		want   Money
+	}{
+		{1500000, 0.11, 165000},
+		{1500000, 0, 0},
+		{1500000, 1, 1500000},
+		{12345, 0.1, 1235},
+		{12344, 0.1, 1234},
+		{999, 0.5, 500},
+		{-999, 0.5, -500},
+		{3333, 0.115, 383},
+	}
+	for _, tt := range tests {
+		if got := tt.amount.MulRate(tt.rate); got != tt.want {
+			t.Errorf("Money(%d).MulRate(%v) = %d, ingin %d", tt.amount, tt.rate, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		data       string
+		want       Money
+		normalized int64
+		wantErr    bool
+	}{
+		{"15000", 1500000, 0, false},
+		{"12500.50", 1250050, 0, false},
+		{"0.30000000000000004", 30, 1, false},
+		{"-7.5", -750, 0, false},
+		{"1e3", 100000, 0, false},
+		{`"15000"`, 0, 0, true},
+		{"true", 0, 0, true},
+	}
+	for _, tt := range tests {
+		moneyNormalized.Store(0)
+		var got Money
+		err := json.Unmarshal([]byte(tt.data), &got)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Unmarshal(%s) galat = %v, ingin galat %v", tt.data, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Unmarshal(%s) = %d sen, ingin %d", tt.data, got, tt.want)
+		}
+		if count := moneyNormalized.Load(); count != tt.normalized {
+			t.Errorf("Unmarshal(%s) mencatat %d nilai dibulatkan, ingin %d", tt.data, count, tt.normalized)
+		}
+	}
+	moneyNormalized.Store(0)
+
+	// null tidak mengubah nilai yang sudah ada
+	got := Money(500)
+	if err := json.Unmarshal([]byte("null"), &got); err != nil || got != 500 {
+		t.Errorf("Unmarshal(null) = %d, %v; ingin 500 tanpa galat", got, err)
+	}
+}
+
+func TestMoneySQLValue(t *testing.T) {
+	value, err := Money(1250050).Value()
+	if err != nil || value != int64(1250050) {
+		t.Fatalf("Value() = %v (%T), %v; ingin int64 1250050", value, value, err)
+	}
+
+	tests := []struct {
+		src  any
+		want Money
+	}{
+		{int64(1250050), 1250050},
+		{float64(1250050), 1250050},
+		{[]byte("1250050"), 1250050},
+		{"-750", -750},
+		{nil, 0},
+	}
+	for _, tt := range tests {
+		got := Money(99)
+		if err := got.Scan(tt.src); err != nil || got != tt.want {
+			t.Errorf("Scan(%#v) = %d, %v; ingin %d", tt.src, got, err, tt.want)
+		}
+	}
+	var got Money
+	if err := got.Scan("15000.5"); err == nil {
+		t.Errorf("Scan teks pecahan berhasil menjadi %d, ingin galat karena kolom berisi sen", got)
+	}
+}
@@ -0,0 +1,6 @@
+//go:build !race
+
+package main
+
+// Bernilai true jika test dijalankan dengan -race
+const raceEnabled = false
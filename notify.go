@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Baris pesanan yang sudah diberi peringatan SLA agar peringatan tidak berulang setiap tick
+type slaKey struct{ order, line int }
+
+var slaNotified = map[slaKey]bool{}
+var slaMutex sync.Mutex
+
+// Ditandai setelah notifikasi desktop gagal sekali agar pesan gagal tidak memenuhi layar
+var desktopNotifyFailed atomic.Bool
+
+// Fungsi untuk memberi tahu manajer tentang kejadian penting lewat layar, bunyi bel
+// terminal dan notifikasi desktop sesuai konfigurasi. Selama dry-run tidak ada notifikasi.
+func notify(title, message string) {
+	if dryRunActive() {
+		return
+	}
+
+	settings := currentConfig().Notify
+	bell := ""
+	if settings.Bell {
+		bell = "\a"
+	}
+	asyncPrintf("%s\n[PERINGATAN] %s: %s\n", bell, title, message)
+
+	if settings.Desktop && !desktopNotifyFailed.Load() {
+		if err := sendDesktopNotification(title, message); err != nil {
+			desktopNotifyFailed.Store(true)
+			asyncPrintf("Notifikasi desktop tidak tersedia: %v\n", err)
+		}
+	}
+}
+
+// Fungsi untuk menampilkan notifikasi desktop dengan osascript di macOS atau notify-send di Linux
+func sendDesktopNotification(title, message string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %q with title %q", message, title)
+		cmd = exec.Command("osascript", "-e", script)
+	case "linux", "freebsd", "openbsd":
+		cmd = exec.Command("notify-send", title, message)
+	default:
+		return fmt.Errorf("tidak didukung di %s", runtime.GOOS)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
+
+// Fungsi untuk mengambil course setiap item menu, dibaca sebelum ordersMutex dikunci
+// agar urutan kunci menuMutex lalu ordersMutex tetap terjaga
+func menuCourses() map[string]string {
+	menuMutex.Lock()
+	defer menuMutex.Unlock()
+
+	courses := map[string]string{}
+	for _, item := range stockItems() {
+		courses[item.Name] = itemCourse(item)
+	}
+	return courses
+}
+
+// Fungsi untuk mengambil batas waktu SLA sebuah course, course tanpa batas sendiri
+// memakai order_sla_minutes. 0 berarti tidak diperiksa.
+func slaLimit(course string) time.Duration {
+	settings := currentConfig().Notify
+	if minutes, ok := settings.CourseSLAMinutes[course]; ok && course != "" {
+		return time.Duration(minutes) * time.Minute
+	}
+	return time.Duration(settings.OrderSLAMinutes) * time.Minute
+}
+
+// Fungsi untuk menghitung berapa lama baris sudah menunggu di dapur dan apakah sudah
+// melewati SLA course-nya. Baris dihitung sejak dikirim ke dapur: waktu fire course,
+// waktu persiapan pesanan terjadwal, atau waktu pesanan dibuat.
+func lineOverdue(order *Order, line OrderLine, course string, now time.Time) (time.Duration, bool) {
+	if line.Status != LineQueued && line.Status != LinePreparing {
+		return 0, false
+	}
+	limit := slaLimit(course)
+	if limit <= 0 {
+		return 0, false
+	}
+	start := order.CreatedAt
+	switch {
+	case !line.FireAt.IsZero():
+		start = line.FireAt
+	case !order.PickupAt.IsZero():
+		start = order.PickupAt.Add(-time.Duration(currentConfig().PreOrderLeadMinutes) * time.Minute)
+	}
+	age := now.Sub(start)
+	return age, age > limit
+}
+
+// Fungsi untuk menandai baris yang melewati SLA di tampilan antrian dapur dan stasiun
+func describeOverdue(order *Order, line OrderLine, course string, now time.Time) string {
+	if age, late := lineOverdue(order, line, course, now); late {
+		return fmt.Sprintf(" [TERLAMBAT %s]", formatPrepTime(age))
+	}
+	return ""
+}
+
+// Fungsi untuk memberi peringatan baris pesanan yang belum siap melewati batas waktu SLA
+// course-nya, misalnya minuman 3 menit dan makanan utama 15 menit. Setiap baris hanya
+// diperingatkan sekali: lewat layar dan bel sesuai notify, lalu hook order_late dijalankan.
+func checkOrderSLA(now time.Time) {
+	courses := menuCourses()
+
+	type lateOrder struct {
+		order Order
+		items []string
+	}
+	var late []lateOrder
+
+	ordersMutex.Lock()
+	slaMutex.Lock()
+	for _, order := range orders {
+		if order.Voided {
+			continue
+		}
+		var items []string
+		for _, line := range order.Lines {
+			key := slaKey{order.ID, line.No}
+			if slaNotified[key] {
+				continue
+			}
+			course := courses[line.ItemName]
+			age, overdue := lineOverdue(order, line, course, now)
+			if !overdue {
+				continue
+			}
+			slaNotified[key] = true
+			label := line.ItemName
+			if course != "" {
+				label += " (" + course + ")"
+			}
+			items = append(items, fmt.Sprintf("%s %s, batas %s", label, formatPrepTime(age), formatPrepTime(slaLimit(course))))
+		}
+		if len(items) > 0 {
+			late = append(late, lateOrder{copyOrder(*order), items})
+		}
+	}
+	slaMutex.Unlock()
+	ordersMutex.Unlock()
+
+	for _, entry := range late {
+		order := entry.order
+		notify("Pesanan terlambat", fmt.Sprintf("pesanan ID %d%s: %s", order.ID, describeTable(order.Table, order.Delivery), strings.Join(entry.items, "; ")))
+		runOrderHooks(HookOrderLate, order)
+	}
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Versi dokumen API, dinaikkan setiap kali bentuk endpoint berubah
+const apiVersion = "1.0.0"
+
+// Fungsi untuk membuat dokumen OpenAPI 3 dari daftar apiRoutes
+func buildOpenAPISpec() map[string]any {
+	schemas := map[string]any{}
+	paths := map[string]map[string]any{}
+
+	for _, route := range apiRoutes {
+		operation := map[string]any{
+			"summary":     route.Summary,
+			"operationId": operationID(route),
+		}
+
+		var parameters []any
+		for _, segment := range strings.Split(route.Path, "/") {
+			if name, ok := strings.CutPrefix(segment, "{"); ok {
+				parameters = append(parameters, map[string]any{
+					"name":     strings.TrimSuffix(name, "}"),
+					"in":       "path",
+					"required": true,
+					"schema":   map[string]any{"type": "integer"},
+				})
+			}
+		}
+		if len(parameters) > 0 {
+			operation["parameters"] = parameters
+		}
+
+		if route.Request != nil {
+			operation["requestBody"] = map[string]any{
+				"required": true,
+				"content": map[string]any{
+					"application/json": map[string]any{"schema": schemaFor(reflect.TypeOf(route.Request), schemas)},
+				},
+			}
+		}
+
+		contentType := "application/json"
+		if route.Stream {
+			contentType = "text/event-stream"
+		}
+		responses := map[string]any{}
+		for status, body := range route.Responses {
+			// Jawaban tanpa isi, misalnya 304 Not Modified
+			if body == nil {
+				responses[strconv.Itoa(status)] = map[string]any{"description": http.StatusText(status)}
+				continue
+			}
+			responses[strconv.Itoa(status)] = map[string]any{
+				"description": http.StatusText(status),
+				"content": map[string]any{
+					contentType: map[string]any{"schema": schemaFor(reflect.TypeOf(body), schemas)},
+				},
+			}
+		}
+		// Semua endpoint melewati pemeriksaan kunci API dan pembatasan laju
+		responses["401"] = map[string]any{"description": "Kunci API tidak valid", "content": errorContent(schemas)}
+		responses["429"] = map[string]any{"description": "Batas permintaan per menit terlampaui", "content": errorContent(schemas)}
+		operation["responses"] = responses
+
+		if paths[route.Path] == nil {
+			paths[route.Path] = map[string]any{}
+		}
+		paths[route.Path][strings.ToLower(route.Method)] = operation
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   currentConfig().RestaurantName + " API",
+			"version": apiVersion,
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+			"securitySchemes": map[string]any{
+				"apiKey":     map[string]any{"type": "apiKey", "in": "header", "name": "X-API-Key"},
+				"bearerAuth": map[string]any{"type": "http", "scheme": "bearer"},
+				"queryKey":   map[string]any{"type": "apiKey", "in": "query", "name": "api_key"},
+			},
+		},
+		"security": []any{
+			map[string]any{"apiKey": []any{}},
+			map[string]any{"bearerAuth": []any{}},
+			map[string]any{"queryKey": []any{}},
+		},
+	}
+}
+
+// Fungsi untuk membuat operationId seperti getOrdersId dari method dan path
+func operationID(route apiRoute) string {
+	var id strings.Builder
+	id.WriteString(strings.ToLower(route.Method))
+	for _, segment := range strings.Split(route.Path, "/") {
+		segment = strings.Trim(segment, "{}")
+		if segment != "" {
+			id.WriteString(strings.ToUpper(segment[:1]) + segment[1:])
+		}
+	}
+	return id.String()
+}
+
+// Fungsi untuk membuat konten jawaban kesalahan standar
+func errorContent(schemas map[string]any) map[string]any {
+	return map[string]any{
+		"application/json": map[string]any{"schema": schemaFor(reflect.TypeOf(apiError{}), schemas)},
+	}
+}
+
+// Fungsi untuk membuat JSON Schema dari tipe Go mengikuti tag json yang sama dengan
+// encoding/json. Struct bernama disimpan di components/schemas dan dirujuk dengan $ref.
+func schemaFor(t reflect.Type, schemas map[string]any) map[string]any {
+	switch t {
+	case reflect.TypeOf(time.Time{}):
+		return map[string]any{"type": "string", "format": "date-time"}
+	case reflect.TypeOf(time.Duration(0)):
+		return map[string]any{"type": "integer", "format": "int64", "description": "durasi dalam nanodetik"}
+	case reflect.TypeOf(Money(0)):
+		return map[string]any{"type": "number", "description": "nominal rupiah, paling banyak dua desimal"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return schemaFor(t.Elem(), schemas)
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": schemaFor(t.Elem(), schemas)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaFor(t.Elem(), schemas)}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return structSchema(t, schemas)
+		}
+		name := t.Name()
+		if _, ok := schemas[name]; !ok {
+			// Daftarkan dulu sebelum mengisi agar tipe rekursif seperti varian menu tidak berulang tanpa akhir
+			schemas[name] = nil
+			schemas[name] = structSchema(t, schemas)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + name}
+	}
+	return map[string]any{}
+}
+
+// Fungsi untuk membuat schema object dari field struct yang diekspor
+func structSchema(t reflect.Type, schemas map[string]any) map[string]any {
+	properties := map[string]any{}
+	var required []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = schemaFor(field.Type, schemas)
+		if !strings.Contains(options, "omitempty") {
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		sort.Strings(required)
+		schema["required"] = required
+	}
+	return schema
+}
+
+// GET /openapi.json mengembalikan dokumen OpenAPI untuk semua endpoint
+func handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, buildOpenAPISpec())
+}
+
+// Fungsi untuk menulis dokumen OpenAPI ke file, atau ke layar jika path berisi "-"
+func writeOpenAPISpec(path string) error {
+	data, err := json.MarshalIndent(buildOpenAPISpec(), "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if path == "-" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return err
+	}
+	fmt.Printf("Dokumen OpenAPI ditulis ke %s\n", path)
+	return nil
+}
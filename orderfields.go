@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Struct untuk kolom tambahan pesanan di konfigurasi, misalnya nomor antrian Grab
+type OrderFieldConfig struct {
+	// Kunci penyimpanan dan laporan kustom, tanpa spasi, misalnya "grab"
+	Key string `json:"key"`
+	// Nama yang ditanyakan ke kasir dan dicetak di struk, misalnya "Nomor antrian Grab"
+	Label string `json:"label"`
+	// Pesanan tidak bisa dibuat tanpa kolom ini
+	Required bool `json:"required"`
+	// Jenis pesanan yang memakai kolom ini, kosong berarti semua jenis
+	OrderTypes []OrderType `json:"order_types"`
+}
+
+// Fungsi untuk memeriksa apakah kolom dipakai untuk jenis pesanan tertentu
+func (field OrderFieldConfig) appliesTo(orderType OrderType) bool {
+	return len(field.OrderTypes) == 0 || slices.Contains(field.OrderTypes, orderType)
+}
+
+// Fungsi untuk memeriksa daftar kolom tambahan di konfigurasi
+func validateOrderFields(fields []OrderFieldConfig) error {
+	seen := map[string]bool{}
+	for _, field := range fields {
+		if field.Key == "" || strings.ContainsAny(field.Key, " =\t") {
+			return fmt.Errorf("order_fields: kunci %q harus diisi tanpa spasi atau tanda =", field.Key)
+		}
+		if seen[strings.ToLower(field.Key)] || containsString(customReportGroups, strings.ToLower(field.Key)) {
+			return fmt.Errorf("order_fields: kunci %q sudah dipakai", field.Key)
+		}
+		seen[strings.ToLower(field.Key)] = true
+		for _, orderType := range field.OrderTypes {
+			if !slices.Contains(orderTypes, orderType) {
+				return fmt.Errorf("order_fields %s: jenis pesanan %q tidak dikenal", field.Key, orderType)
+			}
+		}
+	}
+	return nil
+}
+
+// Fungsi untuk mencari kolom tambahan berdasarkan kunci tanpa membedakan huruf besar kecil
+func findOrderField(key string) (OrderFieldConfig, bool) {
+	for _, field := range currentConfig().OrderFields {
+		if strings.EqualFold(field.Key, key) {
+			return field, true
+		}
+	}
+	return OrderFieldConfig{}, false
+}
+
+// Fungsi untuk mengambil label kolom tambahan, kunci dipakai jika label kosong
+func (field OrderFieldConfig) label() string {
+	if field.Label == "" {
+		return field.Key
+	}
+	return field.Label
+}
+
+// Fungsi untuk menanyakan kolom tambahan yang berlaku untuk jenis pesanan ini. Kolom
+// wajib yang dikosongkan membatalkan pesanan.
+func readOrderFields(reader *bufio.Reader, orderType OrderType) (map[string]string, bool) {
+	values := map[string]string{}
+	for _, field := range currentConfig().OrderFields {
+		if !field.appliesTo(orderType) {
+			continue
+		}
+		optional := " (kosongkan jika tidak ada)"
+		if field.Required {
+			optional = ""
+		}
+		fmt.Printf("%s%s: ", field.label(), optional)
+		input, _ := reader.ReadString('\n')
+		input = strings.TrimSpace(input)
+		if input == "" {
+			if field.Required {
+				fmt.Printf("%s wajib diisi.\n", field.label())
+				return nil, false
+			}
+			continue
+		}
+		values[field.Key] = input
+	}
+	if len(values) == 0 {
+		return nil, true
+	}
+	return values, true
+}
+
+// Fungsi untuk memeriksa kolom tambahan dari API: kunci harus dikenal dan kolom wajib
+// untuk jenis pesanan ini harus terisi. Kunci disamakan dengan konfigurasi.
+func checkOrderFields(values map[string]string, orderType OrderType) (map[string]string, error) {
+	result := map[string]string{}
+	for key, value := range values {
+		field, ok := findOrderField(key)
+		if !ok {
+			return nil, fmt.Errorf("kolom %q tidak dikenal", key)
+		}
+		if !field.appliesTo(orderType) {
+			return nil, fmt.Errorf("kolom %s tidak dipakai untuk pesanan %s", field.Key, orderType.Label())
+		}
+		if value = strings.TrimSpace(value); value != "" {
+			result[field.Key] = value
+		}
+	}
+	for _, field := range currentConfig().OrderFields {
+		if field.Required && field.appliesTo(orderType) && result[field.Key] == "" {
+			return nil, errors.New(field.label() + " wajib diisi")
+		}
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return result, nil
+}
+
+// Fungsi untuk menulis kolom tambahan pesanan ke struk atau tiket sesuai urutan di
+// konfigurasi. Kolom yang sudah dihapus dari konfigurasi tetap ditulis dengan kuncinya.
+func writeOrderFields(l *textLayout, values map[string]string) {
+	if len(values) == 0 {
+		return
+	}
+	written := map[string]bool{}
+	for _, field := range currentConfig().OrderFields {
+		if value, ok := values[field.Key]; ok {
+			l.Pair(field.label(), value)
+			written[field.Key] = true
+		}
+	}
+	var rest []string
+	for key := range values {
+		if !written[key] {
+			rest = append(rest, key)
+		}
+	}
+	slices.Sort(rest)
+	for _, key := range rest {
+		l.Pair(key, values[key])
+	}
+}
@@ -0,0 +1,694 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Semua pesanan yang sudah dibuat, dipakai oleh tampilan dapur
+var orders []*Order
+var ordersMutex sync.Mutex
+
+// ID pesanan terakhir yang tercatat di terminal ini
+var lastOrderID int
+
+// Fungsi untuk menyimpan pesanan baru
+func recordOrder(order *Order) {
+	ordersMutex.Lock()
+	defer ordersMutex.Unlock()
+
+	if order.CreatedAt.IsZero() {
+		order.CreatedAt = time.Now()
+	}
+	if order.PickupCode == "" {
+		order.PickupCode = nextPickupCode(order.CreatedAt)
+	}
+	if order.Cashier == "" {
+		activityMutex.Lock()
+		order.Cashier = currentCashier
+		activityMutex.Unlock()
+	}
+	if order.Source == "" {
+		order.Source = order.Channel()
+	}
+	// Pendapatan baru diakui setelah dapur mengonfirmasi pesanan
+	if currentConfig().KitchenAckMinutes > 0 && order.AcknowledgedAt.IsZero() {
+		order.PendingAck = true
+	} else if order.AcknowledgedAt.IsZero() {
+		order.AcknowledgedAt = order.CreatedAt
+	}
+	orders = append(orders, order)
+	notePickupCode(order)
+	lastOrderID = max(lastOrderID, order.ID)
+	logPriceOverrides(order)
+	publishOrderEvent(OrderEvent{Type: EventOrderCreated, OrderID: order.ID, PickupCode: order.PickupCode, Table: order.Table})
+	runOrderHooks(HookOrderCreated, copyOrder(*order))
+}
+
+// Nomor ambil terakhir hari ini yang diingat agar pesanan baru tidak perlu memindai
+// semua pesanan historis. Hanya berlaku selama jumlah pesanan sama dengan orderCount,
+// jika pesanan dimuat ulang, diarsip atau disinkron nomor dihitung ulang. Dijaga ordersMutex.
+var pickupCache struct {
+	start      time.Time
+	last       int
+	orderCount int
+}
+
+// Fungsi untuk membuat nomor ambil berikutnya pada hari pesanan dibuat. Nomor dimulai
+// dari 1 setiap hari dan terpisah dari ID pesanan. Pemanggil harus memegang ordersMutex.
+func nextPickupCode(createdAt time.Time) string {
+	prefix := currentConfig().PickupPrefix
+	year, month, day := createdAt.Local().Date()
+	start := time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+	if !pickupCache.start.Equal(start) || pickupCache.orderCount != len(orders) {
+		// Batas hari dihitung sekali, membandingkan waktu jauh lebih murah daripada
+		// mengubah setiap pesanan ke zona waktu lokal
+		end := start.AddDate(0, 0, 1)
+		last := 0
+		for _, order := range orders {
+			if order.CreatedAt.Before(start) || !order.CreatedAt.Before(end) {
+				continue
+			}
+			last = max(last, pickupNumber(order.PickupCode, prefix))
+		}
+		pickupCache.start, pickupCache.last, pickupCache.orderCount = start, last, len(orders)
+	}
+	return fmt.Sprintf("%s-%d", prefix, pickupCache.last+1)
+}
+
+// Fungsi untuk mencatat nomor ambil pesanan yang baru ditambahkan ke cache nomor ambil.
+// Pemanggil harus memegang ordersMutex dan sudah menambahkan pesanan ke orders.
+func notePickupCode(order *Order) {
+	if pickupCache.orderCount != len(orders)-1 {
+		return
+	}
+	pickupCache.orderCount = len(orders)
+	end := pickupCache.start.AddDate(0, 0, 1)
+	if !order.CreatedAt.Before(pickupCache.start) && order.CreatedAt.Before(end) {
+		pickupCache.last = max(pickupCache.last, pickupNumber(order.PickupCode, currentConfig().PickupPrefix))
+	}
+}
+
+// Fungsi untuk mengambil angka dari nomor ambil, 0 jika awalannya berbeda
+func pickupNumber(code, prefix string) int {
+	numberText, ok := strings.CutPrefix(code, prefix+"-")
+	if !ok {
+		return 0
+	}
+	number, _ := strconv.Atoi(numberText)
+	return number
+}
+
+// Fungsi untuk menampilkan nomor ambil di samping ID pesanan
+func describePickupCode(code string) string {
+	if code == "" {
+		return ""
+	}
+	return " [" + code + "]"
+}
+
+// Fungsi untuk mengambil ID pesanan baru. Pada backend bersama ID diambil dari
+// database agar tidak bentrok dengan terminal lain, kecuali saat dry-run.
+func newOrderID() (int, bool) {
+	if sharedStore != nil && !dryRunActive() {
+		id, err := sharedStore.NextOrderID()
+		if err != nil {
+			fmt.Println("Gagal mengambil ID pesanan:", err)
+			return 0, false
+		}
+		return id, true
+	}
+
+	ordersMutex.Lock()
+	defer ordersMutex.Unlock()
+
+	return lastOrderID + 1, true
+}
+
+// Fungsi untuk mencari pesanan berdasarkan ID, pemanggil harus memegang ordersMutex
+func findOrder(id int) *Order {
+	for _, order := range orders {
+		if order.ID == id {
+			return order
+		}
+	}
+	return nil
+}
+
+// Fungsi untuk membaca ID pesanan dari input
+func readOrderID(reader *bufio.Reader) (int, bool) {
+	fmt.Print("Masukkan ID pesanan: ")
+	input, _ := reader.ReadString('\n')
+	id, err := strconv.Atoi(strings.TrimSpace(input))
+	if err != nil {
+		fmt.Println("ID pesanan harus berupa angka.")
+		return 0, false
+	}
+	return id, true
+}
+
+// Fungsi untuk menandai pesanan sudah dibayar
+func payOrder(reader *bufio.Reader) {
+	id, ok := readOrderID(reader)
+	if !ok {
+		return
+	}
+
+	ordersMutex.Lock()
+	order := followMergedOrder(findOrder(id))
+	if order != nil {
+		id = order.ID
+	}
+	if order == nil {
+		ordersMutex.Unlock()
+		fmt.Println("Pesanan tidak ditemukan.")
+		return
+	}
+	if order.Paid {
+		ordersMutex.Unlock()
+		fmt.Println("Pesanan sudah dibayar.")
+		return
+	}
+	if order.Voided {
+		ordersMutex.Unlock()
+		fmt.Println("Pesanan sudah dibatalkan.")
+		return
+	}
+	if order.Tab != "" {
+		ordersMutex.Unlock()
+		fmt.Printf("Pesanan ada di tab %s, bayar lewat menu Tab Pelanggan.\n", order.Tab)
+		return
+	}
+	applyTableCharges(order)
+	due := order.AmountDue()
+	balance := order.TabBalance()
+	fmt.Printf("Tagihan pesanan ID %d: %s%s\n", order.ID, formatMoney(due), describeTableCharges(order))
+	if paid := order.TabPaid(); paid > 0 {
+		fmt.Printf("Sudah dibayar: %s | Sisa: %s\n", describePayments(order.TabPayments), formatMoney(balance))
+	}
+	ordersMutex.Unlock()
+
+	// Tagihan boleh dibayar dengan beberapa metode, pembayaran diulang sampai sisa tagihan nol
+	for {
+		// Metode bayar dibaca tanpa memegang ordersMutex agar dapur tidak tertahan
+		method, amount, ok := readPaymentPart(reader, balance)
+		if !ok {
+			return
+		}
+		var tendered Money
+		if method == PaymentCash {
+			if tendered, ok = readCashTendered(reader, amount); !ok {
+				return
+			}
+		}
+
+		ordersMutex.Lock()
+		if order.Paid || order.Voided {
+			ordersMutex.Unlock()
+			fmt.Println("Pesanan sudah dibayar atau dibatalkan.")
+			return
+		}
+		applyTableCharges(order)
+		due = order.AmountDue()
+		if balance = order.TabBalance(); amount > balance {
+			ordersMutex.Unlock()
+			fmt.Printf("Sisa tagihan berubah menjadi %s. Ulangi pembayaran.\n", formatMoney(balance))
+			return
+		}
+
+		now := time.Now()
+		// Dibayar sekaligus dengan satu metode, tanpa catatan pembayaran sebagian
+		if amount == balance && len(order.TabPayments) == 0 {
+			order.Paid = true
+			order.PaidAt = now
+			order.PaymentMethod = method
+			order.CashTendered = tendered
+			publishOrderEvent(OrderEvent{Type: EventOrderPaid, OrderID: order.ID, PickupCode: order.PickupCode, Table: order.Table})
+			completeOrderIfDone(order)
+			fmt.Printf("Pesanan ID %d dibayar %s: %s\n", order.ID, method.Label(), formatMoney(due))
+			ordersMutex.Unlock()
+
+			if tendered > 0 {
+				displayCashChange(tendered, due)
+				logActivity(fmt.Sprintf("bayar tunai pesanan %d%s", id, describeTendered(tendered, due)))
+			}
+			break
+		}
+
+		order.TabPayments = append(order.TabPayments, TabPayment{Amount: amount, Method: method, Time: now})
+		balance -= amount
+		payments := describePayments(order.TabPayments)
+		if balance <= 0 {
+			order.Paid = true
+			order.PaidAt = now
+			order.PaymentMethod = combinedPaymentMethod(order.TabPayments)
+			publishOrderEvent(OrderEvent{Type: EventOrderPaid, OrderID: order.ID, PickupCode: order.PickupCode, Table: order.Table})
+			completeOrderIfDone(order)
+		}
+		ordersMutex.Unlock()
+
+		logActivity(fmt.Sprintf("bayar sebagian pesanan %d %s %s%s", id, method.Label(), formatMoney(amount), describeTendered(tendered, amount)))
+		if tendered > 0 {
+			displayCashChange(tendered, amount)
+		}
+		if balance <= 0 {
+			fmt.Printf("Pesanan ID %d lunas: %s\n", id, payments)
+			break
+		}
+		fmt.Printf("Pembayaran %s %s dicatat. Sisa tagihan: %s\n", method.Label(), formatMoney(amount), formatMoney(balance))
+	}
+
+	// Struk email hanya ditawarkan jika SMTP sudah dikonfigurasi
+	if smtpConfigured() {
+		fmt.Print("Email pelanggan untuk struk (kosongkan jika tidak perlu): ")
+		email, _ := reader.ReadString('\n')
+		if email = strings.TrimSpace(email); email != "" {
+			emailReceipt(id, email)
+		}
+	}
+}
+
+// Fungsi untuk menghitung jumlah yang harus dibayar setelah diskon dan pajak, ditambah
+// biaya cover dan top-up minimum belanja meja yang tidak dikenai pajak
+func (order *Order) AmountDue() Money {
+	return order.TotalPrice - order.Discount + order.Tax() + order.TableCharges()
+}
+
+// Fungsi untuk menghitung pajak dari tagihan setelah diskon sesuai tarif di konfigurasi
+func (order *Order) Tax() Money {
+	return (order.TotalPrice - order.Discount).MulRate(currentConfig().TaxRate / 100)
+}
+
+// Fungsi untuk membatalkan seluruh pesanan yang belum dibayar, perlu PIN admin
+func voidOrder(reader *bufio.Reader) {
+	id, ok := readOrderID(reader)
+	if !ok {
+		return
+	}
+
+	fmt.Print("Alasan pembatalan: ")
+	reason, _ := reader.ReadString('\n')
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		fmt.Println("Alasan pembatalan wajib diisi.")
+		return
+	}
+
+	if !requireAdminPIN(reader, fmt.Sprintf("void pesanan %d", id)) {
+		return
+	}
+
+	id, err := cancelOrder(id)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("Pesanan ID %d dibatalkan (alasan: %s).\n", id, reason)
+}
+
+// Fungsi untuk membatalkan pesanan yang belum dibayar, mengembalikan stok baris yang
+// belum dimasak dan mengurangi pendapatan yang sudah tercatat. Mengembalikan ID pesanan
+// yang dibatalkan (pesanan tujuan jika pesanan sudah digabung).
+func cancelOrder(id int) (int, error) {
+	ordersMutex.Lock()
+	order := followMergedOrder(findOrder(id))
+	if order == nil {
+		ordersMutex.Unlock()
+		return id, errors.New("Pesanan tidak ditemukan.")
+	}
+	id = order.ID
+	if order.Paid {
+		ordersMutex.Unlock()
+		return id, errors.New("Pesanan sudah dibayar, gunakan retur item.")
+	}
+	if order.Tab == "" && order.TabPaid() > 0 {
+		ordersMutex.Unlock()
+		return id, errors.New("Pesanan sudah dibayar sebagian, lunasi lalu gunakan retur item.")
+	}
+	if order.Voided {
+		ordersMutex.Unlock()
+		return id, errors.New("Pesanan sudah dibatalkan.")
+	}
+
+	// Baris yang belum dimasak mengembalikan stok, baris yang sudah masuk dapur
+	// mengurangi total pendapatan yang sudah tercatat
+	restock := map[string]int{}
+	var revenue Money
+	for i := range order.Lines {
+		line := &order.Lines[i]
+		switch line.Status {
+		case LineHeld, LineQueued:
+			restock[line.ItemName] += line.Quantity
+			for _, use := range modifierStock(line.Modifiers, line.Quantity) {
+				restock[use.Name] += use.Quantity
+			}
+		case LinePreparing, LineDone:
+			revenue += line.TotalPrice
+		}
+		line.Status = LineCancelled
+	}
+	// Pesanan yang belum dikonfirmasi dapur belum masuk pendapatan, begitu juga diskonnya
+	if order.PendingAck {
+		revenue = 0
+	} else {
+		revenue -= order.Discount
+	}
+	order.Voided = true
+	order.PendingAck = false
+	publishOrderEvent(OrderEvent{Type: EventOrderVoided, OrderID: order.ID, PickupCode: order.PickupCode, Table: order.Table})
+	ordersMutex.Unlock()
+
+	menuMutex.Lock()
+	for name, quantity := range restock {
+		if item := findMenuItem(name); item != nil {
+			if err := item.addStock(quantity, time.Time{}); err != nil {
+				fmt.Printf("Gagal mengembalikan stok %s: %v\n", name, err)
+				continue
+			}
+			recordMovement(item.Name, quantity, MovementReturn, orderReference(id)+": void")
+		}
+	}
+	menuMutex.Unlock()
+
+	totalMutex.Lock()
+	totalAllOrders -= revenue
+	totalMutex.Unlock()
+	return id, nil
+}
+
+// Fungsi untuk memberi diskon manual pada pesanan yang belum dibayar. Diskon di atas
+// batas persentase di konfigurasi perlu persetujuan manajer.
+func discountOrder(reader *bufio.Reader) {
+	id, ok := readOrderID(reader)
+	if !ok {
+		return
+	}
+
+	ordersMutex.Lock()
+	order := followMergedOrder(findOrder(id))
+	if order != nil {
+		id = order.ID
+	}
+	var total Money
+	var paid, voided bool
+	if order != nil {
+		total, paid, voided = order.TotalPrice, order.Paid, order.Voided
+	}
+	ordersMutex.Unlock()
+
+	if order == nil {
+		fmt.Println("Pesanan tidak ditemukan.")
+		return
+	}
+	if paid || voided {
+		fmt.Println("Diskon hanya bisa diberikan untuk pesanan yang belum dibayar.")
+		return
+	}
+
+	fmt.Printf("Total pesanan %s. Masukkan diskon (misal 10%% atau 5000): ", formatMoney(total))
+	input, _ := reader.ReadString('\n')
+	discount, ok := parseDiscount(strings.TrimSpace(input), total)
+	if !ok {
+		fmt.Println("Diskon tidak valid.")
+		return
+	}
+
+	var approval DiscountApproval
+	if discountNeedsApproval(discount, total) {
+		if approval, ok = requestDiscountApproval(reader, id, discount, total); !ok {
+			return
+		}
+	}
+
+	if _, err := applyDiscount(id, discount, approval); err != nil {
+		fmt.Printf("Diskon tidak bisa diberikan: %v.\n", err)
+		return
+	}
+	fmt.Printf("Diskon %s diberikan, total bayar pesanan ID %d menjadi %s.\n", formatMoney(discount), id, formatMoney(total-discount))
+}
+
+// Fungsi untuk membaca diskon dalam persen atau nominal
+func parseDiscount(input string, total Money) (Money, bool) {
+	if percent, ok := strings.CutSuffix(input, "%"); ok {
+		normalized, err := normalizeNumberInput(percent)
+		var value float64
+		if err == nil {
+			value, err = strconv.ParseFloat(normalized, 64)
+		}
+		if err != nil || value <= 0 || value > 100 {
+			return 0, false
+		}
+		return total.MulRate(value / 100), true
+	}
+
+	value, err := parseMoneyInput(input)
+	if err != nil || value <= 0 || value > total {
+		return 0, false
+	}
+	return value, true
+}
+
+// Fungsi untuk mencari pesanan terakhir sebuah meja, pemanggil harus memegang ordersMutex
+func findLastOrderForTable(table int) *Order {
+	for i := len(orders) - 1; i >= 0; i-- {
+		if orders[i].Table == table && orders[i].MergedInto == 0 {
+			return orders[i]
+		}
+	}
+	return nil
+}
+
+// Fungsi untuk meminta nomor meja dari kasir, "antar" berarti pesanan diantar
+func readTableNumber(reader *bufio.Reader) (table int, delivery bool, ok bool) {
+	fmt.Print("Masukkan nomor meja (kosongkan untuk bawa pulang, \"antar\" untuk diantar): ")
+	input, _ := reader.ReadString('\n')
+	if strings.EqualFold(strings.TrimSpace(input), string(OrderDelivery)) {
+		return 0, true, true
+	}
+	table, ok = parseTableNumber(input)
+	if !ok {
+		fmt.Println("Nomor meja harus berupa angka positif.")
+	}
+	return table, false, ok
+}
+
+// Metode pembayaran pesanan
+type PaymentMethod string
+
+const (
+	PaymentCash PaymentMethod = "cash"
+	PaymentCard PaymentMethod = "card"
+	PaymentQRIS PaymentMethod = "qris"
+	// Pesanan yang dilunasi dengan lebih dari satu metode, rinciannya ada di catatan pembayaran
+	PaymentSplit PaymentMethod = "split"
+)
+
+// Metode bayar berbahasa Indonesia dari data lama, juga dipakai saat kasir mengetik metode
+var legacyPaymentMethods = map[PaymentMethod]PaymentMethod{
+	"tunai": PaymentCash,
+	"kartu": PaymentCard,
+}
+
+// Fungsi untuk menampilkan metode bayar di layar
+func (m PaymentMethod) Label() string {
+	for legacy, method := range legacyPaymentMethods {
+		if method == m {
+			return string(legacy)
+		}
+	}
+	return string(m)
+}
+
+// Fungsi untuk membaca metode bayar dari JSON, nilai lama ikut diterjemahkan
+func (m *PaymentMethod) UnmarshalJSON(data []byte) error {
+	return unmarshalLegacy(data, m, legacyPaymentMethods)
+}
+
+// Fungsi untuk membaca metode pembayaran, input kosong berarti tunai
+func readPaymentMethod(reader *bufio.Reader) (PaymentMethod, bool) {
+	fmt.Print("Metode bayar (tunai/kartu/qris, kosongkan untuk tunai): ")
+	input, _ := reader.ReadString('\n')
+	method, ok := parsePaymentMethod(input)
+	if !ok {
+		fmt.Println("Metode bayar tidak dikenal.")
+	}
+	return method, ok
+}
+
+// Fungsi untuk membaca nama metode pembayaran
+func parsePaymentMethod(input string) (PaymentMethod, bool) {
+	switch method := normalizeLegacy(PaymentMethod(strings.ToLower(strings.TrimSpace(input))), legacyPaymentMethods); method {
+	case "":
+		return PaymentCash, true
+	case PaymentCash, PaymentCard, PaymentQRIS:
+		return method, true
+	}
+	return "", false
+}
+
+// Fungsi untuk mengambil metode pembayaran pesanan, data lama tanpa metode dianggap tunai
+func (order *Order) Payment() PaymentMethod {
+	if order.PaymentMethod == "" {
+		return PaymentCash
+	}
+	return order.PaymentMethod
+}
+
+// Fungsi untuk membaca jumlah tamu pesanan makan di tempat, pesanan bawa pulang tidak ditanya
+func readGuestCount(reader *bufio.Reader, table int) (int, bool) {
+	if table == 0 {
+		return 0, true
+	}
+
+	fmt.Print("Jumlah tamu (kosongkan jika tidak dicatat): ")
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return 0, true
+	}
+	guests, err := strconv.Atoi(input)
+	if err != nil || guests <= 0 {
+		fmt.Println("Jumlah tamu harus berupa angka positif.")
+		return 0, false
+	}
+	return guests, true
+}
+
+// Fungsi untuk membaca nomor meja, input kosong berarti pesanan bawa pulang (meja 0)
+func parseTableNumber(input string) (int, bool) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return 0, true
+	}
+
+	table, err := strconv.Atoi(input)
+	if err != nil || table <= 0 {
+		return 0, false
+	}
+	return table, true
+}
+
+// Fungsi untuk menampilkan keterangan meja pada daftar pesanan
+func describeTable(table int, delivery bool) string {
+	if delivery {
+		return " (antar)"
+	}
+	if table == 0 {
+		return " (bawa pulang)"
+	}
+	return fmt.Sprintf(" (meja %d)", table)
+}
+
+// Fungsi untuk mengulang pesanan sebelumnya sebagai pesanan baru dengan stok dan harga
+// yang berlaku saat ini. Seperti pesanan baru, kolom tambahan ditanyakan lagi dan stok
+// baru dikurangi setelah kasir mengonfirmasi ringkasan pesanan.
+func duplicateOrder(reader *bufio.Reader, orderID int) *Order {
+	fmt.Print("Masukkan ID pesanan, atau \"meja <nomor>\" untuk pesanan terakhir meja: ")
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(input)
+
+	ordersMutex.Lock()
+	source, err := findOrderRef(input)
+	if err != nil {
+		ordersMutex.Unlock()
+		fmt.Println(err)
+		return nil
+	}
+	source = followMergedOrder(source)
+
+	// Salin baris agar pesanan lama tidak ikut berubah saat dapur memproses
+	sourceLines := append([]OrderLine(nil), source.Lines...)
+	table := source.Table
+	guests := source.Guests
+	delivery := source.Delivery
+	priceList := source.PriceList
+	sourceID := source.ID
+	ordersMutex.Unlock()
+
+	order := &Order{ID: orderID, Table: table, Guests: guests, Delivery: delivery}
+	var ok bool
+	if order.Fields, ok = readOrderFields(reader, order.Type()); !ok {
+		return nil
+	}
+
+	// PIN manajer untuk baris di atas batas diminta sebelum menuMutex diambil, supaya
+	// menu tidak terkunci selama kasir mengetik
+	approved := make([]bool, len(sourceLines))
+	for i, line := range sourceLines {
+		if approved[i] = approveLineQuantity(reader, line.ItemName, line.Quantity); !approved[i] {
+			fmt.Printf("%s x%d dilewati.\n", line.ItemName, line.Quantity)
+		}
+	}
+
+	menuMutex.Lock()
+	// Daftar harga yang sudah dihapus dari konfigurasi kembali ke harga menu
+	if name, err := checkPriceList(priceList, order.Type()); err == nil {
+		order.PriceList = name
+	}
+	for i, line := range sourceLines {
+		if !approved[i] {
+			continue
+		}
+		selectedItem := findMenuItem(line.ItemName)
+		if selectedItem == nil {
+			fmt.Printf("%s sudah tidak ada di menu, dilewati.\n", line.ItemName)
+			continue
+		}
+		if err := checkOrderType(selectedItem, order.Type()); err != nil {
+			fmt.Println(err, "Dilewati.")
+			continue
+		}
+		if err := checkAvailable(selectedItem, time.Now()); err != nil {
+			fmt.Println(err, "Dilewati.")
+			continue
+		}
+		if line.Quantity > selectedItem.Quantity {
+			fmt.Printf("Stok %s tidak cukup (tersisa %d), dilewati.\n", selectedItem.Name, selectedItem.Quantity)
+			continue
+		}
+
+		// Tambahan diambil ulang dari konfigurasi agar harga dan bahannya yang terbaru
+		var names []string
+		for _, modifier := range line.Modifiers {
+			names = append(names, modifier.Name)
+		}
+		modifiers, err := resolveModifiers(names)
+		if err == nil {
+			err = checkModifierStock(modifiers, line.Quantity)
+		}
+		if err != nil {
+			fmt.Println(err, "Dilewati.")
+			continue
+		}
+		price := channelPrice(selectedItem, order.PriceList) + modifiersPrice(modifiers)
+		newLine := OrderLine{
+			No:         len(order.Lines) + 1,
+			ItemName:   selectedItem.Name,
+			Quantity:   line.Quantity,
+			Price:      price,
+			TotalPrice: price.Times(line.Quantity),
+			Status:     LineQueued,
+			Station:    selectedItem.Station,
+			Modifiers:  modifiers,
+		}
+		order.Lines = append(order.Lines, newLine)
+		order.TotalPrice += newLine.TotalPrice
+	}
+	menuMutex.Unlock()
+
+	if len(order.Lines) == 0 {
+		fmt.Println("Tidak ada item yang bisa dipesan ulang.")
+		return nil
+	}
+
+	fmt.Printf("Pesanan ID %d disalin dari pesanan ID %d.\n", order.ID, sourceID)
+	if !confirmOrder(reader, order, true) {
+		return nil
+	}
+	return order
+}
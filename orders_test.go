@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Fungsi untuk memakai daftar pesanan, menu dan total pendapatan tertentu selama satu test
+func useOrders(t *testing.T, items []MenuItem, list []*Order, total Money) {
+	t.Helper()
+	menuMutex.Lock()
+	previousMenu := menu
+	menu = items
+	menuMutex.Unlock()
+	ordersMutex.Lock()
+	previousOrders, previousLastID := orders, lastOrderID
+	orders = list
+	for _, order := range list {
+		lastOrderID = max(lastOrderID, order.ID)
+	}
+	ordersMutex.Unlock()
+	totalMutex.Lock()
+	previousTotal := totalAllOrders
+	totalAllOrders = total
+	totalMutex.Unlock()
+
+	t.Cleanup(func() {
+		menuMutex.Lock()
+		menu = previousMenu
+		menuMutex.Unlock()
+		ordersMutex.Lock()
+		orders, lastOrderID = previousOrders, previousLastID
+		ordersMutex.Unlock()
+		totalMutex.Lock()
+		totalAllOrders = previousTotal
+		totalMutex.Unlock()
+	})
+}
+
+func TestCancelDiscountedOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		pending bool
+		status  LineStatus
+		total   Money
+	}{
+		// Pendapatan dan diskon pesanan yang belum dikonfirmasi dapur belum pernah tercatat
+		{"belum dikonfirmasi", true, LineQueued, 0},
+		// Pendapatan yang sudah tercatat dikurangi diskon ditarik kembali seluruhnya
+		{"sudah dimasak", false, LineDone, 30000 * moneyScale},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price := Money(15000) * moneyScale
+			order := &Order{
+				ID:         1,
+				Lines:      []OrderLine{{No: 1, ItemName: "Nasi Goreng", Quantity: 2, Price: price, TotalPrice: price.Times(2), Status: tt.status}},
+				TotalPrice: price.Times(2),
+				CreatedAt:  time.Now(),
+				PendingAck: tt.pending,
+			}
+			if !tt.pending {
+				order.AcknowledgedAt = order.CreatedAt
+			}
+			useOrders(t, []MenuItem{{Name: "Nasi Goreng", Price: price, Quantity: 8}}, []*Order{order}, tt.total)
+
+			if _, err := applyDiscount(1, 5000*moneyScale, DiscountApproval{}); err != nil {
+				t.Fatalf("applyDiscount: %v", err)
+			}
+			if _, err := cancelOrder(1); err != nil {
+				t.Fatalf("cancelOrder: %v", err)
+			}
+			if totalAllOrders != 0 {
+				t.Errorf("total semua pesanan setelah void = %s, ingin %s", formatMoney(totalAllOrders), formatMoney(0))
+			}
+			if !order.Voided || order.PendingAck {
+				t.Errorf("pesanan Voided = %v, PendingAck = %v; ingin true, false", order.Voided, order.PendingAck)
+			}
+		})
+	}
+}
+
+func TestDuplicateOrderAsksFieldsAndConfirmation(t *testing.T) {
+	useJSONStorage(t)
+	configMutex.Lock()
+	config.OrderFields = []OrderFieldConfig{{Key: "nama", Label: "Nama pelanggan", Required: true}}
+	configMutex.Unlock()
+
+	price := Money(15000) * moneyScale
+	source := &Order{
+		ID:         1,
+		Lines:      []OrderLine{{No: 1, ItemName: "Nasi Goreng", Quantity: 2, Price: price, TotalPrice: price.Times(2), Status: LineDone}},
+		TotalPrice: price.Times(2),
+		CreatedAt:  time.Now(),
+	}
+	useOrders(t, []MenuItem{{Name: "Nasi Goreng", Price: price, Quantity: 8, Station: StationWok}}, []*Order{source}, 0)
+
+	tests := []struct {
+		name  string
+		input string
+		saved bool
+		stock int
+	}{
+		// Kolom wajib yang dikosongkan membatalkan salinan sebelum stok disentuh
+		{"kolom wajib kosong", "1\n\n", false, 8},
+		{"dibatalkan di ringkasan", "1\nBudi\nn\n", false, 8},
+		{"dikonfirmasi", "1\nBudi\ny\n", true, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := duplicateOrder(bufio.NewReader(strings.NewReader(tt.input)), 2)
+			if (order != nil) != tt.saved {
+				t.Fatalf("duplicateOrder = %v, ingin pesanan dibuat %v", order, tt.saved)
+			}
+			if order != nil && order.Fields["nama"] != "Budi" {
+				t.Errorf("kolom nama = %q, ingin %q", order.Fields["nama"], "Budi")
+			}
+			if stock := menuItemQuantity("Nasi Goreng"); stock != tt.stock {
+				t.Errorf("stok Nasi Goreng = %d, ingin %d", stock, tt.stock)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"slices"
+	"strings"
+)
+
+// Saluran asal pesanan, dipakai untuk memecah laporan per sumber
+type OrderSource string
+
+const (
+	// Kasir lewat menu CLI, pesanan cepat, skrip atau terminal daemon
+	SourceCounter OrderSource = "counter"
+	// Kiosk pesan mandiri yang memakai server API
+	SourceKiosk OrderSource = "kiosk"
+	// Aplikasi lain lewat server API
+	SourceAPI OrderSource = "api"
+	// Bot chat seperti Telegram yang memakai server API
+	SourceBot OrderSource = "bot"
+)
+
+// Semua sumber pesanan yang dikenal, dipakai untuk validasi input
+var orderSources = []OrderSource{SourceCounter, SourceKiosk, SourceAPI, SourceBot}
+
+// Fungsi untuk menampilkan nama sumber pesanan yang mudah dibaca
+func (s OrderSource) Label() string {
+	switch s {
+	case SourceCounter:
+		return "kasir"
+	case SourceAPI:
+		return "API"
+	}
+	return string(s)
+}
+
+// Fungsi untuk membaca sumber pesanan dari input, nama tampilan seperti "kasir" juga diterima
+func parseOrderSource(input string) (OrderSource, bool) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	for _, source := range orderSources {
+		if input == string(source) || input == strings.ToLower(source.Label()) {
+			return source, true
+		}
+	}
+	return "", false
+}
+
+// Fungsi untuk menentukan sumber pesanan. Pesanan lama tanpa sumber dianggap dari API
+// jika dibuat klien API dan dari kasir jika tidak.
+func (order *Order) Channel() OrderSource {
+	switch {
+	case order.Source != "":
+		return order.Source
+	case strings.HasPrefix(order.Cashier, "api:"):
+		return SourceAPI
+	default:
+		return SourceCounter
+	}
+}
+
+// Fungsi untuk menentukan sumber pesanan dari klien API sesuai api_client_sources,
+// klien yang tidak diatur dianggap API biasa
+func apiOrderSource(client string) OrderSource {
+	if source, ok := currentConfig().APIClientSources[client]; ok {
+		return source
+	}
+	return SourceAPI
+}
+
+// Fungsi untuk memeriksa sumber klien API di konfigurasi
+func validOrderSource(source OrderSource) bool {
+	return slices.Contains(orderSources, source) && source != SourceCounter
+}
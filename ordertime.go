@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// Fungsi untuk menampilkan jarak waktu dari sekarang, misalnya "3 menit lalu"
+func formatRelative(t, now time.Time) string {
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		return "baru saja"
+	case d < time.Hour:
+		return fmt.Sprintf("%d menit lalu", int(d/time.Minute))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%d jam lalu", int(d/time.Hour))
+	default:
+		return fmt.Sprintf("%d hari lalu", int(d/(24*time.Hour)))
+	}
+}
+
+// Fungsi untuk menampilkan waktu absolut dan relatif, misalnya "09:12 (3 menit lalu)".
+// Tanggal hanya ditulis jika bukan hari ini.
+func formatTimestamp(t, now time.Time) string {
+	local, today := t.Local(), now.Local()
+	absolute := local.Format("15:04")
+	if local.YearDay() != today.YearDay() || local.Year() != today.Year() {
+		absolute = formatDateTime(t)
+	}
+	return fmt.Sprintf("%s (%s)", absolute, formatRelative(t, now))
+}
+
+// Fungsi untuk mengambil waktu perubahan terakhir pesanan: dibuat, dikonfirmasi dapur,
+// item mulai diproses atau siap, pembayaran tab, atau lunas
+func (order *Order) UpdatedAt() time.Time {
+	latest := order.CreatedAt
+	times := []time.Time{order.AcknowledgedAt, order.PaidAt}
+	for _, line := range order.Lines {
+		times = append(times, line.StartedAt, line.ReadyAt)
+	}
+	for _, payment := range order.TabPayments {
+		times = append(times, payment.Time)
+	}
+	for _, t := range times {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
+
+// Fungsi untuk menghitung lama proses pesanan dari dibuat sampai item terakhir siap,
+// atau sampai sekarang jika masih ada item di dapur. Mengembalikan false untuk
+// pesanan terjadwal yang belum dilepas ke dapur.
+func (order *Order) ProcessingTime(now time.Time) (time.Duration, bool, bool) {
+	finished := true
+	var readyAt time.Time
+	for _, line := range order.Lines {
+		switch line.Status {
+		case LineScheduled:
+			return 0, false, false
+		case LineDone:
+			if line.ReadyAt.After(readyAt) {
+				readyAt = line.ReadyAt
+			}
+		case LineCancelled:
+		default:
+			finished = false
+		}
+	}
+	if finished && readyAt.IsZero() {
+		return 0, false, false
+	}
+	if !finished {
+		readyAt = now
+	}
+	return readyAt.Sub(order.CreatedAt), finished, true
+}
+
+// Fungsi untuk meringkas waktu pesanan di daftar pesanan: waktu dibuat, perubahan
+// terakhir dan lama proses. Pemanggil harus memegang ordersMutex.
+func describeOrderTimes(order *Order, now time.Time) string {
+	if order.CreatedAt.IsZero() {
+		return ""
+	}
+	text := " | Dibuat: " + formatTimestamp(order.CreatedAt, now)
+	if updated := order.UpdatedAt(); updated.After(order.CreatedAt) {
+		text += " | Diperbarui: " + formatTimestamp(updated, now)
+	}
+	if duration, finished, ok := order.ProcessingTime(now); ok {
+		text += " | Proses: " + formatPrepTime(duration)
+		if !finished {
+			text += " (berjalan)"
+		}
+	}
+	return text
+}
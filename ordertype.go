@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Jenis pesanan, menentukan item mana yang boleh dipesan
+type OrderType string
+
+const (
+	OrderDineIn   OrderType = "dine_in"
+	OrderTakeaway OrderType = "takeaway"
+	OrderDelivery OrderType = "delivery"
+)
+
+// Jenis pesanan berbahasa Indonesia dari data lama, juga dipakai saat kasir mengetik jenis pesanan
+var legacyOrderTypes = map[OrderType]OrderType{
+	"makan_di_tempat": OrderDineIn,
+	"bawa_pulang":     OrderTakeaway,
+	"antar":           OrderDelivery,
+}
+
+// Fungsi untuk membaca jenis pesanan dari JSON, nilai lama ikut diterjemahkan
+func (t *OrderType) UnmarshalJSON(data []byte) error {
+	return unmarshalLegacy(data, t, legacyOrderTypes)
+}
+
+// Semua jenis pesanan yang dikenal, dipakai untuk validasi input
+var orderTypes = []OrderType{OrderDineIn, OrderTakeaway, OrderDelivery}
+
+// Fungsi untuk menentukan jenis pesanan dari nomor meja dan tanda antar
+func (order *Order) Type() OrderType {
+	switch {
+	case order.Delivery:
+		return OrderDelivery
+	case order.Table > 0:
+		return OrderDineIn
+	default:
+		return OrderTakeaway
+	}
+}
+
+// Fungsi untuk menampilkan nama jenis pesanan yang mudah dibaca
+func (t OrderType) Label() string {
+	switch t {
+	case OrderDineIn:
+		return "makan di tempat"
+	case OrderTakeaway:
+		return "bawa pulang"
+	case OrderDelivery:
+		return "antar"
+	}
+	return string(t)
+}
+
+// Fungsi untuk membaca daftar jenis pesanan yang dipisahkan koma
+func parseOrderTypes(input string) ([]OrderType, bool) {
+	var result []OrderType
+	for _, part := range strings.Split(input, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+		orderType := normalizeLegacy(OrderType(strings.ReplaceAll(part, " ", "_")), legacyOrderTypes)
+		if !slices.Contains(orderTypes, orderType) {
+			return nil, false
+		}
+		if !slices.Contains(result, orderType) {
+			result = append(result, orderType)
+		}
+	}
+	return result, true
+}
+
+// Fungsi untuk menampilkan daftar jenis pesanan yang dibatasi
+func describeOrderTypes(types []OrderType) string {
+	if len(types) == 0 {
+		return "-"
+	}
+	labels := make([]string, len(types))
+	for i, orderType := range types {
+		labels[i] = orderType.Label()
+	}
+	return strings.Join(labels, ", ")
+}
+
+// Fungsi untuk menampilkan pembatasan item pada daftar menu, kosong jika tidak dibatasi
+func describeRestriction(types []OrderType) string {
+	if len(types) == 0 {
+		return ""
+	}
+	return " | Tidak untuk: " + describeOrderTypes(types)
+}
+
+// Fungsi untuk memeriksa apakah item boleh masuk pesanan jenis tertentu. Varian
+// ikut dibatasi oleh item induknya. Pemanggil harus memegang menuMutex.
+func checkOrderType(item *MenuItem, orderType OrderType) error {
+	restricted := item.Restricted
+	if parent := variantParent(item); parent != nil {
+		restricted = append(slices.Clone(parent.Restricted), restricted...)
+	}
+	if slices.Contains(restricted, orderType) {
+		return fmt.Errorf("%s tidak tersedia untuk pesanan %s.", item.Name, orderType.Label())
+	}
+	return nil
+}
+
+// Fungsi untuk mencari item induk sebuah varian, pemanggil harus memegang menuMutex
+func variantParent(variant *MenuItem) *MenuItem {
+	for i := range menu {
+		for j := range menu[i].Variants {
+			if &menu[i].Variants[j] == variant {
+				return &menu[i]
+			}
+		}
+	}
+	return nil
+}
+
+// Fungsi untuk membaca perubahan pembatasan jenis pesanan saat mengubah item menu.
+// Input kosong berarti tetap, "-" menghapus semua pembatasan.
+func readRestrictedOrderTypes(reader *bufio.Reader, current []OrderType) ([]OrderType, bool) {
+	fmt.Printf("Tidak tersedia untuk (makan_di_tempat/bawa_pulang/antar dipisah koma, - untuk hapus, kosongkan untuk tetap %s): ", describeOrderTypes(current))
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(input)
+	switch input {
+	case "":
+		return current, true
+	case "-":
+		return nil, true
+	}
+
+	types, ok := parseOrderTypes(input)
+	if !ok {
+		fmt.Println("Jenis pesanan tidak dikenal.")
+	}
+	return types, ok
+}
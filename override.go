@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Struct untuk satu baris pesanan yang harganya diubah manual, dipakai laporan harian
+type priceOverride struct {
+	OrderID int
+	Cashier string
+	Line    OrderLine
+}
+
+// Fungsi untuk memeriksa apakah harga baris diubah manual saat pesanan dibuat
+func (line OrderLine) PriceOverridden() bool {
+	return line.PriceReason != ""
+}
+
+// Fungsi untuk menanyakan harga khusus satu baris, misalnya harga nego atau kompensasi.
+// Harga khusus wajib disertai alasan dan PIN manajer. Mengembalikan harga yang dipakai,
+// alasannya (kosong jika memakai harga menu) dan false jika dibatalkan. listPrice adalah
+// harga item di daftar harga pesanan.
+func readPriceOverride(reader *bufio.Reader, name string, listPrice Money, quantity int) (Money, string, bool) {
+	fmt.Printf("Harga khusus per item (kosongkan untuk %s): ", formatMoney(listPrice))
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return listPrice, "", true
+	}
+
+	price, err := parseMoneyInput(input)
+	if err != nil || price < 0 {
+		fmt.Println("Harga tidak valid.")
+		return 0, "", false
+	}
+	if price == listPrice {
+		return listPrice, "", true
+	}
+
+	fmt.Print("Alasan harga khusus (misal harga nego, kompensasi): ")
+	reason, _ := reader.ReadString('\n')
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		fmt.Println("Alasan harga khusus wajib diisi.")
+		return 0, "", false
+	}
+
+	action := fmt.Sprintf("harga khusus %s x%d %s -> %s", name, quantity, formatMoney(listPrice), formatMoney(price))
+	if !requireAdminPIN(reader, action) {
+		return 0, "", false
+	}
+	return price, reason, true
+}
+
+// Fungsi untuk mencatat baris berharga khusus ke log aktivitas setelah pesanan
+// mendapat ID. Pemanggil harus memegang ordersMutex.
+func logPriceOverrides(order *Order) {
+	for _, line := range order.Lines {
+		if !line.PriceOverridden() {
+			continue
+		}
+		logActivity(fmt.Sprintf("harga khusus pesanan %d: %s x%d %s -> %s, alasan: %s",
+			order.ID, line.ItemName, line.Quantity, formatMoney(line.ListPrice), formatMoney(line.Price), line.PriceReason))
+	}
+}
+
+// Fungsi untuk mengumpulkan baris berharga khusus dari pesanan yang dibuat dalam
+// rentang waktu, tanpa pesanan yang dibatalkan
+func priceOverrides(from, to time.Time) []priceOverride {
+	ordersMutex.Lock()
+	defer ordersMutex.Unlock()
+
+	var result []priceOverride
+	for _, order := range orders {
+		if order.Voided || order.CreatedAt.Before(from) || !order.CreatedAt.Before(to) {
+			continue
+		}
+		for _, line := range order.Lines {
+			if line.PriceOverridden() {
+				result = append(result, priceOverride{OrderID: order.ID, Cashier: order.Cashier, Line: line})
+			}
+		}
+	}
+	return result
+}
+
+// Fungsi untuk menulis bagian harga khusus di laporan harian beserta total selisihnya
+func formatPriceOverrides(l *textLayout, overrides []priceOverride) {
+	if len(overrides) == 0 {
+		return
+	}
+
+	var difference Money
+	l.Blank()
+	l.Line("Harga Khusus Manual:")
+	for _, override := range overrides {
+		line := override.Line
+		change := (line.Price - line.ListPrice).Times(line.Quantity)
+		difference += change
+		l.Line("  Pesanan ID %d: %s x%d, %s -> %s (selisih %s), alasan: %s, kasir: %s",
+			override.OrderID, line.ItemName, line.Quantity, formatMoney(line.ListPrice), formatMoney(line.Price),
+			formatMoneyChange(change), line.PriceReason, override.Cashier)
+	}
+	l.Pair("  Total selisih", formatMoneyChange(difference))
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Nama file pesanan yang diparkir, disimpan di folder data terpisah dari pesanan
+const parkedOrdersFile = "parked.json"
+
+// Struct untuk pesanan yang belum selesai diisi dan diparkir kasir, misalnya karena
+// pelanggan pergi sebentar. Stok belum dikurangi dan pesanan belum punya ID.
+type ParkedOrder struct {
+	Slot     int       `json:"slot"`
+	Order    Order     `json:"order"`
+	Cashier  string    `json:"cashier"`
+	ParkedAt time.Time `json:"parked_at"`
+}
+
+// Pesanan yang diparkir, dibaca dari file saat pertama kali dipakai
+var parkedOrders []ParkedOrder
+var parkedLoaded bool
+var parkedMutex sync.Mutex
+
+// Fungsi untuk membaca pesanan parkir dari file jika belum dibaca. Pada storage memory
+// pesanan parkir hanya ada selama program berjalan. Pemanggil harus memegang parkedMutex.
+func ensureParkedLoaded() {
+	if parkedLoaded {
+		return
+	}
+	parkedLoaded = true
+	if currentConfig().Storage == StorageMemory {
+		return
+	}
+	if _, err := readJSONFile(filepath.Join(currentConfig().DataDir, parkedOrdersFile), &parkedOrders); err != nil {
+		fmt.Println("Gagal membaca pesanan parkir:", err)
+	}
+}
+
+// Fungsi untuk menyimpan pesanan parkir ke file, pemanggil harus memegang parkedMutex
+func saveParkedOrders() {
+	if currentConfig().Storage == StorageMemory {
+		return
+	}
+	if err := writeJSONFile(filepath.Join(currentConfig().DataDir, parkedOrdersFile), parkedOrders); err != nil {
+		fmt.Println("Gagal menyimpan pesanan parkir:", err)
+	}
+}
+
+// Fungsi untuk mencari slot kosong terkecil, pemanggil harus memegang parkedMutex
+func freeParkingSlot() int {
+	slot := 1
+	for {
+		used := false
+		for _, parked := range parkedOrders {
+			if parked.Slot == slot {
+				used = true
+				break
+			}
+		}
+		if !used {
+			return slot
+		}
+		slot++
+	}
+}
+
+// Fungsi untuk memarkir pesanan yang sedang diisi beserta baris yang sudah diketik.
+// ID pesanan dilepas dan diganti ID baru saat pesanan dipanggil kembali.
+func parkOrder(order *Order) bool {
+	if dryRunActive() {
+		fmt.Println("Pesanan tidak bisa diparkir saat dry-run.")
+		return false
+	}
+
+	activityMutex.Lock()
+	cashier := currentCashier
+	activityMutex.Unlock()
+
+	parked := ParkedOrder{Order: copyOrder(*order), Cashier: cashier, ParkedAt: time.Now()}
+	parked.Order.ID = 0
+
+	parkedMutex.Lock()
+	ensureParkedLoaded()
+	parked.Slot = freeParkingSlot()
+	parkedOrders = append(parkedOrders, parked)
+	saveParkedOrders()
+	parkedMutex.Unlock()
+
+	logActivity(fmt.Sprintf("parkir pesanan slot %d: %s", parked.Slot, describeLines(parked.Order.Lines)))
+	fmt.Printf("Pesanan diparkir di slot %d, panggil kembali lewat opsi Panggil Pesanan Parkir.\n", parked.Slot)
+	return true
+}
+
+// Fungsi untuk menampilkan pesanan yang sedang diparkir
+func displayParkedOrders() bool {
+	parkedMutex.Lock()
+	defer parkedMutex.Unlock()
+
+	ensureParkedLoaded()
+	if len(parkedOrders) == 0 {
+		fmt.Println("Tidak ada pesanan yang diparkir.")
+		return false
+	}
+	fmt.Println("\n===== Pesanan Parkir =====")
+	for _, parked := range parkedOrders {
+		order := parked.Order
+		fmt.Printf("Slot %d%s | %s | %s | Kasir: %s | Diparkir: %s\n", parked.Slot, describeTable(order.Table, order.Delivery), describeLines(order.Lines),
+			formatMoney(order.TotalPrice), parked.Cashier, formatDateTime(parked.ParkedAt))
+	}
+	return true
+}
+
+// Fungsi untuk mengambil pesanan dari slot parkir dan menghapusnya dari daftar parkir
+func takeParkedOrder(slot int) (Order, bool) {
+	parkedMutex.Lock()
+	defer parkedMutex.Unlock()
+
+	ensureParkedLoaded()
+	for i, parked := range parkedOrders {
+		if parked.Slot == slot {
+			parkedOrders = append(parkedOrders[:i], parkedOrders[i+1:]...)
+			saveParkedOrders()
+			return parked.Order, true
+		}
+	}
+	return Order{}, false
+}
+
+// Fungsi untuk memanggil kembali pesanan parkir dengan nomor slot. Pesanan mendapat ID
+// baru lalu masuk ke ringkasan konfirmasi sehingga kasir bisa menambah item, menyimpan,
+// memarkir lagi atau membatalkannya. Argumen boleh berisi nomor slot langsung.
+func recallParkedOrder(reader *bufio.Reader, args string) *Order {
+	input := args
+	if input == "" {
+		if !displayParkedOrders() {
+			return nil
+		}
+		fmt.Print("Masukkan nomor slot: ")
+		input, _ = reader.ReadString('\n')
+	}
+	slot, err := strconv.Atoi(strings.TrimSpace(input))
+	if err != nil {
+		fmt.Println("Nomor slot harus berupa angka.")
+		return nil
+	}
+
+	if err := checkKitchenCapacity(); err != nil {
+		fmt.Printf("Pesanan tidak bisa dibuat: %v.\n", err)
+		return nil
+	}
+	orderID, ok := newOrderID()
+	if !ok {
+		return nil
+	}
+	parked, ok := takeParkedOrder(slot)
+	if !ok {
+		fmt.Printf("Slot %d kosong.\n", slot)
+		return nil
+	}
+
+	order := &parked
+	order.ID = orderID
+	logActivity(fmt.Sprintf("panggil pesanan parkir slot %d sebagai pesanan ID %d", slot, orderID))
+	if !confirmOrder(reader, order, true) {
+		return nil
+	}
+	return order
+}
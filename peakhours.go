@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Lebar slot waktu pada laporan jam sibuk
+const peakSlotMinutes = 30
+
+// Slot dengan jumlah pesanan minimal persentase ini dari slot tersibuk ditandai sebagai jam sibuk
+const peakThreshold = 0.8
+
+// Ringkasan pesanan pada satu slot waktu
+type peakSlot struct {
+	Orders  int
+	Revenue Money
+}
+
+// Fungsi untuk menampilkan jumlah pesanan dan pendapatan per slot 30 menit
+// dalam rentang tanggal, slot tersibuk ditandai untuk perencanaan jadwal staf
+func displayPeakHours(reader *bufio.Reader) {
+	from, ok := readOptionalDate(reader, "Dari tanggal (YYYY-MM-DD, kosongkan untuk semua): ")
+	if !ok {
+		return
+	}
+	to, ok := readOptionalDate(reader, "Sampai tanggal (YYYY-MM-DD, kosongkan untuk semua): ")
+	if !ok {
+		return
+	}
+	if !to.IsZero() {
+		to = to.AddDate(0, 0, 1)
+	}
+	fmt.Print("Sertakan pesanan arsip? (y/n): ")
+	archiveInput, _ := reader.ReadString('\n')
+
+	reportOrders, err := ordersForReport(strings.EqualFold(strings.TrimSpace(archiveInput), "y"), from, to)
+	if err != nil {
+		fmt.Println("Gagal membaca arsip pesanan:", err)
+		return
+	}
+
+	slots := make([]peakSlot, 24*60/peakSlotMinutes)
+	days := map[string]bool{}
+	for i := range reportOrders {
+		order := &reportOrders[i]
+		if order.Voided || order.CreatedAt.IsZero() {
+			continue
+		}
+		created := order.CreatedAt.Local()
+		slot := (created.Hour()*60 + created.Minute()) / peakSlotMinutes
+		slots[slot].Orders++
+		slots[slot].Revenue += orderRevenue(order)
+		days[created.Format("2006-01-02")] = true
+	}
+
+	fmt.Println("\n===== Laporan Jam Sibuk =====")
+	busiest := 0
+	for _, slot := range slots {
+		busiest = max(busiest, slot.Orders)
+	}
+	if busiest == 0 {
+		fmt.Println("Tidak ada pesanan pada periode ini.")
+		return
+	}
+
+	fmt.Printf("Jumlah hari dengan pesanan: %d\n", len(days))
+	for i, slot := range slots {
+		if slot.Orders == 0 {
+			continue
+		}
+		start := time.Duration(i*peakSlotMinutes) * time.Minute
+		end := start + peakSlotMinutes*time.Minute
+		marker := ""
+		if float64(slot.Orders) >= peakThreshold*float64(busiest) {
+			marker = " << JAM SIBUK"
+		}
+		bar := strings.Repeat("#", slot.Orders*20/busiest)
+		fmt.Printf("%s-%s | Pesanan: %3d | Pendapatan: %s | Rata-rata/hari: %s | %s%s\n",
+			formatClock(start), formatClock(end), slot.Orders, formatMoney(slot.Revenue),
+			formatNumber(float64(slot.Orders)/float64(len(days)), 1), bar, marker)
+	}
+}
+
+// Fungsi untuk menampilkan durasi sejak tengah malam sebagai jam HH:MM
+func formatClock(d time.Duration) string {
+	minutes := int(d.Minutes())
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Nama file riwayat waktu persiapan di folder data, tetap ada walaupun pesanan diarsipkan
+const prepTimesFile = "prep_times.json"
+
+// Waktu persiapan di atas batas ini dianggap item lupa di-bump dan tidak dipelajari
+const maxPrepSample = 2 * time.Hour
+
+// Struct untuk waktu persiapan terakhir satu item di satu stasiun
+type PrepTimeRecord struct {
+	ItemName string          `json:"item"`
+	Station  Station         `json:"station"`
+	Samples  []time.Duration `json:"samples"`
+}
+
+// Fungsi untuk menghitung rata-rata waktu persiapan yang tersimpan
+func (record PrepTimeRecord) Average() time.Duration {
+	if len(record.Samples) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, sample := range record.Samples {
+		total += sample
+	}
+	return total / time.Duration(len(record.Samples))
+}
+
+// Riwayat waktu persiapan per item per stasiun, dibaca dari file saat pertama kali dipakai
+var prepTimes []PrepTimeRecord
+var prepTimesLoaded bool
+var prepTimesMutex sync.Mutex
+
+// Fungsi untuk membaca riwayat waktu persiapan dari file jika belum dibaca. Pada storage
+// memory riwayat hanya ada selama program berjalan. Pemanggil harus memegang prepTimesMutex.
+func ensurePrepTimesLoaded() {
+	if prepTimesLoaded {
+		return
+	}
+	prepTimesLoaded = true
+	if currentConfig().Storage == StorageMemory {
+		return
+	}
+	if _, err := readJSONFile(filepath.Join(currentConfig().DataDir, prepTimesFile), &prepTimes); err != nil {
+		fmt.Println("Gagal membaca riwayat waktu persiapan:", err)
+	}
+}
+
+// Fungsi untuk mencari riwayat item di stasiun, pemanggil harus memegang prepTimesMutex
+func findPrepTimeRecord(itemName string, station Station) *PrepTimeRecord {
+	for i := range prepTimes {
+		if strings.EqualFold(prepTimes[i].ItemName, itemName) && prepTimes[i].Station == station {
+			return &prepTimes[i]
+		}
+	}
+	return nil
+}
+
+// Fungsi untuk mengambil estimasi waktu persiapan hasil belajar, false jika item belum
+// pernah di-bump di stasiun itu atau prep_time_window bernilai 0
+func learnedPrepTime(itemName string, station Station) (time.Duration, bool) {
+	window := currentConfig().PrepTimeWindow
+	if window == 0 {
+		return 0, false
+	}
+
+	prepTimesMutex.Lock()
+	defer prepTimesMutex.Unlock()
+	ensurePrepTimesLoaded()
+	record := findPrepTimeRecord(itemName, station)
+	if record == nil || len(record.Samples) == 0 {
+		return 0, false
+	}
+	// Window yang diperkecil lewat konfigurasi langsung berlaku tanpa menunggu sampel baru
+	recent := *record
+	recent.Samples = recent.Samples[max(len(recent.Samples)-window, 0):]
+	return recent.Average(), true
+}
+
+// Fungsi untuk mencatat waktu persiapan sebenarnya saat item di-bump. Hanya sejumlah
+// prep_time_window sampel terakhir yang disimpan sehingga estimasi mengikuti
+// perubahan kecepatan dapur, misalnya koki baru atau resep yang diubah.
+func learnPrepTime(itemName string, station Station, prep time.Duration) {
+	window := currentConfig().PrepTimeWindow
+	if window == 0 || prep <= 0 || prep > maxPrepSample || dryRunActive() {
+		return
+	}
+
+	prepTimesMutex.Lock()
+	defer prepTimesMutex.Unlock()
+	ensurePrepTimesLoaded()
+	record := findPrepTimeRecord(itemName, station)
+	if record == nil {
+		prepTimes = append(prepTimes, PrepTimeRecord{ItemName: itemName, Station: station})
+		record = &prepTimes[len(prepTimes)-1]
+	}
+	record.Samples = append(record.Samples, prep)
+	if len(record.Samples) > window {
+		record.Samples = slices.Clone(record.Samples[len(record.Samples)-window:])
+	}
+
+	if currentConfig().Storage == StorageMemory {
+		return
+	}
+	if err := writeJSONFile(filepath.Join(currentConfig().DataDir, prepTimesFile), prepTimes); err != nil {
+		fmt.Println("Gagal menyimpan riwayat waktu persiapan:", err)
+	}
+}
+
+// Fungsi untuk menampilkan estimasi waktu persiapan hasil belajar per stasiun dan item
+func displayLearnedPrepTimes() {
+	window := currentConfig().PrepTimeWindow
+	if window == 0 {
+		return
+	}
+
+	prepTimesMutex.Lock()
+	ensurePrepTimesLoaded()
+	records := slices.Clone(prepTimes)
+	prepTimesMutex.Unlock()
+	if len(records) == 0 {
+		return
+	}
+
+	slices.SortFunc(records, func(a, b PrepTimeRecord) int {
+		if a.Station != b.Station {
+			return slices.Index(stations, a.Station) - slices.Index(stations, b.Station)
+		}
+		return strings.Compare(a.ItemName, b.ItemName)
+	})
+	fmt.Printf("Estimasi dipakai (rata-rata %d bump terakhir):\n", window)
+	for _, record := range records {
+		record.Samples = record.Samples[max(len(record.Samples)-window, 0):]
+		fmt.Printf("  %s | %s | Sampel: %d | Estimasi: %s\n", record.Station, record.ItemName, len(record.Samples), formatPrepTime(record.Average()))
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Perkiraan waktu persiapan untuk item yang belum pernah selesai dibuat
+const defaultPrepTime = 10 * time.Minute
+
+// Fungsi untuk menghitung waktu persiapan sebenarnya dari baris yang sudah di-bump
+func actualPrepTime(line OrderLine) (time.Duration, bool) {
+	if line.Status != LineDone || line.StartedAt.IsZero() || line.ReadyAt.IsZero() {
+		return 0, false
+	}
+	return line.ReadyAt.Sub(line.StartedAt), true
+}
+
+// Fungsi untuk memperkirakan waktu persiapan item di stasiun. Rata-rata bergulir dari
+// waktu persiapan terakhir di stasiun itu dipakai lebih dulu; jika belum ada, dipakai
+// rata-rata waktu sebenarnya dari pesanan yang masih tersimpan. Pemanggil harus memegang ordersMutex.
+func estimatePrepTime(itemName string, station Station) time.Duration {
+	if learned, ok := learnedPrepTime(itemName, station); ok {
+		return learned
+	}
+
+	var total time.Duration
+	count := 0
+	for _, order := range orders {
+		for _, line := range order.Lines {
+			if line.ItemName != itemName {
+				continue
+			}
+			if prep, ok := actualPrepTime(line); ok {
+				total += prep
+				count++
+			}
+		}
+	}
+
+	if count == 0 {
+		return defaultPrepTime
+	}
+	return total / time.Duration(count)
+}
+
+// Fungsi untuk memperkirakan kapan satu baris siap, nol jika baris tidak sedang menuju dapur.
+// Pemanggil harus memegang ordersMutex.
+func estimateLineReadyAt(line OrderLine, now time.Time) time.Time {
+	switch line.Status {
+	case LinePreparing:
+		return line.StartedAt.Add(line.EstimatedPrep)
+	case LineQueued:
+		return now.Add(estimatePrepTime(line.ItemName, line.Station))
+	}
+	return time.Time{}
+}
+
+// Fungsi untuk memperkirakan kapan seluruh baris pesanan yang dikirim ke dapur siap,
+// pemanggil harus memegang ordersMutex
+func estimateReadyAt(order *Order, now time.Time) time.Time {
+	var readyAt time.Time
+	for _, line := range order.Lines {
+		if lineReadyAt := estimateLineReadyAt(line, now); lineReadyAt.After(readyAt) {
+			readyAt = lineReadyAt
+		}
+	}
+	return readyAt
+}
+
+// Fungsi untuk menampilkan durasi persiapan dalam menit dan detik
+func formatPrepTime(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
+
+// Ringkasan waktu persiapan untuk satu item atau stasiun
+type prepStats struct {
+	Count     int
+	Actual    time.Duration
+	Estimated time.Duration
+	Longest   time.Duration
+	Late      int
+}
+
+// Fungsi untuk menambahkan satu baris selesai ke ringkasan
+func (stats *prepStats) add(line OrderLine, prep time.Duration) {
+	stats.Count++
+	stats.Actual += prep
+	stats.Estimated += line.EstimatedPrep
+	stats.Longest = max(stats.Longest, prep)
+	if prep > line.EstimatedPrep {
+		stats.Late++
+	}
+}
+
+// Fungsi untuk menampilkan satu baris laporan kinerja
+func (stats *prepStats) print(label string) {
+	n := time.Duration(stats.Count)
+	fmt.Printf("%s | Selesai: %d | Rata-rata: %s | Estimasi: %s | Terlama: %s | Terlambat: %d\n",
+		label, stats.Count, formatPrepTime(stats.Actual/n), formatPrepTime(stats.Estimated/n), formatPrepTime(stats.Longest), stats.Late)
+}
+
+// Fungsi untuk menampilkan laporan kinerja dapur: waktu persiapan sebenarnya
+// dibandingkan estimasi, per item dan per stasiun
+func displayKitchenPerformance() {
+	ordersMutex.Lock()
+	byItem := map[string]*prepStats{}
+	byStation := map[Station]*prepStats{}
+	for _, order := range orders {
+		for _, line := range order.Lines {
+			prep, ok := actualPrepTime(line)
+			if !ok {
+				continue
+			}
+			if byItem[line.ItemName] == nil {
+				byItem[line.ItemName] = &prepStats{}
+			}
+			if byStation[line.Station] == nil {
+				byStation[line.Station] = &prepStats{}
+			}
+			byItem[line.ItemName].add(line, prep)
+			byStation[line.Station].add(line, prep)
+		}
+	}
+	ordersMutex.Unlock()
+
+	fmt.Println("\n===== Laporan Kinerja Dapur =====")
+	if len(byItem) == 0 {
+		fmt.Println("Belum ada item yang selesai di-bump.")
+		return
+	}
+
+	names := make([]string, 0, len(byItem))
+	for name := range byItem {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Println("Per item:")
+	for _, name := range names {
+		byItem[name].print("  " + name)
+	}
+
+	fmt.Println("Per stasiun:")
+	for _, station := range stations {
+		if stats, ok := byStation[station]; ok {
+			stats.print("  " + string(station))
+		}
+	}
+
+	displayLearnedPrepTimes()
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Struct untuk daftar harga bernama di konfigurasi, misalnya harga GoFood yang lebih
+// tinggi dari harga menu karena komisi platform
+type PriceListConfig struct {
+	// Kenaikan dari harga menu dalam persen, misalnya 20 untuk komisi platform 20%
+	Markup float64 `json:"markup"`
+	// Harga setelah kenaikan dibulatkan ke atas ke kelipatan ini, misalnya 500
+	RoundTo Money `json:"round_to"`
+	// Harga tetap per item yang menggantikan kenaikan, misalnya {"Nasi Goreng": 21000}
+	Prices map[string]Money `json:"prices"`
+	// Jenis pesanan yang boleh memakai daftar harga ini, kosong berarti semua jenis
+	OrderTypes []OrderType `json:"order_types"`
+	// Klien API yang pesanannya otomatis memakai daftar harga ini, misalnya ["gofood"]
+	Clients []string `json:"clients"`
+}
+
+// Fungsi untuk memeriksa apakah daftar harga boleh dipakai untuk jenis pesanan tertentu
+func (list PriceListConfig) appliesTo(orderType OrderType) bool {
+	return len(list.OrderTypes) == 0 || slices.Contains(list.OrderTypes, orderType)
+}
+
+// Fungsi untuk memeriksa daftar harga di konfigurasi
+func validatePriceLists(lists map[string]PriceListConfig, apiKeys map[string]string) error {
+	clients := map[string]string{}
+	for name, list := range lists {
+		if name == "" || strings.ContainsAny(name, " =\t") || strings.EqualFold(name, "menu") {
+			return fmt.Errorf("price_lists: nama %q harus diisi tanpa spasi dan bukan menu", name)
+		}
+		if list.Markup < 0 || list.RoundTo < 0 {
+			return fmt.Errorf("price_lists %s: markup dan round_to tidak boleh negatif", name)
+		}
+		for item, price := range list.Prices {
+			if price < 0 {
+				return fmt.Errorf("price_lists %s: harga %s tidak boleh negatif", name, item)
+			}
+		}
+		for _, orderType := range list.OrderTypes {
+			if !slices.Contains(orderTypes, orderType) {
+				return fmt.Errorf("price_lists %s: jenis pesanan %q tidak dikenal", name, orderType)
+			}
+		}
+		for _, client := range list.Clients {
+			if _, ok := apiKeys[client]; !ok {
+				return fmt.Errorf("price_lists %s memuat klien %q yang tidak ada di api_keys", name, client)
+			}
+			if other, ok := clients[client]; ok {
+				return fmt.Errorf("price_lists: klien %q dipakai di %s dan %s", client, other, name)
+			}
+			clients[client] = name
+		}
+	}
+	return nil
+}
+
+// Fungsi untuk mencari daftar harga tanpa membedakan huruf besar kecil, nama yang
+// dikembalikan mengikuti konfigurasi
+func findPriceList(name string) (string, PriceListConfig, bool) {
+	for key, list := range currentConfig().PriceLists {
+		if strings.EqualFold(key, name) {
+			return key, list, true
+		}
+	}
+	return "", PriceListConfig{}, false
+}
+
+// Fungsi untuk menghitung harga item di daftar harga. Harga tetap di prices dipakai
+// lebih dulu, jika tidak ada harga menu dinaikkan sesuai markup. Daftar kosong atau
+// yang sudah dihapus dari konfigurasi memakai harga menu.
+func channelPrice(item *MenuItem, name string) Money {
+	if name == "" {
+		return item.Price
+	}
+	_, list, ok := findPriceList(name)
+	if !ok {
+		return item.Price
+	}
+	for key, price := range list.Prices {
+		if strings.EqualFold(key, item.Name) {
+			return price
+		}
+	}
+	return repriceQuery{Percent: list.Markup, RoundTo: list.RoundTo}.apply(item.Price)
+}
+
+// Fungsi untuk mengambil nama daftar harga yang boleh dipakai jenis pesanan ini, urut abjad
+func priceListNames(orderType OrderType) []string {
+	var names []string
+	for name, list := range currentConfig().PriceLists {
+		if list.appliesTo(orderType) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Fungsi untuk memeriksa daftar harga yang diminta untuk jenis pesanan ini. Nama kosong
+// berarti harga menu.
+func checkPriceList(name string, orderType OrderType) (string, error) {
+	if name == "" || strings.EqualFold(name, "menu") {
+		return "", nil
+	}
+	key, list, ok := findPriceList(name)
+	if !ok {
+		return "", fmt.Errorf("daftar harga %q tidak dikenal", name)
+	}
+	if !list.appliesTo(orderType) {
+		return "", fmt.Errorf("daftar harga %s tidak dipakai untuk pesanan %s", key, orderType.Label())
+	}
+	return key, nil
+}
+
+// Fungsi untuk menentukan daftar harga pesanan API: yang diminta di body permintaan,
+// atau daftar harga klien sesuai clients di price_lists
+func apiPriceList(requested, client string, orderType OrderType) (string, error) {
+	if requested != "" {
+		return checkPriceList(requested, orderType)
+	}
+	for name, list := range currentConfig().PriceLists {
+		if slices.Contains(list.Clients, client) && list.appliesTo(orderType) {
+			return name, nil
+		}
+	}
+	return "", nil
+}
+
+// Fungsi untuk menanyakan daftar harga jika ada yang berlaku untuk jenis pesanan ini.
+// Dikosongkan berarti harga menu.
+func readPriceList(reader *bufio.Reader, orderType OrderType) (string, bool) {
+	names := priceListNames(orderType)
+	if len(names) == 0 {
+		return "", true
+	}
+	fmt.Printf("Daftar harga (%s; kosongkan untuk harga menu): ", strings.Join(names, ", "))
+	input, _ := reader.ReadString('\n')
+	name, err := checkPriceList(strings.TrimSpace(input), orderType)
+	if err != nil {
+		fmt.Println(err)
+		return "", false
+	}
+	return name, true
+}
+
+// Fungsi untuk menampilkan nama daftar harga pesanan, pesanan tanpa daftar harga memakai harga menu
+func describePriceList(name string) string {
+	if name == "" {
+		return "menu"
+	}
+	return name
+}
@@ -0,0 +1,422 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Struct untuk data pemasok
+type Supplier struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Phone string `json:"phone"`
+}
+
+// Status purchase order
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrdered  PurchaseOrderStatus = "ordered"
+	PurchaseReceived PurchaseOrderStatus = "received"
+)
+
+// Status purchase order berbahasa Indonesia dari data lama
+var legacyPurchaseOrderStatuses = map[PurchaseOrderStatus]PurchaseOrderStatus{
+	"dipesan":  PurchaseOrdered,
+	"diterima": PurchaseReceived,
+}
+
+// Fungsi untuk menampilkan status purchase order di layar
+func (s PurchaseOrderStatus) Label() string {
+	for legacy, status := range legacyPurchaseOrderStatuses {
+		if status == s {
+			return string(legacy)
+		}
+	}
+	return string(s)
+}
+
+// Fungsi untuk membaca status purchase order dari JSON, nilai lama ikut diterjemahkan
+func (s *PurchaseOrderStatus) UnmarshalJSON(data []byte) error {
+	return unmarshalLegacy(data, s, legacyPurchaseOrderStatuses)
+}
+
+// Struct untuk satu baris purchase order
+type PurchaseOrderLine struct {
+	ItemName string `json:"item"`
+	Quantity int    `json:"quantity"`
+	UnitCost Money  `json:"unit_cost"`
+}
+
+// Struct untuk purchase order ke pemasok
+type PurchaseOrder struct {
+	ID         int                 `json:"id"`
+	SupplierID int                 `json:"supplier_id"`
+	Lines      []PurchaseOrderLine `json:"lines"`
+	Status     PurchaseOrderStatus `json:"status"`
+	CreatedAt  time.Time           `json:"created_at"`
+	ReceivedAt time.Time           `json:"received_at"`
+}
+
+// Nama file pemasok, purchase order dan retur ke pemasok di folder data
+const purchasingFile = "purchasing.json"
+
+// Isi file purchasing.json. Penghitung ID ikut disimpan agar ID tidak terpakai ulang.
+type purchasingData struct {
+	Suppliers           []Supplier       `json:"suppliers"`
+	PurchaseOrders      []*PurchaseOrder `json:"purchase_orders"`
+	SupplierReturns     []SupplierReturn `json:"supplier_returns"`
+	NextSupplierID      int              `json:"next_supplier_id"`
+	NextPurchaseOrderID int              `json:"next_purchase_order_id"`
+}
+
+// Data pemasok dan purchase order, dibaca dari file saat pertama kali dipakai
+var suppliers []Supplier
+var purchaseOrders []*PurchaseOrder
+var nextSupplierID = 1
+var nextPurchaseOrderID = 1
+var purchasingLoaded bool
+var purchasingMutex sync.Mutex
+
+// Fungsi untuk membaca pemasok, purchase order dan retur ke pemasok dari file jika belum dibaca. Pada storage
+// memory datanya hanya ada selama program berjalan. Pemanggil harus memegang purchasingMutex.
+func ensurePurchasingLoaded() {
+	if purchasingLoaded {
+		return
+	}
+	purchasingLoaded = true
+	if currentConfig().Storage == StorageMemory {
+		return
+	}
+	var data purchasingData
+	found, err := readJSONFile(filepath.Join(currentConfig().DataDir, purchasingFile), &data)
+	if err != nil {
+		fmt.Println("Gagal membaca data pembelian:", err)
+		return
+	}
+	if !found {
+		return
+	}
+	suppliers = data.Suppliers
+	purchaseOrders = data.PurchaseOrders
+	supplierReturns = data.SupplierReturns
+	nextSupplierID = max(data.NextSupplierID, 1)
+	nextPurchaseOrderID = max(data.NextPurchaseOrderID, 1)
+}
+
+// Fungsi untuk menyimpan pemasok, purchase order dan retur ke pemasok ke file. Perubahan selama dry-run
+// tidak disimpan. Pemanggil harus memegang purchasingMutex.
+func savePurchasing() {
+	if currentConfig().Storage == StorageMemory || dryRunActive() {
+		return
+	}
+	data := purchasingData{
+		Suppliers:           suppliers,
+		PurchaseOrders:      purchaseOrders,
+		SupplierReturns:     supplierReturns,
+		NextSupplierID:      nextSupplierID,
+		NextPurchaseOrderID: nextPurchaseOrderID,
+	}
+	if err := writeJSONFile(filepath.Join(currentConfig().DataDir, purchasingFile), data); err != nil {
+		fmt.Println("Gagal menyimpan data pembelian:", err)
+	}
+}
+
+// Fungsi untuk menampilkan submenu pemasok dan purchase order
+func purchasingMenu(reader *bufio.Reader) {
+	fmt.Println("\n===== Pemasok & Purchase Order =====")
+	fmt.Println("1. Tambah Pemasok")
+	fmt.Println("2. Daftar Pemasok")
+	fmt.Println("3. Buat Purchase Order")
+	fmt.Println("4. Daftar Purchase Order")
+	fmt.Println("5. Terima Purchase Order")
+	fmt.Println("6. Retur ke Pemasok")
+	fmt.Print("Pilih opsi: ")
+
+	input, _ := reader.ReadString('\n')
+	switch strings.TrimSpace(input) {
+	case "1":
+		addSupplier(reader)
+	case "2":
+		displaySuppliers()
+	case "3":
+		createPurchaseOrder(reader)
+	case "4":
+		displayPurchaseOrders()
+	case "5":
+		receivePurchaseOrder(reader)
+	case "6":
+		returnToSupplier(reader)
+	default:
+		fmt.Println("Opsi tidak valid.")
+	}
+}
+
+// Fungsi untuk menambah pemasok baru
+func addSupplier(reader *bufio.Reader) {
+	fmt.Print("Nama pemasok: ")
+	name, _ := reader.ReadString('\n')
+	name = strings.TrimSpace(name)
+	if name == "" {
+		fmt.Println("Nama pemasok wajib diisi.")
+		return
+	}
+
+	fmt.Print("Nomor telepon: ")
+	phone, _ := reader.ReadString('\n')
+
+	purchasingMutex.Lock()
+	defer purchasingMutex.Unlock()
+
+	ensurePurchasingLoaded()
+	supplier := Supplier{ID: nextSupplierID, Name: name, Phone: strings.TrimSpace(phone)}
+	suppliers = append(suppliers, supplier)
+	nextSupplierID++
+	savePurchasing()
+	fmt.Printf("Pemasok %s ditambahkan dengan ID %d.\n", supplier.Name, supplier.ID)
+}
+
+// Fungsi untuk mencari pemasok berdasarkan ID, pemanggil harus memegang purchasingMutex
+func findSupplier(id int) *Supplier {
+	for i := range suppliers {
+		if suppliers[i].ID == id {
+			return &suppliers[i]
+		}
+	}
+	return nil
+}
+
+// Fungsi untuk mencari purchase order berdasarkan ID, pemanggil harus memegang purchasingMutex
+func findPurchaseOrder(id int) *PurchaseOrder {
+	for _, po := range purchaseOrders {
+		if po.ID == id {
+			return po
+		}
+	}
+	return nil
+}
+
+// Fungsi untuk menampilkan daftar pemasok
+func displaySuppliers() {
+	purchasingMutex.Lock()
+	defer purchasingMutex.Unlock()
+
+	ensurePurchasingLoaded()
+	if len(suppliers) == 0 {
+		fmt.Println("Belum ada pemasok.")
+		return
+	}
+
+	for _, supplier := range suppliers {
+		fmt.Printf("ID: %d | Nama: %s | Telepon: %s\n", supplier.ID, supplier.Name, supplier.Phone)
+	}
+}
+
+// Fungsi untuk membuat purchase order untuk item menu
+func createPurchaseOrder(reader *bufio.Reader) {
+	fmt.Print("Masukkan ID pemasok: ")
+	idInput, _ := reader.ReadString('\n')
+	supplierID, err := strconv.Atoi(strings.TrimSpace(idInput))
+	if err != nil {
+		fmt.Println("ID pemasok harus berupa angka.")
+		return
+	}
+
+	purchasingMutex.Lock()
+	ensurePurchasingLoaded()
+	supplier := findSupplier(supplierID)
+	purchasingMutex.Unlock()
+	if supplier == nil {
+		fmt.Println("Pemasok tidak ditemukan.")
+		return
+	}
+
+	po := &PurchaseOrder{SupplierID: supplierID, Status: PurchaseOrdered, CreatedAt: time.Now()}
+	for {
+		if line, ok := readPurchaseOrderLine(reader); ok {
+			po.Lines = append(po.Lines, line)
+		}
+
+		fmt.Print("Tambah item lain? (y/n): ")
+		more, _ := reader.ReadString('\n')
+		if !strings.EqualFold(strings.TrimSpace(more), "y") {
+			break
+		}
+	}
+
+	if len(po.Lines) == 0 {
+		fmt.Println("Purchase order tidak memiliki item.")
+		return
+	}
+
+	purchasingMutex.Lock()
+	po.ID = nextPurchaseOrderID
+	nextPurchaseOrderID++
+	purchaseOrders = append(purchaseOrders, po)
+	savePurchasing()
+	purchasingMutex.Unlock()
+
+	fmt.Printf("Purchase order ID %d dibuat dengan total %s.\n", po.ID, formatMoney(po.Total()))
+}
+
+// Fungsi untuk membaca satu baris purchase order
+func readPurchaseOrderLine(reader *bufio.Reader) (PurchaseOrderLine, bool) {
+	fmt.Print("Masukkan nama item: ")
+	name, _ := reader.ReadString('\n')
+	name = strings.TrimSpace(name)
+
+	menuMutex.Lock()
+	item := findMenuItem(name)
+	if item != nil {
+		name = item.Name
+	}
+	menuMutex.Unlock()
+	if item == nil {
+		fmt.Println("Item tidak ditemukan.")
+		return PurchaseOrderLine{}, false
+	}
+
+	// Jumlah dan harga boleh diketik dalam satuan beli, disimpan dalam satuan pakai
+	fmt.Printf("Masukkan jumlah%s: ", describeUnitInput(name, true))
+	quantityInput, _ := reader.ReadString('\n')
+	quantity, err := parseUnitQuantity(name, quantityInput, true)
+	if err != nil {
+		fmt.Println("Jumlah tidak valid:", err)
+		return PurchaseOrderLine{}, false
+	}
+	if quantity <= 0 {
+		fmt.Println("Jumlah harus berupa angka positif.")
+		return PurchaseOrderLine{}, false
+	}
+
+	costUnit := "unit"
+	if units, ok := stockUnitsFor(name); ok {
+		costUnit = units.Unit
+		if units.PurchaseUnit != "" && units.factor() > 0 {
+			costUnit = units.PurchaseUnit
+		}
+	}
+	fmt.Printf("Masukkan harga beli per %s: ", costUnit)
+	costInput, _ := reader.ReadString('\n')
+	purchaseCost, err := parseMoneyInput(strings.TrimSpace(costInput))
+	if err != nil || purchaseCost < 0 {
+		fmt.Println("Harga beli harus berupa angka positif.")
+		return PurchaseOrderLine{}, false
+	}
+
+	return PurchaseOrderLine{ItemName: name, Quantity: quantity, UnitCost: unitCostFromPurchase(name, purchaseCost)}, true
+}
+
+// Fungsi untuk menghitung total nilai purchase order
+func (po *PurchaseOrder) Total() Money {
+	var total Money
+	for _, line := range po.Lines {
+		total += line.UnitCost.Times(line.Quantity)
+	}
+	return total
+}
+
+// Fungsi untuk menampilkan daftar purchase order
+func displayPurchaseOrders() {
+	purchasingMutex.Lock()
+	defer purchasingMutex.Unlock()
+
+	ensurePurchasingLoaded()
+	if len(purchaseOrders) == 0 {
+		fmt.Println("Belum ada purchase order.")
+		return
+	}
+
+	for _, po := range purchaseOrders {
+		supplierName := "-"
+		if supplier := findSupplier(po.SupplierID); supplier != nil {
+			supplierName = supplier.Name
+		}
+		fmt.Printf("PO ID %d | Pemasok: %s | Status: %s | Total: %s\n", po.ID, supplierName, po.Status.Label(), formatMoney(po.Total()))
+		for _, line := range po.Lines {
+			returned := ""
+			if quantity := returnedToSupplier(po.ID, line.ItemName); quantity > 0 {
+				returned = fmt.Sprintf(" | Diretur: %d", quantity)
+			}
+			fmt.Printf("  %s x%s @ %s%s\n", line.ItemName, describeStockQuantity(line.ItemName, line.Quantity), formatMoney(line.UnitCost), returned)
+		}
+	}
+}
+
+// Fungsi untuk menerima purchase order, menambah stok dan memperbarui harga pokok item
+func receivePurchaseOrder(reader *bufio.Reader) {
+	fmt.Print("Masukkan ID purchase order: ")
+	idInput, _ := reader.ReadString('\n')
+	id, err := strconv.Atoi(strings.TrimSpace(idInput))
+	if err != nil {
+		fmt.Println("ID purchase order harus berupa angka.")
+		return
+	}
+
+	purchasingMutex.Lock()
+	ensurePurchasingLoaded()
+	po := findPurchaseOrder(id)
+	if po == nil {
+		purchasingMutex.Unlock()
+		fmt.Println("Purchase order tidak ditemukan.")
+		return
+	}
+	if po.Status == PurchaseReceived {
+		purchasingMutex.Unlock()
+		fmt.Println("Purchase order sudah diterima.")
+		return
+	}
+	po.Status = PurchaseReceived
+	po.ReceivedAt = time.Now()
+	lines := po.Lines
+	purchasingMutex.Unlock()
+
+	// Tanggal kedaluwarsa dibaca dulu agar menu tidak terkunci selama kasir mengetik
+	expiries := make([]time.Time, len(lines))
+	for i, line := range lines {
+		expiresAt, ok := readOptionalDate(reader, fmt.Sprintf("Tanggal kedaluwarsa %s (YYYY-MM-DD, kosongkan jika tidak ada): ", line.ItemName))
+		if !ok {
+			// Batalkan penerimaan agar PO bisa diterima ulang dengan tanggal yang benar
+			purchasingMutex.Lock()
+			po.Status = PurchaseOrdered
+			po.ReceivedAt = time.Time{}
+			purchasingMutex.Unlock()
+			return
+		}
+		expiries[i] = expiresAt
+	}
+	purchasingMutex.Lock()
+	savePurchasing()
+	purchasingMutex.Unlock()
+
+	menuMutex.Lock()
+	defer menuMutex.Unlock()
+
+	reference := fmt.Sprintf("PO %d", id)
+	for i, line := range lines {
+		item := findMenuItem(line.ItemName)
+		if item == nil {
+			fmt.Printf("%s sudah tidak ada di menu, dilewati.\n", line.ItemName)
+			continue
+		}
+
+		// Harga pokok dihitung dengan rata-rata tertimbang stok lama dan stok baru,
+		// item yang belum punya harga pokok langsung memakai harga beli terakhir
+		cost := line.UnitCost
+		if item.Cost != 0 && item.Quantity > 0 {
+			cost = (item.Cost.Times(item.Quantity) + line.UnitCost.Times(line.Quantity)).Div(item.Quantity + line.Quantity)
+		}
+		if err := item.addStock(line.Quantity, expiries[i]); err != nil {
+			fmt.Printf("Gagal menambah stok %s: %v\n", item.Name, err)
+			continue
+		}
+		item.Cost = cost
+		recordCostedMovement(item.Name, line.Quantity, MovementPurchase, reference, line.UnitCost)
+		fmt.Printf("Stok %s bertambah %s menjadi %s (harga pokok %s).\n", item.Name, describeStockQuantity(item.Name, line.Quantity), describeStockQuantity(item.Name, item.Quantity), formatMoney(item.Cost))
+	}
+}
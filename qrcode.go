@@ -0,0 +1,387 @@
+package main
+
+import (
+	"errors"
+	"strings"
+)
+
+// Struktur blok QR level koreksi L untuk versi 1 sampai 6, cukup untuk isi struk dan
+// masih muat di kertas 80mm. Versi 1-5 satu blok, versi 6 dua blok yang sama besar
+// sehingga codeword data dan koreksinya diselang-seling.
+var qrVersionsL = []struct {
+	blocks, dataPerBlock, ecPerBlock int
+}{
+	{1, 19, 7},
+	{1, 34, 10},
+	{1, 55, 15},
+	{1, 80, 20},
+	{1, 108, 26},
+	{2, 68, 18},
+}
+
+// Kode QR yang sudah dibuat, true berarti modul gelap
+type qrCode struct {
+	size    int
+	modules [][]bool
+	// Modul pola tetap yang tidak boleh ditimpa data atau mask
+	function [][]bool
+}
+
+// Fungsi untuk membuat kode QR mode byte dengan versi terkecil yang cukup untuk isinya
+func encodeQR(payload string) (*qrCode, error) {
+	data := []byte(payload)
+	version := 0
+	for i, v := range qrVersionsL {
+		// 4 bit mode dan 8 bit panjang data
+		if len(data)+2 <= v.blocks*v.dataPerBlock {
+			version = i + 1
+			break
+		}
+	}
+	if version == 0 {
+		return nil, errors.New("isi QR terlalu panjang")
+	}
+	spec := qrVersionsL[version-1]
+	capacity := spec.blocks * spec.dataPerBlock
+
+	// Susun bit data: mode byte, panjang, isi, terminator lalu byte pengisi
+	var bits []bool
+	appendBits := func(value, count int) {
+		for i := count - 1; i >= 0; i-- {
+			bits = append(bits, (value>>i)&1 == 1)
+		}
+	}
+	appendBits(0x4, 4)
+	appendBits(len(data), 8)
+	for _, b := range data {
+		appendBits(int(b), 8)
+	}
+	appendBits(0, min(4, capacity*8-len(bits)))
+	for len(bits)%8 != 0 {
+		bits = append(bits, false)
+	}
+	codewords := make([]byte, 0, capacity)
+	for i := 0; i < len(bits); i += 8 {
+		var b byte
+		for j := 0; j < 8; j++ {
+			if bits[i+j] {
+				b |= 1 << (7 - j)
+			}
+		}
+		codewords = append(codewords, b)
+	}
+	for pad := byte(0xEC); len(codewords) < capacity; pad ^= 0xEC ^ 0x11 {
+		codewords = append(codewords, pad)
+	}
+
+	// Hitung koreksi kesalahan per blok lalu selang-seling data dan koreksinya
+	divisor := reedSolomonDivisor(spec.ecPerBlock)
+	var blocks, ecBlocks [][]byte
+	for i := 0; i < spec.blocks; i++ {
+		block := codewords[i*spec.dataPerBlock : (i+1)*spec.dataPerBlock]
+		blocks = append(blocks, block)
+		ecBlocks = append(ecBlocks, reedSolomonRemainder(block, divisor))
+	}
+	var final []byte
+	for i := 0; i < spec.dataPerBlock; i++ {
+		for _, block := range blocks {
+			final = append(final, block[i])
+		}
+	}
+	for i := 0; i < spec.ecPerBlock; i++ {
+		for _, block := range ecBlocks {
+			final = append(final, block[i])
+		}
+	}
+
+	qr := newQRCode(version)
+	qr.drawCodewords(final)
+
+	// Pilih mask dengan penalti terkecil agar mudah dipindai
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		qr.applyMask(mask)
+		qr.drawFormatBits(mask)
+		if penalty := qr.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			best, bestPenalty = mask, penalty
+		}
+		qr.applyMask(mask)
+	}
+	qr.applyMask(best)
+	qr.drawFormatBits(best)
+	return qr, nil
+}
+
+// Fungsi untuk membuat kode QR kosong dengan semua pola tetap sudah digambar
+func newQRCode(version int) *qrCode {
+	size := version*4 + 17
+	qr := &qrCode{size: size, modules: make([][]bool, size), function: make([][]bool, size)}
+	for i := range qr.modules {
+		qr.modules[i] = make([]bool, size)
+		qr.function[i] = make([]bool, size)
+	}
+
+	for i := 0; i < size; i++ {
+		qr.set(6, i, i%2 == 0)
+		qr.set(i, 6, i%2 == 0)
+	}
+	qr.drawFinder(3, 3)
+	qr.drawFinder(size-4, 3)
+	qr.drawFinder(3, size-4)
+	if version > 1 {
+		// Versi 2-6 hanya punya satu pola alignment di dekat pojok kanan bawah
+		center := size - 7
+		for dy := -2; dy <= 2; dy++ {
+			for dx := -2; dx <= 2; dx++ {
+				qr.set(center+dx, center+dy, max(abs(dx), abs(dy)) != 1)
+			}
+		}
+	}
+	// Cadangkan area format, isinya ditulis setelah mask dipilih
+	qr.drawFormatBits(0)
+	return qr
+}
+
+// Fungsi untuk menggambar pola finder beserta pemisahnya dengan pusat di x, y
+func (qr *qrCode) drawFinder(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || xx >= qr.size || yy < 0 || yy >= qr.size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			qr.set(xx, yy, dist != 2 && dist != 4)
+		}
+	}
+}
+
+// Fungsi untuk menulis modul pola tetap
+func (qr *qrCode) set(x, y int, dark bool) {
+	qr.modules[y][x] = dark
+	qr.function[y][x] = true
+}
+
+// Fungsi untuk menulis informasi format: level koreksi L dan nomor mask
+func (qr *qrCode) drawFormatBits(mask int) {
+	data := 1<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+	bit := func(i int) bool { return (bits>>i)&1 == 1 }
+
+	for i := 0; i <= 5; i++ {
+		qr.set(8, i, bit(i))
+	}
+	qr.set(8, 7, bit(6))
+	qr.set(8, 8, bit(7))
+	qr.set(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		qr.set(14-i, 8, bit(i))
+	}
+	for i := 0; i < 8; i++ {
+		qr.set(qr.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		qr.set(8, qr.size-15+i, bit(i))
+	}
+	qr.set(8, qr.size-8, true)
+}
+
+// Fungsi untuk menempatkan codeword secara zig-zag dari pojok kanan bawah
+func (qr *qrCode) drawCodewords(data []byte) {
+	i := 0
+	for right := qr.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < qr.size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = qr.size - 1 - vert
+				}
+				if qr.function[y][x] || i >= len(data)*8 {
+					continue
+				}
+				qr.modules[y][x] = (data[i>>3]>>(7-i&7))&1 == 1
+				i++
+			}
+		}
+	}
+}
+
+// Fungsi untuk membalik modul data sesuai pola mask, memanggil dua kali mengembalikannya
+func (qr *qrCode) applyMask(mask int) {
+	for y := 0; y < qr.size; y++ {
+		for x := 0; x < qr.size; x++ {
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert && !qr.function[y][x] {
+				qr.modules[y][x] = !qr.modules[y][x]
+			}
+		}
+	}
+}
+
+// Fungsi untuk menghitung penalti pola sesuai standar QR: deretan warna sama, blok 2x2,
+// pola mirip finder dan keseimbangan modul gelap
+func (qr *qrCode) penalty() int {
+	penalty := 0
+	finderLike := [][]bool{
+		{true, false, true, true, true, false, true, false, false, false, false},
+		{false, false, false, false, true, false, true, true, true, false, true},
+	}
+	for pass := 0; pass < 2; pass++ {
+		// Pass pertama membaca baris, pass kedua membaca kolom
+		at := func(a, b int) bool {
+			if pass == 0 {
+				return qr.modules[a][b]
+			}
+			return qr.modules[b][a]
+		}
+		for a := 0; a < qr.size; a++ {
+			run := 1
+			for b := 1; b <= qr.size; b++ {
+				if b < qr.size && at(a, b) == at(a, b-1) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					penalty += run - 2
+				}
+				run = 1
+			}
+			for b := 0; b+11 <= qr.size; b++ {
+				for _, pattern := range finderLike {
+					match := true
+					for k, dark := range pattern {
+						if at(a, b+k) != dark {
+							match = false
+							break
+						}
+					}
+					if match {
+						penalty += 40
+					}
+				}
+			}
+		}
+	}
+
+	dark := 0
+	for y := 0; y < qr.size; y++ {
+		for x := 0; x < qr.size; x++ {
+			if qr.modules[y][x] {
+				dark++
+			}
+			if x+1 < qr.size && y+1 < qr.size {
+				c := qr.modules[y][x]
+				if c == qr.modules[y][x+1] && c == qr.modules[y+1][x] && c == qr.modules[y+1][x+1] {
+					penalty += 3
+				}
+			}
+		}
+	}
+	total := qr.size * qr.size
+	penalty += ((abs(dark*20-total*10)+total-1)/total - 1) * 10
+	return penalty
+}
+
+// Fungsi untuk menampilkan kode QR sebagai teks dengan karakter setengah blok, satu baris
+// teks berisi dua baris modul. Modul gelap dicetak sehingga di kertas struk warnanya benar.
+func (qr *qrCode) Lines(quiet int) []string {
+	size := qr.size + quiet*2
+	dark := func(x, y int) bool {
+		x, y = x-quiet, y-quiet
+		return x >= 0 && y >= 0 && x < qr.size && y < qr.size && qr.modules[y][x]
+	}
+	var lines []string
+	for y := 0; y < size; y += 2 {
+		var b strings.Builder
+		for x := 0; x < size; x++ {
+			top, bottom := dark(x, y), dark(x, y+1)
+			switch {
+			case top && bottom:
+				b.WriteString("█")
+			case top:
+				b.WriteString("▀")
+			case bottom:
+				b.WriteString("▄")
+			default:
+				b.WriteString(" ")
+			}
+		}
+		lines = append(lines, b.String())
+	}
+	return lines
+}
+
+// Fungsi untuk mengambil nilai mutlak bilangan bulat
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Fungsi untuk menghitung pembagi Reed-Solomon dengan derajat tertentu
+func reedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+	return result
+}
+
+// Fungsi untuk menghitung codeword koreksi kesalahan satu blok data
+func reedSolomonRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i := range result {
+			result[i] ^= gfMultiply(divisor[i], factor)
+		}
+	}
+	return result
+}
+
+// Fungsi untuk mengalikan dua angka di GF(256) dengan polinomial 0x11D
+func gfMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>i)&1) * int(x)
+	}
+	return byte(z)
+}
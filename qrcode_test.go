@@ -0,0 +1,217 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// Informasi format level L untuk mask 0-7 sesuai tabel standar QR, sudah di-XOR 0x5412
+var qrFormatL = []int{0x77C4, 0x72F3, 0x7DAA, 0x789D, 0x662F, 0x6318, 0x6C41, 0x6976}
+
+// Jumlah codeword total dan codeword data level L per versi sesuai tabel standar QR
+var qrCapacityL = map[int]struct{ total, data, blocks int }{
+	1: {26, 19, 1},
+	2: {44, 34, 1},
+	6: {172, 136, 2},
+}
+
+// Fungsi untuk membaca informasi format salinan pertama (di sekitar finder kiri atas)
+// dan salinan kedua (di bawah finder kiri atas dan kanan finder kanan atas)
+func readQRFormat(qr *qrCode) (int, int) {
+	at := func(x, y int) int {
+		if qr.modules[y][x] {
+			return 1
+		}
+		return 0
+	}
+	var first, second int
+	for i := 0; i <= 5; i++ {
+		first |= at(8, i) << i
+	}
+	first |= at(8, 7)<<6 | at(8, 8)<<7 | at(7, 8)<<8
+	for i := 9; i < 15; i++ {
+		first |= at(14-i, 8) << i
+	}
+	for i := 0; i < 8; i++ {
+		second |= at(qr.size-1-i, 8) << i
+	}
+	for i := 8; i < 15; i++ {
+		second |= at(8, qr.size-15+i) << i
+	}
+	return first, second
+}
+
+// Fungsi untuk menandai modul pola tetap versi 1-6 tanpa memakai kode encoder
+func qrReservedModules(version int) [][]bool {
+	size := version*4 + 17
+	reserved := make([][]bool, size)
+	for y := range reserved {
+		reserved[y] = make([]bool, size)
+	}
+	mark := func(x0, y0, w, h int) {
+		for y := y0; y < y0+h; y++ {
+			for x := x0; x < x0+w; x++ {
+				reserved[y][x] = true
+			}
+		}
+	}
+	// Finder, pemisah dan area format
+	mark(0, 0, 9, 9)
+	mark(size-8, 0, 8, 9)
+	mark(0, size-8, 9, 8)
+	// Pola timing
+	mark(6, 0, 1, size)
+	mark(0, 6, size, 1)
+	if version > 1 {
+		mark(size-9, size-9, 5, 5)
+	}
+	return reserved
+}
+
+// Fungsi untuk membaca kembali isi kode QR mode byte: mencocokkan format, membuka mask,
+// membaca codeword zig-zag, memeriksa koreksi kesalahan tiap blok lalu mengurai data
+func decodeQR(t *testing.T, qr *qrCode) (string, int) {
+	t.Helper()
+	version := (qr.size - 17) / 4
+	capacity, ok := qrCapacityL[version]
+	if !ok {
+		t.Fatalf("versi %d (ukuran %d) tidak diuji", version, qr.size)
+	}
+
+	first, second := readQRFormat(qr)
+	if first != second {
+		t.Fatalf("dua salinan informasi format berbeda: %015b dan %015b", first, second)
+	}
+	mask := -1
+	for m, format := range qrFormatL {
+		if format == first {
+			mask = m
+		}
+	}
+	if mask < 0 {
+		t.Fatalf("informasi format %015b bukan level L yang valid", first)
+	}
+	if !qr.modules[qr.size-8][8] {
+		t.Error("modul gelap di samping finder kiri bawah tidak ada")
+	}
+
+	masked := map[int]func(row, col int) bool{
+		0: func(row, col int) bool { return (row+col)%2 == 0 },
+		1: func(row, col int) bool { return row%2 == 0 },
+		2: func(row, col int) bool { return col%3 == 0 },
+		3: func(row, col int) bool { return (row+col)%3 == 0 },
+		4: func(row, col int) bool { return (row/2+col/3)%2 == 0 },
+		5: func(row, col int) bool { return row*col%2+row*col%3 == 0 },
+		6: func(row, col int) bool { return (row*col%2+row*col%3)%2 == 0 },
+		7: func(row, col int) bool { return ((row+col)%2+row*col%3)%2 == 0 },
+	}[mask]
+
+	// Kolom dibaca berpasangan dari kanan, naik dan turun bergantian, melewati kolom timing
+	reserved := qrReservedModules(version)
+	var bits []bool
+	upward := true
+	for right := qr.size - 1; right > 0; right -= 2 {
+		if right == 6 {
+			right--
+		}
+		for k := 0; k < qr.size; k++ {
+			row := k
+			if upward {
+				row = qr.size - 1 - k
+			}
+			for col := right; col > right-2; col-- {
+				if !reserved[row][col] {
+					bits = append(bits, qr.modules[row][col] != masked(row, col))
+				}
+			}
+		}
+		upward = !upward
+	}
+	if len(bits) < capacity.total*8 {
+		t.Fatalf("hanya %d modul data, ingin setidaknya %d", len(bits), capacity.total*8)
+	}
+	codewords := make([]byte, capacity.total)
+	for i := range codewords {
+		for j := 0; j < 8; j++ {
+			if bits[i*8+j] {
+				codewords[i] |= 1 << (7 - j)
+			}
+		}
+	}
+
+	// Blok-blok sama besar, codeword data lalu codeword koreksi diselang-seling
+	dataPerBlock := capacity.data / capacity.blocks
+	ecPerBlock := (capacity.total - capacity.data) / capacity.blocks
+	var data []byte
+	blocks := make([][]byte, capacity.blocks)
+	for b := range blocks {
+		for i := 0; i < dataPerBlock; i++ {
+			blocks[b] = append(blocks[b], codewords[i*capacity.blocks+b])
+		}
+		data = append(data, blocks[b]...)
+		for i := 0; i < ecPerBlock; i++ {
+			blocks[b] = append(blocks[b], codewords[capacity.data+i*capacity.blocks+b])
+		}
+	}
+	// Blok yang benar habis dibagi generator, jadi sindromnya di akar 2^0..2^(ec-1) nol
+	for b, block := range blocks {
+		root := byte(1)
+		for i := 0; i < ecPerBlock; i++ {
+			var syndrome byte
+			for _, c := range block {
+				syndrome = gfMultiply(syndrome, root) ^ c
+			}
+			if syndrome != 0 {
+				t.Fatalf("blok %d: sindrom %d = %#x, koreksi kesalahan salah", b, i, syndrome)
+			}
+			root = gfMultiply(root, 2)
+		}
+	}
+
+	if mode := data[0] >> 4; mode != 0x4 {
+		t.Fatalf("mode %#x, ingin mode byte 0x4", mode)
+	}
+	length := int(data[0]&0x0F)<<4 | int(data[1]>>4)
+	var payload []byte
+	for i := 0; i < length; i++ {
+		payload = append(payload, data[1+i]<<4|data[2+i]>>4)
+	}
+	// Sisa codeword setelah terminator adalah pengisi 0xEC dan 0x11 bergantian
+	for i, pad := 2+length, byte(0xEC); i < len(data); i, pad = i+1, pad^0xEC^0x11 {
+		if data[i] != pad {
+			t.Fatalf("codeword pengisi %d = %#x, ingin %#x", i, data[i], pad)
+		}
+	}
+	return string(payload), version
+}
+
+func TestEncodeQRRoundTrip(t *testing.T) {
+	tests := []struct {
+		payload string
+		version int
+	}{
+		{"ORDER:42", 1},
+		{"https://resto.example/r/1234", 2},
+		{"Struk 0017 | Nasi Goreng x2 Rp 30.000,00 | Es Teh x3 Rp 15.000,00 | Total Rp 45.000,00 | Meja 12 | Terima kasih", 6},
+		{strings.Repeat("A", 134), 6},
+	}
+	for _, tt := range tests {
+		qr, err := encodeQR(tt.payload)
+		if err != nil {
+			t.Fatalf("encodeQR(%q): %v", tt.payload, err)
+		}
+		payload, version := decodeQR(t, qr)
+		if version != tt.version {
+			t.Errorf("encodeQR(%q) memakai versi %d, ingin %d", tt.payload, version, tt.version)
+		}
+		if payload != tt.payload {
+			t.Errorf("isi QR terbaca %q, ingin %q", payload, tt.payload)
+		}
+	}
+}
+
+func TestEncodeQRTooLong(t *testing.T) {
+	if _, err := encodeQR(strings.Repeat("A", 135)); err == nil {
+		t.Error("encodeQR dengan 135 byte berhasil, ingin galat karena melebihi versi 6")
+	}
+}
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Satu item hasil parsing perintah pesanan cepat
+type quickItem struct {
+	Name      string
+	Quantity  int
+	Modifiers []string
+}
+
+// Hasil parsing pesanan cepat, meja 0 berarti bawa pulang
+type quickOrder struct {
+	Items    []quickItem
+	Table    int
+	Guests   int
+	Note     string
+	Delivery bool
+	// Daftar harga dari price_lists, kosong berarti harga menu
+	PriceList string
+}
+
+// Contoh sintaks yang ditampilkan bersama pesan kesalahan
+const quickOrderExample = `contoh: 2 Nasi Goreng +telur, 1 Es Teh meja 4 tamu 2 catatan "tanpa sambal" (atau antar untuk diantar, harga gofood untuk daftar harga lain)`
+
+// Token hasil pemecahan input pesanan cepat
+type quickToken struct {
+	Text   string
+	Quoted bool
+}
+
+// Fungsi untuk memecah input menjadi kata, koma dan teks dalam tanda kutip
+func tokenizeQuickOrder(input string) ([]quickToken, error) {
+	var tokens []quickToken
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, quickToken{Text: word.String()})
+			word.Reset()
+		}
+	}
+
+	for i := 0; i < len(input); i++ {
+		switch c := input[i]; {
+		case c == '"':
+			flush()
+			end := strings.IndexByte(input[i+1:], '"')
+			if end < 0 {
+				return nil, errors.New("tanda kutip pada catatan tidak ditutup")
+			}
+			tokens = append(tokens, quickToken{Text: input[i+1 : i+1+end], Quoted: true})
+			i += end + 1
+		case c == ',':
+			flush()
+			tokens = append(tokens, quickToken{Text: ","})
+		case c == ' ' || c == '\t':
+			flush()
+		default:
+			word.WriteByte(c)
+		}
+	}
+	flush()
+	return tokens, nil
+}
+
+// Fungsi untuk membaca pesanan cepat seperti
+// `2 Nasi Goreng, 1 Es Teh meja 4 tamu 2 catatan "tanpa sambal"`.
+// Jumlah boleh ditulis "2x" atau "2" dan dianggap 1 jika tidak ditulis.
+// Kata kunci antar (tanpa nilai) menandai pesanan yang diantar.
+func parseQuickOrder(input string) (quickOrder, error) {
+	var result quickOrder
+
+	tokens, err := tokenizeQuickOrder(input)
+	if err != nil {
+		return result, err
+	}
+
+	// Daftar item berakhir di kata kunci meja, tamu, catatan, harga atau antar
+	end := len(tokens)
+	for i, token := range tokens {
+		if !token.Quoted && (strings.EqualFold(token.Text, "meja") || strings.EqualFold(token.Text, "tamu") || strings.EqualFold(token.Text, "catatan") || strings.EqualFold(token.Text, "harga") || strings.EqualFold(token.Text, "antar")) {
+			end = i
+			break
+		}
+	}
+
+	var words []string
+	for i := 0; i <= end; i++ {
+		if i < end && tokens[i].Text != "," {
+			if tokens[i].Quoted {
+				return result, fmt.Errorf("teks %q dalam tanda kutip hanya boleh dipakai setelah catatan", tokens[i].Text)
+			}
+			words = append(words, tokens[i].Text)
+			continue
+		}
+
+		item, err := parseQuickItem(words, len(result.Items)+1)
+		if err != nil {
+			return result, err
+		}
+		result.Items = append(result.Items, item)
+		words = nil
+	}
+
+	for i := end; i < len(tokens); i++ {
+		keyword := strings.ToLower(tokens[i].Text)
+		if keyword == "antar" && !tokens[i].Quoted {
+			result.Delivery = true
+			continue
+		}
+		if i+1 >= len(tokens) {
+			return result, fmt.Errorf("%s harus diikuti nilainya", keyword)
+		}
+		value := tokens[i+1]
+		i++
+
+		switch keyword {
+		case "meja":
+			if result.Table != 0 {
+				return result, errors.New("meja ditulis lebih dari sekali")
+			}
+			table, err := strconv.Atoi(value.Text)
+			if err != nil || table <= 0 || value.Quoted {
+				return result, fmt.Errorf("nomor meja %q harus berupa angka positif", value.Text)
+			}
+			result.Table = table
+		case "tamu":
+			guests, err := strconv.Atoi(value.Text)
+			if err != nil || guests <= 0 || value.Quoted {
+				return result, fmt.Errorf("jumlah tamu %q harus berupa angka positif", value.Text)
+			}
+			result.Guests = guests
+		case "catatan":
+			if !value.Quoted {
+				return result, errors.New(`catatan harus diapit tanda kutip, misalnya catatan "tanpa sambal"`)
+			}
+			result.Note = strings.TrimSpace(value.Text)
+		case "harga":
+			result.PriceList = value.Text
+		default:
+			return result, fmt.Errorf("kata %q tidak dikenal, gunakan meja, tamu, catatan, harga atau antar", tokens[i-1].Text)
+		}
+	}
+	if result.Delivery && result.Table != 0 {
+		return result, errors.New("pesanan antar tidak memakai nomor meja")
+	}
+	if result.Guests > 0 && result.Table == 0 {
+		return result, errors.New("jumlah tamu hanya untuk pesanan makan di tempat, tulis juga nomor meja")
+	}
+
+	return result, nil
+}
+
+// Fungsi untuk membaca satu item berupa jumlah opsional diikuti nama item dan tambahan
+// berawalan +, misalnya "2 nasi goreng +telur +keju"
+func parseQuickItem(words []string, position int) (quickItem, error) {
+	if len(words) == 0 {
+		return quickItem{}, fmt.Errorf("item ke-%d kosong, %s", position, quickOrderExample)
+	}
+
+	item := quickItem{Quantity: 1}
+	quantityText := strings.TrimSuffix(strings.ToLower(words[0]), "x")
+	if quantity, err := strconv.Atoi(quantityText); err == nil {
+		if quantity <= 0 {
+			return quickItem{}, fmt.Errorf("jumlah item ke-%d harus positif", position)
+		}
+		if len(words) == 1 {
+			return quickItem{}, fmt.Errorf("item ke-%d hanya berisi jumlah tanpa nama item", position)
+		}
+		item.Quantity = quantity
+		words = words[1:]
+	}
+	for i, word := range words {
+		if !strings.HasPrefix(word, "+") {
+			continue
+		}
+		if i == 0 {
+			return quickItem{}, fmt.Errorf("item ke-%d hanya berisi tambahan tanpa nama item", position)
+		}
+		// Kata setelah + masuk ke tambahan yang sama sampai ada + berikutnya
+		for _, rest := range words[i:] {
+			if strings.HasPrefix(rest, "+") {
+				item.Modifiers = append(item.Modifiers, "")
+				rest = strings.TrimPrefix(rest, "+")
+			}
+			last := &item.Modifiers[len(item.Modifiers)-1]
+			*last = strings.TrimSpace(*last + " " + rest)
+		}
+		words = words[:i]
+		break
+	}
+	item.Name = strings.Join(words, " ")
+	return item, nil
+}
+
+// Fungsi untuk membuat pesanan langsung dari perintah pesanan cepat
+func createQuickOrder(orderID int, input string) *Order {
+	parsed, err := parseQuickOrder(input)
+	if err != nil {
+		fmt.Println("Pesanan cepat tidak valid:", err)
+		return nil
+	}
+
+	order, skipped := placeQuickOrder(orderID, parsed)
+	for _, err := range skipped {
+		fmt.Println(err)
+	}
+	if order == nil {
+		return nil
+	}
+	fmt.Printf("Pesanan ID %d%s dibuat: %s\n", order.ID, describeTable(order.Table, order.Delivery), describeLines(order.Lines))
+	return order
+}
+
+// Fungsi untuk memesan stok semua item pesanan cepat. Item yang gagal dilewati dan
+// alasannya dikembalikan, pesanan bernilai nil jika tidak ada item yang berhasil.
+func placeQuickOrder(orderID int, parsed quickOrder) (*Order, []error) {
+	var skipped []error
+	order := &Order{ID: orderID, Table: parsed.Table, Guests: parsed.Guests, Note: parsed.Note, Delivery: parsed.Delivery}
+	priceList, err := checkPriceList(parsed.PriceList, order.Type())
+	if err != nil {
+		return nil, []error{err}
+	}
+	order.PriceList = priceList
+	for _, item := range parsed.Items {
+		line, err := reserveQuickLine(item, order.Type(), order.PriceList)
+		if err != nil {
+			skipped = append(skipped, err)
+			continue
+		}
+		line.No = len(order.Lines) + 1
+		order.Lines = append(order.Lines, *line)
+		order.TotalPrice += line.TotalPrice
+		recordMovement(line.ItemName, -line.Quantity, MovementSale, orderReference(order.ID))
+		recordModifierSale(line.Modifiers, line.Quantity, MovementSale, orderReference(order.ID))
+	}
+
+	if len(order.Lines) == 0 {
+		return nil, skipped
+	}
+	return order, skipped
+}
+
+// Fungsi untuk memesan stok satu item pesanan cepat dengan harga dari daftar harga pesanan
+func reserveQuickLine(item quickItem, orderType OrderType, priceList string) (*OrderLine, error) {
+	menuMutex.Lock()
+	defer menuMutex.Unlock()
+
+	selectedItem := findMenuItem(item.Name)
+	if selectedItem == nil {
+		return nil, fmt.Errorf("Item %q tidak ditemukan.%s", item.Name, suggestMenuItem(item.Name))
+	}
+	if len(selectedItem.Variants) > 0 {
+		return nil, fmt.Errorf("%s punya varian, tulis salah satu: %s.", selectedItem.Name, describeVariants(selectedItem))
+	}
+	if err := checkOrderType(selectedItem, orderType); err != nil {
+		return nil, err
+	}
+	if err := checkAvailable(selectedItem, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := checkLineQuantity(selectedItem, item.Quantity); err != nil {
+		return nil, err
+	}
+	if item.Quantity > selectedItem.Quantity {
+		return nil, fmt.Errorf("Jumlah %s melebihi stok yang tersedia.", selectedItem.Name)
+	}
+	modifiers, err := resolveModifiers(item.Modifiers)
+	if err != nil {
+		return nil, err
+	}
+	if err := reserveModifierStock(modifiers, item.Quantity); err != nil {
+		return nil, err
+	}
+	if err := selectedItem.removeStock(item.Quantity); err != nil {
+		releaseModifierStock(modifiers, item.Quantity, "")
+		return nil, fmt.Errorf("Gagal mengurangi stok %s: %w", selectedItem.Name, err)
+	}
+
+	price := channelPrice(selectedItem, priceList) + modifiersPrice(modifiers)
+	return &OrderLine{
+		ItemName:   selectedItem.Name,
+		Quantity:   item.Quantity,
+		Price:      price,
+		TotalPrice: price.Times(item.Quantity),
+		Status:     LineQueued,
+		Station:    selectedItem.Station,
+		Modifiers:  modifiers,
+	}, nil
+}
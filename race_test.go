@@ -0,0 +1,7 @@
+//go:build race
+
+package main
+
+// Bernilai true jika test dijalankan dengan -race. Detektor race memperlambat setiap
+// operasi berkali lipat sehingga anggaran kinerja tidak bisa diperiksa.
+const raceEnabled = true
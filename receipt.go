@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fungsi untuk menyusun struk pesanan dalam bentuk teks selebar kertas di receipt_paper,
+// pemanggil harus memegang ordersMutex
+func formatReceipt(order *Order) string {
+	l := newReceiptLayout()
+	writeReceipt(l, order)
+	return l.String()
+}
+
+// Fungsi untuk menulis isi struk pesanan ke penyusun teks, pemanggil harus memegang ordersMutex
+func writeReceipt(l *textLayout, order *Order) {
+	cfg := currentConfig()
+	l.Title(cfg.RestaurantName)
+	l.Line("Pesanan ID %d%s", order.ID, describeTable(order.Table, order.Delivery))
+	if order.PickupCode != "" {
+		l.Pair("Nomor Ambil", order.PickupCode)
+	}
+	l.Pair("Tanggal", formatDateTime(order.CreatedAt))
+	if len(order.MergedFrom) > 0 {
+		ids := make([]string, len(order.MergedFrom))
+		for i, id := range order.MergedFrom {
+			ids[i] = strconv.Itoa(id)
+		}
+		l.Pair("Gabungan dari pesanan ID", strings.Join(ids, ", "))
+	}
+	l.Separator("-")
+	for _, line := range order.Lines {
+		if line.Status == LineCancelled {
+			continue
+		}
+		l.Item(lineLabel(line), fmt.Sprintf("x%d @ %s", line.Quantity, formatMoney(line.Price)), formatMoney(line.Price.Times(line.Quantity)))
+		if line.Returned > 0 {
+			l.Item("  Diretur", fmt.Sprintf("x%d", line.Returned), "-"+formatMoney(line.Price.Times(line.Returned)))
+		}
+	}
+	if order.Note != "" {
+		l.Line("Catatan: %s", order.Note)
+	}
+	writeOrderFields(l, order.Fields)
+	if order.PriceList != "" {
+		l.Pair("Daftar Harga", order.PriceList)
+	}
+	l.Separator("-")
+	l.Pair("Subtotal", formatMoney(order.TotalPrice))
+	if order.Discount > 0 {
+		l.Pair("Diskon", "-"+formatMoney(order.Discount))
+		if approval := describeDiscountApproval(order.DiscountApproval); approval != "" {
+			l.Line("  (%s)", approval)
+		}
+	}
+	if cfg.TaxRate > 0 {
+		l.Pair(fmt.Sprintf("Pajak (%s)", formatPercent(cfg.TaxRate)), formatMoney(order.Tax()))
+	}
+	writeTableCharges(l, order.CoverCharge, order.MinimumSpendTopUp)
+	l.Pair("Total", formatMoney(order.AmountDue()))
+
+	status := "BELUM DIBAYAR"
+	if order.Voided {
+		status = "DIBATALKAN"
+	} else if order.Paid {
+		status = fmt.Sprintf("LUNAS (%s)", order.Payment().Label())
+	}
+	l.Pair("Status", status)
+	// Tagihan yang dibayar sebagian atau dengan beberapa metode dirinci per pembayaran
+	for _, payment := range order.TabPayments {
+		l.Pair(fmt.Sprintf("Bayar %s %s", payment.Method.Label(), payment.Time.Local().Format("15:04")), formatMoney(payment.Amount))
+	}
+	if !order.Paid && !order.Voided && len(order.TabPayments) > 0 {
+		l.Pair("Sisa", formatMoney(order.TabBalance()))
+	}
+	if order.Paid && order.CashTendered > 0 {
+		l.Pair("Tunai", formatMoney(order.CashTendered))
+		l.Pair("Kembalian", formatMoney(order.CashChange()))
+	}
+}
+
+// Fungsi untuk menyusun isi QR struk: alamat pesanan di server API selama mode serve
+// berjalan dan receipt_lookup_url diatur, selain itu nomor pesanan yang bisa dicari kasir
+func receiptQRPayload(id int) string {
+	base := currentConfig().ReceiptLookupURL
+	if base == "" || !apiServerRunning.Load() {
+		return fmt.Sprintf("PESANAN %d", id)
+	}
+	return fmt.Sprintf("%s/orders/%d", strings.TrimRight(base, "/"), id)
+}
+
+// Fungsi untuk mencetak ulang struk pesanan yang sudah ada dengan tanda COPY, misalnya
+// untuk pelanggan yang meminta struk kedua. Setiap cetak ulang dicatat di log aktivitas.
+// ID pesanan boleh ditulis langsung setelah opsi, misalnya "41 12" atau "41 meja 3".
+func reprintReceipt(reader *bufio.Reader, args string) {
+	ref := strings.TrimSpace(args)
+	if ref == "" {
+		fmt.Print("Masukkan ID pesanan atau meja <nomor>: ")
+		input, _ := reader.ReadString('\n')
+		ref = strings.TrimSpace(input)
+	}
+
+	activityMutex.Lock()
+	cashier := currentCashier
+	activityMutex.Unlock()
+
+	ordersMutex.Lock()
+	order, err := findOrderRef(ref)
+	if err != nil {
+		ordersMutex.Unlock()
+		fmt.Println(err)
+		return
+	}
+	order = followMergedOrder(order)
+	id := order.ID
+	l := newReceiptLayout()
+	l.Banner("COPY", "*")
+	writeReceipt(l, order)
+	ordersMutex.Unlock()
+	l.Blank()
+	l.QRCode(receiptQRPayload(id))
+	l.Pair("Dicetak ulang", fmt.Sprintf("%s oleh %s", formatDateTime(time.Now()), cashier))
+	l.Banner("COPY", "*")
+
+	fmt.Println()
+	fmt.Print(l.String())
+	logActivity(fmt.Sprintf("cetak ulang struk pesanan %d", id))
+}
+
+// Fungsi untuk mencetak tagihan sementara (pro-forma) pesanan yang belum dibayar agar
+// pelanggan bisa memeriksanya sebelum membayar. Tagihan ini bukan struk pembayaran dan
+// ditandai jelas belum dibayar. ID pesanan boleh ditulis langsung setelah opsi,
+// misalnya "bill 12" atau "bill meja 3".
+func printBill(reader *bufio.Reader, args string) {
+	ref := strings.TrimSpace(args)
+	if ref == "" {
+		fmt.Print("Masukkan ID pesanan atau meja <nomor>: ")
+		input, _ := reader.ReadString('\n')
+		ref = strings.TrimSpace(input)
+	}
+
+	activityMutex.Lock()
+	cashier := currentCashier
+	activityMutex.Unlock()
+
+	ordersMutex.Lock()
+	order, err := findOrderRef(ref)
+	if err != nil {
+		ordersMutex.Unlock()
+		fmt.Println(err)
+		return
+	}
+	order = followMergedOrder(order)
+	if order.Paid || order.Voided {
+		ordersMutex.Unlock()
+		fmt.Println("Pesanan sudah dibayar atau dibatalkan, gunakan Cetak Ulang Struk.")
+		return
+	}
+	if order.Tab != "" {
+		ordersMutex.Unlock()
+		fmt.Printf("Pesanan ada di tab %s, tagihannya dilihat lewat menu Tab Pelanggan.\n", order.Tab)
+		return
+	}
+	id := order.ID
+	// Biaya cover dan minimum belanja dihitung seperti saat meja dibayar agar totalnya sama
+	applyTableCharges(order)
+	l := newReceiptLayout()
+	l.Banner("TAGIHAN SEMENTARA", "*")
+	writeReceipt(l, order)
+	ordersMutex.Unlock()
+	l.Blank()
+	l.Line("BUKAN BUKTI PEMBAYARAN")
+	l.Pair("Dicetak", fmt.Sprintf("%s oleh %s", formatDateTime(time.Now()), cashier))
+	l.Banner("BELUM DIBAYAR", "*")
+
+	fmt.Println()
+	fmt.Print(l.String())
+	logActivity(fmt.Sprintf("cetak tagihan sementara pesanan %d", id))
+}
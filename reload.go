@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Waktu modifikasi terakhir file yang dipantau, dipakai untuk mendeteksi
+// perubahan yang dibuat di luar program (misalnya lewat editor teks)
+var watchedFiles = map[string]time.Time{}
+
+// Melindungi config karena penjadwal membacanya dari goroutine lain
+var configMutex sync.RWMutex
+
+// Fungsi untuk mengambil salinan konfigurasi yang berlaku dari goroutine mana pun
+func currentConfig() Config {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	return config
+}
+
+// Fungsi untuk mengembalikan daftar file yang perubahannya diterapkan tanpa restart
+func watchedPaths() []string {
+	paths := []string{configFile}
+	// Menu hanya bisa diedit langsung pada penyimpanan JSON
+	if store, ok := localMenuRepo().(*jsonStore); ok {
+		paths = append(paths, filepath.Join(store.dir, "menu.json"))
+	}
+	return paths
+}
+
+// Fungsi untuk mengambil waktu modifikasi file, nol jika file belum ada
+func fileModTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// Fungsi untuk mencatat waktu modifikasi file yang dipantau, dipanggil setelah
+// program sendiri menulis file agar tidak dianggap perubahan dari luar
+func rememberWatchedFiles() {
+	for _, path := range watchedPaths() {
+		watchedFiles[path] = fileModTime(path)
+	}
+}
+
+// Fungsi untuk memeriksa apakah ada file yang dipantau diubah dari luar dan belum
+// diterapkan, pemanggil harus memegang saveMutex
+func watchedFilesChanged() bool {
+	for _, path := range watchedPaths() {
+		if !fileModTime(path).Equal(watchedFiles[path]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Fungsi untuk menerapkan perubahan config.json dan menu.json yang dibuat di luar
+// program. Dipanggil di awal setiap perintah agar tidak bertabrakan dengan perintah yang berjalan.
+func reloadChangedFiles() {
+	saveMutex.Lock()
+	defer saveMutex.Unlock()
+
+	for _, path := range watchedPaths() {
+		modTime := fileModTime(path)
+		if modTime.Equal(watchedFiles[path]) {
+			continue
+		}
+		watchedFiles[path] = modTime
+
+		if path == configFile {
+			reloadConfig()
+		} else {
+			reloadMenu()
+		}
+	}
+}
+
+// Fungsi untuk memuat ulang konfigurasi. Pengaturan penyimpanan tidak bisa
+// diganti saat program berjalan sehingga tetap memakai nilai lama. Konfigurasi baru
+// disusun dan diperiksa lebih dulu agar pembaca tidak pernah melihat penyimpanan lain.
+func reloadConfig() {
+	loaded, found, err := readConfig(configFile)
+	if err != nil {
+		fmt.Println("\nPerubahan konfigurasi diabaikan:", err)
+		return
+	}
+	if !found {
+		return
+	}
+
+	previous := currentConfig()
+	if loaded.Storage != previous.Storage || loaded.DataDir != previous.DataDir || loaded.DatabaseURL != previous.DatabaseURL {
+		fmt.Println("\nPerubahan pengaturan penyimpanan baru berlaku setelah program dijalankan ulang.")
+		loaded.Storage, loaded.DataDir, loaded.DatabaseURL = previous.Storage, previous.DataDir, previous.DatabaseURL
+	}
+
+	configMutex.Lock()
+	config = loaded
+	configMutex.Unlock()
+	fmt.Println("\nKonfigurasi dimuat ulang.")
+}
+
+// Fungsi untuk memuat ulang menu dari file yang diedit di luar program
+func reloadMenu() {
+	items, err := menuRepo.LoadMenu()
+	if err != nil {
+		fmt.Println("\nPerubahan menu diabaikan:", err)
+		return
+	}
+	if items == nil {
+		return
+	}
+
+	menuMutex.Lock()
+	menu = items
+	invalidateMenuCache()
+	menuMutex.Unlock()
+	fmt.Printf("\nMenu dimuat ulang (%d item).\n", len(items))
+}
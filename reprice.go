@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Contoh perintah yang ditampilkan bersama pesan kesalahan
+const repriceExample = "contoh: menu reprice +10% stasiun=bar, menu reprice -2000 item=ayam, menu reprice bulat=500"
+
+// Struct untuk perintah ubah harga massal
+type repriceQuery struct {
+	Percent float64 // kenaikan dalam persen, negatif untuk penurunan
+	Amount  Money   // kenaikan nominal, negatif untuk penurunan
+	RoundTo Money   // harga dibulatkan ke atas ke kelipatan ini, 0 berarti tanpa pembulatan
+	Station Station
+	Item    string // potongan nama item, tanpa membedakan huruf besar kecil
+}
+
+// Struct untuk satu baris pratinjau perubahan harga
+type repriceChange struct {
+	Item     MenuItem
+	NewPrice Money
+}
+
+// Fungsi untuk membaca perintah ubah harga massal, misalnya "+10% stasiun=bar bulat=500"
+func parseRepriceQuery(input string) (repriceQuery, error) {
+	var query repriceQuery
+	for _, field := range strings.Fields(input) {
+		if key, value, ok := strings.Cut(field, "="); ok {
+			switch strings.ToLower(key) {
+			case "stasiun":
+				station, ok := parseStation(value)
+				if !ok {
+					return query, fmt.Errorf("stasiun %q tidak dikenal", value)
+				}
+				query.Station = station
+			case "item":
+				query.Item = strings.ToLower(value)
+			case "bulat":
+				step, err := parseMoneyInput(value)
+				if err != nil || step <= 0 {
+					return query, fmt.Errorf("bulat harus berupa angka positif, misalnya bulat=500")
+				}
+				query.RoundTo = step
+			default:
+				return query, fmt.Errorf("filter %q tidak dikenal", key)
+			}
+			continue
+		}
+
+		if query.Percent != 0 || query.Amount != 0 {
+			return query, errors.New("hanya satu perubahan harga per perintah")
+		}
+		if !strings.HasPrefix(field, "+") && !strings.HasPrefix(field, "-") {
+			return query, fmt.Errorf("perubahan %q harus diawali + atau -", field)
+		}
+		number, percent := strings.CutSuffix(field, "%")
+		normalized, err := normalizeNumberInput(number)
+		var value float64
+		if err == nil {
+			value, err = strconv.ParseFloat(normalized, 64)
+		}
+		if err != nil || value == 0 {
+			return query, fmt.Errorf("perubahan %q tidak valid", field)
+		}
+		if percent {
+			if value <= -100 {
+				return query, errors.New("penurunan harus kurang dari 100%")
+			}
+			query.Percent = value
+		} else {
+			query.Amount = moneyFromFloat(value)
+		}
+	}
+
+	if query.Percent == 0 && query.Amount == 0 && query.RoundTo == 0 {
+		return query, errors.New("tidak ada perubahan harga")
+	}
+	return query, nil
+}
+
+// Fungsi untuk menghitung harga baru satu item. Hasil persen dibulatkan ke sen lebih dulu
+// lalu dibulatkan ke atas ke kelipatan bulat=.
+func (query repriceQuery) apply(price Money) Money {
+	price += price.MulRate(query.Percent / 100)
+	price += query.Amount
+	if query.RoundTo > 0 {
+		steps := price / query.RoundTo
+		if price%query.RoundTo > 0 {
+			steps++
+		}
+		price = steps * query.RoundTo
+	}
+	return price
+}
+
+// Fungsi untuk menyusun daftar item yang harganya berubah. Item yang punya varian
+// diwakili variannya. Pemanggil harus memegang menuMutex.
+func planReprice(query repriceQuery) []repriceChange {
+	var changes []repriceChange
+	for _, item := range stockItems() {
+		station := item.Station
+		if parent := variantParent(item); station == "" && parent != nil {
+			station = parent.Station
+		}
+		if query.Station != "" && station != query.Station {
+			continue
+		}
+		if query.Item != "" && !strings.Contains(strings.ToLower(item.Name), query.Item) {
+			continue
+		}
+		if price := query.apply(item.Price); price != item.Price {
+			changes = append(changes, repriceChange{Item: copyMenuItem(*item), NewPrice: price})
+		}
+	}
+	return changes
+}
+
+// Fungsi untuk menjalankan perintah `menu reprice`: menampilkan pratinjau harga lama
+// dan baru, lalu menyimpan perubahan setelah dikonfirmasi
+func repriceMenu(reader *bufio.Reader, args string) {
+	args = strings.TrimSpace(args)
+	if rest, ok := strings.CutPrefix(args, "reprice"); ok {
+		args = strings.TrimSpace(rest)
+	}
+	if args == "" {
+		fmt.Println("Perubahan: +N% / -N% / +N / -N, filter: stasiun=, item=, pembulatan: bulat=")
+		fmt.Print("Masukkan perubahan harga: ")
+		input, _ := reader.ReadString('\n')
+		args = strings.TrimSpace(input)
+	}
+
+	query, err := parseRepriceQuery(args)
+	if err != nil {
+		fmt.Printf("Perintah tidak valid: %v\n%s\n", err, repriceExample)
+		return
+	}
+
+	menuMutex.Lock()
+	changes := planReprice(query)
+	menuMutex.Unlock()
+	if len(changes) == 0 {
+		fmt.Println("Tidak ada harga yang berubah.")
+		return
+	}
+
+	fmt.Println("\n===== Pratinjau Perubahan Harga =====")
+	fmt.Printf("%-24s | %16s | %16s | %16s\n", "Item", "Harga Lama", "Harga Baru", "Selisih")
+	for _, change := range changes {
+		fmt.Printf("%-24s | %16s | %16s | %16s\n", change.Item.Name, formatMoney(change.Item.Price), formatMoney(change.NewPrice), formatMoneyChange(change.NewPrice-change.Item.Price))
+	}
+	for _, change := range changes {
+		if change.NewPrice <= 0 {
+			fmt.Printf("Harga %s menjadi %s, perubahan dibatalkan.\n", change.Item.Name, formatMoney(change.NewPrice))
+			return
+		}
+	}
+
+	fmt.Printf("Terapkan %d perubahan harga? (y/n): ", len(changes))
+	confirm, _ := reader.ReadString('\n')
+	if !strings.EqualFold(strings.TrimSpace(confirm), "y") {
+		fmt.Println("Perubahan harga dibatalkan.")
+		return
+	}
+
+	// Item yang sudah diubah pihak lain sejak pratinjau dilewati, bukan ditimpa
+	updatedCount := 0
+	for _, change := range changes {
+		edited := change.Item
+		edited.Price = change.NewPrice
+		updated, err := activeMenuRepo().UpdateMenuItem(edited)
+		var conflict *VersionConflictError
+		if errors.As(err, &conflict) {
+			fmt.Printf("%s dilewati karena sudah diubah pihak lain (harga sekarang %s).\n", edited.Name, formatMoney(conflict.Current.Price))
+			continue
+		}
+		if err != nil {
+			fmt.Printf("Gagal menyimpan %s: %v\n", edited.Name, err)
+			continue
+		}
+		applyMenuItemEdit(updated)
+		updatedCount++
+	}
+
+	logActivity(fmt.Sprintf("ubah harga massal %q: %d item", args, updatedCount))
+	fmt.Printf("%d harga item diperbarui.\n", updatedCount)
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Nama file catatan retur di folder data. Jumlah yang diretur juga tersimpan di baris
+// pesanan, tetapi refund hanya dicatat di sini sehingga harus ikut disimpan.
+const returnsFile = "returns.json"
+
+// Struct untuk mencatat retur item pesanan
+type ReturnRecord struct {
+	OrderID  int       `json:"order_id"`
+	LineNo   int       `json:"line_no"`
+	ItemName string    `json:"item"`
+	Quantity int       `json:"quantity"`
+	Amount   Money     `json:"amount"`
+	Reason   string    `json:"reason"`
+	Refunded bool      `json:"refunded"`
+	Time     time.Time `json:"time"`
+}
+
+// Catatan semua retur, termasuk refund untuk pesanan yang sudah dibayar, dibaca dari
+// file saat pertama kali dipakai
+var returns []ReturnRecord
+var returnsLoaded bool
+var returnsMutex sync.Mutex
+
+// Fungsi untuk membaca catatan retur dari file jika belum dibaca. Pada storage memory
+// catatan retur hanya ada selama program berjalan. Pemanggil harus memegang returnsMutex.
+func ensureReturnsLoaded() {
+	if returnsLoaded {
+		return
+	}
+	returnsLoaded = true
+	if currentConfig().Storage == StorageMemory {
+		return
+	}
+	if _, err := readJSONFile(filepath.Join(currentConfig().DataDir, returnsFile), &returns); err != nil {
+		fmt.Println("Gagal membaca catatan retur:", err)
+	}
+}
+
+// Fungsi untuk menyimpan catatan retur ke file, pemanggil harus memegang returnsMutex
+func saveReturns() {
+	if currentConfig().Storage == StorageMemory {
+		return
+	}
+	if err := writeJSONFile(filepath.Join(currentConfig().DataDir, returnsFile), returns); err != nil {
+		fmt.Println("Gagal menyimpan catatan retur:", err)
+	}
+}
+
+// Fungsi untuk meretur item dari pesanan, misalnya minuman yang belum dibuka
+func returnOrderLine(reader *bufio.Reader) {
+	id, ok := readOrderID(reader)
+	if !ok {
+		return
+	}
+
+	ordersMutex.Lock()
+	order := followMergedOrder(findOrder(id))
+	if order != nil {
+		id = order.ID
+	}
+	if order == nil {
+		ordersMutex.Unlock()
+		fmt.Println("Pesanan tidak ditemukan.")
+		return
+	}
+	for _, line := range order.Lines {
+		fmt.Printf("%d. %s x%d | Diretur: %d | Status: %s\n", line.No, line.ItemName, line.Quantity, line.Returned, line.Status.Label())
+	}
+	ordersMutex.Unlock()
+
+	fmt.Print("Masukkan nomor baris yang diretur: ")
+	lineInput, _ := reader.ReadString('\n')
+	lineNo, err := strconv.Atoi(strings.TrimSpace(lineInput))
+	if err != nil {
+		fmt.Println("Nomor baris harus berupa angka.")
+		return
+	}
+
+	fmt.Print("Masukkan jumlah yang diretur: ")
+	quantityInput, _ := reader.ReadString('\n')
+	quantity, err := parseQuantityInput(quantityInput)
+	if err != nil || quantity <= 0 {
+		fmt.Println("Jumlah harus berupa angka positif.")
+		return
+	}
+
+	fmt.Print("Alasan retur: ")
+	reason, _ := reader.ReadString('\n')
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		fmt.Println("Alasan retur wajib diisi.")
+		return
+	}
+
+	ordersMutex.Lock()
+	var line *OrderLine
+	for i := range order.Lines {
+		if order.Lines[i].No == lineNo {
+			line = &order.Lines[i]
+		}
+	}
+	if line == nil {
+		ordersMutex.Unlock()
+		fmt.Println("Baris pesanan tidak ditemukan.")
+		return
+	}
+	// Hanya item yang sudah diproses dapur yang sudah masuk total dan bisa diretur
+	if line.Status != LineDone {
+		ordersMutex.Unlock()
+		fmt.Println("Hanya item yang sudah selesai diproses yang bisa diretur.")
+		return
+	}
+	if quantity > line.Quantity-line.Returned {
+		ordersMutex.Unlock()
+		fmt.Println("Jumlah retur melebihi jumlah yang dipesan.")
+		return
+	}
+
+	amount := line.Price.Times(quantity)
+	line.Returned += quantity
+	record := ReturnRecord{
+		OrderID:  order.ID,
+		LineNo:   line.No,
+		ItemName: line.ItemName,
+		Quantity: quantity,
+		Amount:   amount,
+		Reason:   reason,
+		Refunded: order.Paid,
+		Time:     time.Now(),
+	}
+	// Tagihan yang belum dibayar langsung disesuaikan, yang sudah dibayar dikembalikan lewat refund
+	if !order.Paid {
+		line.TotalPrice -= amount
+		order.TotalPrice -= amount
+	}
+	pending := order.PendingAck
+	modifiers := line.Modifiers
+	ordersMutex.Unlock()
+
+	menuMutex.Lock()
+	if item := findMenuItem(record.ItemName); item != nil {
+		if err := item.addStock(quantity, time.Time{}); err != nil {
+			fmt.Println("Gagal mengembalikan stok:", err)
+		} else {
+			recordMovement(item.Name, quantity, MovementReturn, orderReference(record.OrderID)+": "+reason)
+		}
+	}
+	releaseModifierStock(modifiers, quantity, orderReference(record.OrderID)+": "+reason)
+	menuMutex.Unlock()
+
+	if !pending {
+		totalMutex.Lock()
+		totalAllOrders -= amount
+		totalMutex.Unlock()
+	}
+
+	returnsMutex.Lock()
+	ensureReturnsLoaded()
+	returns = append(returns, record)
+	saveReturns()
+	returnsMutex.Unlock()
+
+	if record.Refunded {
+		fmt.Printf("Refund %s untuk %s x%d dicatat (alasan: %s).\n", formatMoney(amount), record.ItemName, quantity, reason)
+	} else {
+		fmt.Printf("Tagihan pesanan ID %d dikurangi %s untuk %s x%d (alasan: %s).\n", order.ID, formatMoney(amount), record.ItemName, quantity, reason)
+	}
+}
+
+// Fungsi untuk menghitung total refund yang sudah dicatat
+func totalRefunds() Money {
+	returnsMutex.Lock()
+	defer returnsMutex.Unlock()
+
+	ensureReturnsLoaded()
+	var total Money
+	for _, record := range returns {
+		if record.Refunded {
+			total += record.Amount
+		}
+	}
+	return total
+}
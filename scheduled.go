@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Interval pengecekan pesanan terjadwal yang sudah waktunya masuk dapur
+const schedulerInterval = 10 * time.Second
+
+// Fungsi untuk membuat pesanan dengan waktu ambil di masa depan
+func createScheduledOrder(reader *bufio.Reader, orderID int) *Order {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Println("Terjadi kesalahan:", r)
+		}
+	}()
+
+	table, delivery, ok := readTableNumber(reader)
+	if !ok {
+		return nil
+	}
+
+	fmt.Print("Masukkan waktu ambil (HH:MM atau YYYY-MM-DD HH:MM): ")
+	pickupInput, _ := reader.ReadString('\n')
+	pickupAt, err := parsePickupTime(strings.TrimSpace(pickupInput), time.Now())
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+
+	guests, ok := readGuestCount(reader, table)
+	if !ok {
+		return nil
+	}
+
+	order := &Order{ID: orderID, Table: table, PickupAt: pickupAt, Guests: guests, Delivery: delivery}
+	if order.PriceList, ok = readPriceList(reader, order.Type()); !ok {
+		return nil
+	}
+	readOrderLines(reader, order, false)
+
+	if len(order.Lines) == 0 || !confirmOrder(reader, order, false) {
+		return nil
+	}
+
+	lead := time.Duration(currentConfig().PreOrderLeadMinutes) * time.Minute
+	fmt.Printf("Pesanan terjadwal ID %d dibuat, masuk dapur pada %s.\n", order.ID, formatDateTime(pickupAt.Add(-lead)))
+	return order
+}
+
+// Fungsi untuk membaca waktu ambil, format jam saja berarti hari ini
+func parsePickupTime(input string, now time.Time) (time.Time, error) {
+	pickupAt, err := time.ParseInLocation("2006-01-02 15:04", input, time.Local)
+	if err != nil {
+		clock, clockErr := time.ParseInLocation("15:04", input, time.Local)
+		if clockErr != nil {
+			return time.Time{}, errors.New("Format waktu ambil tidak valid.")
+		}
+		pickupAt = time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), 0, 0, time.Local)
+	}
+
+	if !pickupAt.After(now) {
+		return time.Time{}, errors.New("Waktu ambil harus di masa depan.")
+	}
+	return pickupAt, nil
+}
+
+// Fungsi untuk menampilkan waktu ambil pada daftar pesanan
+func describePickup(pickupAt time.Time) string {
+	if pickupAt.IsZero() {
+		return ""
+	}
+	return " | Ambil: " + formatDateTime(pickupAt)
+}
+
+// Fungsi untuk menjalankan penjadwal di goroutine terpisah, mengembalikan fungsi
+// untuk menghentikannya sebelum antrian dapur ditutup
+func startScheduler() func() {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(schedulerInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case now := <-ticker.C:
+				dryRunMutex.Lock()
+				releaseScheduledOrders(now)
+				dryRunMutex.Unlock()
+				retryPendingEmails(now)
+				checkOrderSLA(now)
+				autoAcknowledgeOrders(now)
+				generateScheduledReport(now)
+				syncWithCentral(now, false, true)
+				autoSave(now)
+			}
+		}
+	}()
+
+	return func() {
+		close(stop)
+		<-done
+	}
+}
+
+// Fungsi untuk memesan stok dan mengirim pesanan terjadwal yang sudah masuk waktu persiapan
+func releaseScheduledOrders(now time.Time) {
+	lead := time.Duration(currentConfig().PreOrderLeadMinutes) * time.Minute
+
+	type dueOrder struct {
+		id    int
+		lines []OrderLine
+	}
+
+	ordersMutex.Lock()
+	var due []dueOrder
+	for _, order := range orders {
+		if order.PickupAt.IsZero() || now.Before(order.PickupAt.Add(-lead)) {
+			continue
+		}
+		var lines []OrderLine
+		for _, line := range order.Lines {
+			if line.Status == LineScheduled {
+				lines = append(lines, line)
+			}
+		}
+		if len(lines) > 0 {
+			due = append(due, dueOrder{id: order.ID, lines: lines})
+		}
+	}
+	ordersMutex.Unlock()
+
+	for _, d := range due {
+		var reserved []OrderLine
+		cancelled := map[int]bool{}
+
+		menuMutex.Lock()
+		for _, line := range d.lines {
+			item := findMenuItem(line.ItemName)
+			if item == nil || item.Quantity < line.Quantity {
+				asyncPrintf("Stok %s tidak cukup untuk pesanan terjadwal ID %d, item dibatalkan.\n", line.ItemName, d.id)
+				cancelled[line.No] = true
+				continue
+			}
+			if err := reserveModifierStock(line.Modifiers, line.Quantity); err != nil {
+				asyncPrintf("%v Item %s pesanan terjadwal ID %d dibatalkan.\n", err, line.ItemName, d.id)
+				cancelled[line.No] = true
+				continue
+			}
+			if err := item.removeStock(line.Quantity); err != nil {
+				releaseModifierStock(line.Modifiers, line.Quantity, "")
+				asyncPrintf("Gagal memesan stok %s untuk pesanan terjadwal ID %d: %v, item dibatalkan.\n", line.ItemName, d.id, err)
+				cancelled[line.No] = true
+				continue
+			}
+			recordMovement(item.Name, -line.Quantity, MovementReservation, orderReference(d.id))
+			recordModifierSale(line.Modifiers, line.Quantity, MovementReservation, orderReference(d.id))
+			reserved = append(reserved, line)
+		}
+		menuMutex.Unlock()
+
+		ordersMutex.Lock()
+		if order := findOrder(d.id); order != nil {
+			for i := range order.Lines {
+				line := &order.Lines[i]
+				if line.Status != LineScheduled {
+					continue
+				}
+				if cancelled[line.No] {
+					line.Status = LineCancelled
+					order.TotalPrice -= line.TotalPrice
+					line.TotalPrice = 0
+				} else {
+					line.Status = LineQueued
+				}
+				publishLineStatus(order.ID, *line)
+			}
+		}
+		ordersMutex.Unlock()
+
+		if len(reserved) > 0 {
+			processingPrintf("Pesanan terjadwal ID %d masuk antrian dapur.\n", d.id)
+			sendToKitchen(d.id, reserved)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Sumber input dari file skrip. Setiap Read hanya mengembalikan satu baris dan
+// menampilkannya di layar, sehingga hasil skrip terbaca seperti sesi kasir biasa.
+// Baris yang diawali # adalah komentar dan dilewati.
+type scriptSource struct {
+	reader  *bufio.Reader
+	pending []byte
+}
+
+// Fungsi untuk membuka file skrip berisi input kasir, satu input per baris
+func openScript(path string) (*scriptSource, io.Closer, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &scriptSource{reader: bufio.NewReader(file)}, file, nil
+}
+
+func (s *scriptSource) Read(p []byte) (int, error) {
+	for len(s.pending) == 0 {
+		line, err := s.reader.ReadString('\n')
+		if line == "" && err != nil {
+			return 0, err
+		}
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		if !strings.HasSuffix(line, "\n") {
+			line += "\n"
+		}
+		fmt.Print(line)
+		s.pending = []byte(line)
+	}
+
+	n := copy(p, s.pending)
+	s.pending = s.pending[n:]
+	return n, nil
+}
+
+// Fungsi untuk menyiapkan mode skrip agar hasilnya tidak bergantung pada data yang sudah
+// ada: config.json tidak dibaca, data disimpan di memori dan folder sementara, menu
+// memakai menu bawaan dan wizard pengaturan awal dilewati. Fungsi yang dikembalikan
+// menghapus folder sementara setelah skrip selesai.
+func prepareScriptSandbox() (func(), error) {
+	dir, err := os.MkdirTemp("", "restoran-script-")
+	if err != nil {
+		return nil, err
+	}
+
+	settings := defaultConfig()
+	settings.Storage = StorageMemory
+	settings.DataDir = dir
+	settings.DefaultMenuFile = ""
+	configMutex.Lock()
+	config = settings
+	configMutex.Unlock()
+	return func() { os.RemoveAll(dir) }, nil
+}
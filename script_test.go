@@ -0,0 +1,87 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Variabel lingkungan yang membuat binary test berjalan sebagai program kasir, dipakai
+// untuk memutar skrip lewat -script persis seperti dari baris perintah
+const runMainEnv = "RESTORAN_TEST_RUN_MAIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(runMainEnv) == "1" {
+		main()
+		os.Exit(0)
+	}
+	code := m.Run()
+	if benchDataDir != "" {
+		os.RemoveAll(benchDataDir)
+	}
+	os.Exit(code)
+}
+
+// Fungsi untuk menjalankan skrip kasir di folder kerja dir dan mengembalikan keluarannya
+func runScript(t *testing.T, dir, script string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "skrip.txt")
+	if err := os.WriteFile(path, []byte(script), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(os.Args[0], "-script", path)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), runMainEnv+"=1")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("skrip gagal: %v\n%s", err, output)
+	}
+	return string(output)
+}
+
+func TestScriptOrderAndMenu(t *testing.T) {
+	output := runScript(t, t.TempDir(), `# pesanan cepat lalu periksa stok
+o 2x nasi goreng
+1
+`)
+
+	for _, want := range []string{
+		"Pesanan ID 1 (bawa pulang) dibuat: Nasi Goreng x2",
+		"Nama: Nasi Goreng | Harga: Rp 15.000,00 | Stok: 8 |",
+		"Skrip selesai.",
+		"Pesanan ID 1: Nasi Goreng x2 telah diproses.",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("keluaran skrip tidak memuat %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestScriptIgnoresExistingState(t *testing.T) {
+	// config.json dan data yang sudah ada tidak boleh memengaruhi atau diubah oleh skrip
+	dir := t.TempDir()
+	settings := `{"storage":"json","currency":"USD","data_dir":"data"}`
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte(settings), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	output := runScript(t, dir, "1\n")
+	if strings.Contains(output, "Pengaturan Awal") {
+		t.Errorf("wizard pengaturan awal berjalan di mode skrip:\n%s", output)
+	}
+	if !strings.Contains(output, "Harga: Rp 15.000,00") {
+		t.Errorf("skrip tidak memakai konfigurasi dan menu bawaan:\n%s", output)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "data")); !os.IsNotExist(err) {
+		t.Errorf("skrip menulis ke folder data di folder kerja: %v", err)
+	}
+
+	// Tanpa config.json wizard juga tidak boleh memakan baris skrip
+	output = runScript(t, t.TempDir(), "1\n")
+	if strings.Contains(output, "Pengaturan Awal") || !strings.Contains(output, "===== Menu =====") {
+		t.Errorf("baris skrip tidak dijalankan sebagai perintah:\n%s", output)
+	}
+}
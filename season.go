@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Fungsi untuk memeriksa apakah item punya masa musim
+func (item *MenuItem) Seasonal() bool {
+	return !item.SeasonStart.IsZero() || !item.SeasonEnd.IsZero()
+}
+
+// Fungsi untuk memeriksa apakah waktu tertentu berada di dalam musim item. Tanggal
+// selesai ikut dihitung sampai akhir hari itu.
+func (item *MenuItem) InSeason(now time.Time) bool {
+	if !item.SeasonStart.IsZero() && now.Before(item.SeasonStart) {
+		return false
+	}
+	if !item.SeasonEnd.IsZero() && !now.Before(item.SeasonEnd.AddDate(0, 0, 1)) {
+		return false
+	}
+	return true
+}
+
+// Fungsi untuk mengambil item yang menentukan musim. Varian tanpa musim sendiri ikut
+// musim item induknya. Pemanggil harus memegang menuMutex.
+func seasonSource(item *MenuItem) *MenuItem {
+	if item.Seasonal() {
+		return item
+	}
+	if parent := variantParent(item); parent != nil {
+		return parent
+	}
+	return item
+}
+
+// Fungsi untuk memeriksa apakah item musiman sedang bisa dipesan. Item yang dipaksa
+// tampil tetap bisa dipesan di luar musim. Pemanggil harus memegang menuMutex.
+func checkSeason(item *MenuItem, now time.Time) error {
+	source := seasonSource(item)
+	if source.ForceShow || source.InSeason(now) {
+		return nil
+	}
+	return fmt.Errorf("%s adalah item musiman dan sedang tidak tersedia (%s).", item.Name, describeSeasonDates(source))
+}
+
+// Fungsi untuk mengambil item yang bisa dipesan saat ini, dipakai untuk daftar bernomor
+// agar nomor yang ditampilkan sama dengan nomor yang dipilih. Pemanggil harus memegang menuMutex.
+func orderableItems(now time.Time) []*MenuItem {
+	var result []*MenuItem
+	for _, item := range stockItems() {
+		if checkAvailable(item, now) == nil {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// Fungsi untuk menampilkan rentang musim, misalnya "01/12/2026 - 31/12/2026"
+func describeSeasonDates(item *MenuItem) string {
+	start, end := "...", "..."
+	if !item.SeasonStart.IsZero() {
+		start = formatDate(item.SeasonStart)
+	}
+	if !item.SeasonEnd.IsZero() {
+		end = formatDate(item.SeasonEnd)
+	}
+	return start + " - " + end
+}
+
+// Fungsi untuk menampilkan keterangan musim di daftar menu, kosong jika item tidak musiman
+func describeSeason(item *MenuItem, now time.Time) string {
+	if !item.Seasonal() {
+		return ""
+	}
+	text := " | Musim: " + describeSeasonDates(item)
+	switch {
+	case item.InSeason(now):
+	case item.ForceShow:
+		text += " [di luar musim, dipaksa tampil]"
+	default:
+		text += " [disembunyikan]"
+	}
+	return text
+}
+
+// Fungsi untuk membaca tanggal musim dengan format YYYY-MM-DD, "-" berarti tanpa batas
+func parseSeasonDate(value string) (time.Time, bool) {
+	if value == "-" {
+		return time.Time{}, true
+	}
+	date, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	return date, err == nil
+}
+
+// Fungsi untuk menanyakan masa musim dan paksa tampil saat item diubah. Mengembalikan
+// false jika input tidak valid.
+func readSeason(reader *bufio.Reader, item *MenuItem) bool {
+	current := "tidak musiman"
+	if item.Seasonal() {
+		current = describeSeasonDates(item)
+	}
+	fmt.Printf("Musim (YYYY-MM-DD s/d YYYY-MM-DD, - untuk hapus, kosongkan untuk tetap %s): ", current)
+	input, _ := reader.ReadString('\n')
+	switch input = strings.TrimSpace(input); input {
+	case "":
+	case "-":
+		item.SeasonStart, item.SeasonEnd, item.ForceShow = time.Time{}, time.Time{}, false
+	default:
+		startText, endText, ok := strings.Cut(input, " s/d ")
+		if !ok {
+			startText, endText = input, "-"
+		}
+		start, okStart := parseSeasonDate(strings.TrimSpace(startText))
+		end, okEnd := parseSeasonDate(strings.TrimSpace(endText))
+		if !okStart || !okEnd || (start.IsZero() && end.IsZero()) {
+			fmt.Println("Tanggal musim tidak valid, contoh: 2026-12-01 s/d 2026-12-31.")
+			return false
+		}
+		if !start.IsZero() && !end.IsZero() && end.Before(start) {
+			fmt.Println("Tanggal selesai musim tidak boleh sebelum tanggal mulai.")
+			return false
+		}
+		item.SeasonStart, item.SeasonEnd = start, end
+	}
+	if !item.Seasonal() {
+		return true
+	}
+
+	currentForce := "n"
+	if item.ForceShow {
+		currentForce = "y"
+	}
+	fmt.Printf("Paksa tampil di luar musim? (y/n, kosongkan untuk tetap %s): ", currentForce)
+	force, _ := reader.ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(force)) {
+	case "":
+	case "y":
+		item.ForceShow = true
+	case "n":
+		item.ForceShow = false
+	default:
+		fmt.Println("Jawaban harus y atau n.")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Fungsi untuk memeriksa apakah program baru pertama kali dijalankan,
+// yaitu belum ada config.json dan folder data masih kosong
+func isFirstRun() bool {
+	if _, err := os.Stat(configFile); !errors.Is(err, fs.ErrNotExist) {
+		return false
+	}
+	entries, err := os.ReadDir(currentConfig().DataDir)
+	return errors.Is(err, fs.ErrNotExist) || (err == nil && len(entries) == 0)
+}
+
+// Fungsi untuk menanyakan pengaturan awal restoran lalu menyimpannya ke config.json,
+// mengembalikan true jika menu contoh perlu dipakai
+func runSetupWizard(reader *bufio.Reader) bool {
+	fmt.Println("\n===== Pengaturan Awal =====")
+
+	setup := currentConfig()
+	fmt.Print("Nama restoran: ")
+	name, _ := reader.ReadString('\n')
+	if name = strings.TrimSpace(name); name != "" {
+		setup.RestaurantName = name
+	}
+
+	fmt.Printf("Mata uang (kosongkan untuk %s): ", setup.Currency)
+	currency, _ := reader.ReadString('\n')
+	if currency = strings.TrimSpace(currency); currency != "" {
+		setup.Currency = currency
+	}
+
+	for {
+		fmt.Print("Tarif pajak dalam persen (kosongkan untuk 0): ")
+		input, _ := reader.ReadString('\n')
+		input = strings.TrimSpace(input)
+		if input == "" {
+			break
+		}
+		rate, err := strconv.ParseFloat(input, 64)
+		if err != nil || rate < 0 || rate > 100 {
+			fmt.Println("Tarif pajak harus angka antara 0 dan 100.")
+			continue
+		}
+		setup.TaxRate = rate
+		break
+	}
+
+	fmt.Print("Isi menu dengan contoh menu? (y/n): ")
+	seed, _ := reader.ReadString('\n')
+	seedMenu := strings.EqualFold(strings.TrimSpace(seed), "y")
+
+	if err := writeJSONFile(configFile, setup); err != nil {
+		fmt.Println("Gagal menyimpan konfigurasi:", err)
+	} else {
+		fmt.Println("Pengaturan disimpan ke", configFile)
+	}
+
+	configMutex.Lock()
+	config = setup
+	configMutex.Unlock()
+	return seedMenu
+}
@@ -0,0 +1,338 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Nama file spesial hari ini, disimpan di folder data
+const specialsFile = "specials.json"
+
+// Struct untuk satu item spesial hari ini. Harga spesial langsung menjadi harga item di
+// menu dan dikembalikan ke harga normal saat tutup hari.
+type DailySpecial struct {
+	ItemName string `json:"item"`
+	Price    Money  `json:"price"`
+	// Harga normal sebelum dipromosikan, kosong untuk item satu hari
+	RegularPrice Money `json:"regular_price,omitempty"`
+	// Item yang dibuat hanya untuk hari ini dan dihapus dari menu saat tutup hari
+	OneDay  bool      `json:"one_day,omitempty"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+// Spesial hari ini, dibaca dari file saat pertama kali dipakai. specialsMutex tidak
+// boleh dipegang saat mengunci menuMutex.
+var specials []DailySpecial
+var specialsLoaded bool
+var specialsMutex sync.Mutex
+
+// Fungsi untuk membaca spesial dari file jika belum dibaca. Pada storage memory spesial
+// tidak disimpan karena item satu hari ikut hilang bersama menu.
+// Pemanggil harus memegang specialsMutex.
+func ensureSpecialsLoaded() {
+	if specialsLoaded {
+		return
+	}
+	specialsLoaded = true
+	if currentConfig().Storage == StorageMemory {
+		return
+	}
+	if _, err := readJSONFile(filepath.Join(currentConfig().DataDir, specialsFile), &specials); err != nil {
+		fmt.Println("Gagal membaca spesial hari ini:", err)
+	}
+}
+
+// Fungsi untuk menyimpan spesial ke file, pemanggil harus memegang specialsMutex
+func saveSpecials() {
+	invalidateMenuCache()
+	if currentConfig().Storage == StorageMemory {
+		return
+	}
+	if err := writeJSONFile(filepath.Join(currentConfig().DataDir, specialsFile), specials); err != nil {
+		fmt.Println("Gagal menyimpan spesial hari ini:", err)
+	}
+}
+
+// Fungsi untuk mengambil salinan spesial hari ini
+func currentSpecials() []DailySpecial {
+	specialsMutex.Lock()
+	defer specialsMutex.Unlock()
+
+	ensureSpecialsLoaded()
+	return append([]DailySpecial(nil), specials...)
+}
+
+// Fungsi untuk mencari spesial berdasarkan nama item, nil jika item bukan spesial
+func findSpecial(list []DailySpecial, name string) *DailySpecial {
+	for i := range list {
+		if normalizeMenuName(list[i].ItemName) == normalizeMenuName(name) {
+			return &list[i]
+		}
+	}
+	return nil
+}
+
+// Fungsi untuk menampilkan tanda spesial di daftar pilih item, kosong jika bukan spesial
+func describeSpecial(list []DailySpecial, name string) string {
+	if findSpecial(list, name) == nil {
+		return ""
+	}
+	return " [SPESIAL]"
+}
+
+// Fungsi untuk menampilkan spesial hari ini di atas daftar menu. Pemanggil harus
+// memegang menuMutex.
+func displaySpecials(list []DailySpecial) {
+	if len(list) == 0 {
+		return
+	}
+	fmt.Println("\n===== Spesial Hari Ini =====")
+	for _, special := range list {
+		item := findMenuItem(special.ItemName)
+		if item == nil {
+			continue
+		}
+		regular := ""
+		if special.RegularPrice > 0 {
+			regular = " (normal " + formatMoney(special.RegularPrice) + ")"
+		}
+		fmt.Printf("Nama: %s | Harga: %s%s | Stok: %s%s\n", item.Name, formatMoney(item.Price), regular, formatQuantity(item.Quantity), describeUnavailable(item))
+	}
+}
+
+// Fungsi untuk mengelola spesial hari ini: mempromosikan item menu dengan harga
+// spesial, membuat item satu hari, atau menghentikan spesial
+func specialsMenu(reader *bufio.Reader) {
+	list := currentSpecials()
+	fmt.Println("\n===== Spesial Hari Ini =====")
+	if len(list) == 0 {
+		fmt.Println("Belum ada spesial hari ini.")
+	}
+	for i, special := range list {
+		kind := "normal " + formatMoney(special.RegularPrice)
+		if special.OneDay {
+			kind = "item satu hari"
+		}
+		fmt.Printf("%d. %s | %s (%s)\n", i+1, special.ItemName, formatMoney(special.Price), kind)
+	}
+
+	fmt.Print("Pilih (1 = promosikan item menu, 2 = buat item satu hari, 3 = hentikan spesial, kosongkan untuk kembali): ")
+	choice, _ := reader.ReadString('\n')
+	switch strings.TrimSpace(choice) {
+	case "":
+	case "1":
+		promoteSpecial(reader)
+	case "2":
+		addOneDaySpecial(reader)
+	case "3":
+		fmt.Print("Nama item spesial: ")
+		name, _ := reader.ReadString('\n')
+		special := findSpecial(list, strings.TrimSpace(name))
+		if special == nil {
+			fmt.Println("Item ini bukan spesial hari ini.")
+			return
+		}
+		if err := endSpecial(*special); err != nil {
+			fmt.Println(err)
+			return
+		}
+		logActivity("hentikan spesial " + special.ItemName)
+		fmt.Printf("Spesial %s dihentikan.\n", special.ItemName)
+	default:
+		fmt.Println("Pilihan tidak valid.")
+	}
+}
+
+// Fungsi untuk membaca harga spesial dari kasir
+func readSpecialPrice(reader *bufio.Reader, prompt string) (Money, bool) {
+	fmt.Print(prompt)
+	input, _ := reader.ReadString('\n')
+	price, err := parseMoneyInput(strings.TrimSpace(input))
+	if err != nil || price <= 0 {
+		fmt.Println("Harga harus berupa angka positif.")
+		return 0, false
+	}
+	return price, true
+}
+
+// Fungsi untuk mempromosikan item menu yang sudah ada dengan harga spesial hari ini
+func promoteSpecial(reader *bufio.Reader) {
+	fmt.Print("Nama item: ")
+	name, _ := reader.ReadString('\n')
+
+	found := findMenuItemOrSuggest(reader, strings.TrimSpace(name))
+	if found == "" {
+		return
+	}
+
+	menuMutex.Lock()
+	item := findMenuItem(found)
+	var edited MenuItem
+	if item != nil {
+		edited = copyMenuItem(*item)
+	}
+	menuMutex.Unlock()
+	if item == nil {
+		return
+	}
+	if len(edited.Variants) > 0 {
+		fmt.Println("Item ini punya varian, promosikan variannya satu per satu.")
+		return
+	}
+	if findSpecial(currentSpecials(), edited.Name) != nil {
+		fmt.Printf("%s sudah menjadi spesial hari ini.\n", edited.Name)
+		return
+	}
+
+	price, ok := readSpecialPrice(reader, fmt.Sprintf("Harga spesial (harga normal %s): ", formatMoney(edited.Price)))
+	if !ok {
+		return
+	}
+	special := DailySpecial{ItemName: edited.Name, Price: price, RegularPrice: edited.Price, AddedAt: time.Now()}
+
+	edited.Price = price
+	updated, err := activeMenuRepo().UpdateMenuItem(edited)
+	var conflict *VersionConflictError
+	if errors.As(err, &conflict) {
+		fmt.Printf("%s sudah diubah pihak lain, coba lagi.\n", edited.Name)
+		return
+	}
+	if err != nil {
+		fmt.Printf("Gagal menyimpan %s: %v\n", edited.Name, err)
+		return
+	}
+	applyMenuItemEdit(updated)
+
+	specialsMutex.Lock()
+	ensureSpecialsLoaded()
+	specials = append(specials, special)
+	saveSpecials()
+	specialsMutex.Unlock()
+
+	logActivity(fmt.Sprintf("spesial hari ini %s %s (normal %s)", special.ItemName, formatMoney(price), formatMoney(special.RegularPrice)))
+	fmt.Printf("%s menjadi spesial hari ini seharga %s sampai tutup hari.\n", special.ItemName, formatMoney(price))
+}
+
+// Fungsi untuk membuat item yang hanya dijual hari ini, dihapus dari menu saat tutup hari
+func addOneDaySpecial(reader *bufio.Reader) {
+	fmt.Print("Nama item satu hari: ")
+	nameInput, _ := reader.ReadString('\n')
+	name := strings.TrimSpace(nameInput)
+	if name == "" {
+		fmt.Println("Nama item wajib diisi.")
+		return
+	}
+	price, ok := readSpecialPrice(reader, "Harga spesial: ")
+	if !ok {
+		return
+	}
+
+	fmt.Print("Stok tersedia: ")
+	quantityInput, _ := reader.ReadString('\n')
+	quantity, err := parseQuantityInput(quantityInput)
+	if err != nil || quantity < 0 {
+		fmt.Println("Stok harus berupa angka positif.")
+		return
+	}
+
+	fmt.Print("Stasiun (grill/wok/bar): ")
+	stationInput, _ := reader.ReadString('\n')
+	station, ok := parseStation(stationInput)
+	if !ok {
+		fmt.Println("Stasiun tidak dikenal.")
+		return
+	}
+
+	menuMutex.Lock()
+	if findMenuItem(name) != nil {
+		menuMutex.Unlock()
+		fmt.Printf("%s sudah ada di menu, gunakan promosikan item menu.\n", name)
+		return
+	}
+	menu = append(menu, MenuItem{Name: name, Price: price, Quantity: quantity, Station: station})
+	invalidateMenuCache()
+	if quantity > 0 {
+		recordMovement(name, quantity, MovementRestock, "spesial hari ini")
+	}
+	menuMutex.Unlock()
+
+	specialsMutex.Lock()
+	ensureSpecialsLoaded()
+	specials = append(specials, DailySpecial{ItemName: name, Price: price, OneDay: true, AddedAt: time.Now()})
+	saveSpecials()
+	specialsMutex.Unlock()
+
+	logActivity(fmt.Sprintf("item satu hari %s %s stok %d", name, formatMoney(price), quantity))
+	fmt.Printf("%s ditambahkan sebagai spesial hari ini dan dihapus dari menu saat tutup hari.\n", name)
+}
+
+// Fungsi untuk menghentikan satu spesial: item satu hari dihapus dari menu, item yang
+// dipromosikan kembali ke harga normal. Harga yang sudah diubah lagi sejak promosi
+// tidak ditimpa.
+func endSpecial(special DailySpecial) error {
+	if special.OneDay {
+		if err := activeMenuRepo().DeleteMenuItem(special.ItemName); err != nil {
+			return fmt.Errorf("Gagal menghapus %s: %v", special.ItemName, err)
+		}
+		menuMutex.Lock()
+		menu = removeMenuItem(menu, special.ItemName)
+		invalidateMenuCache()
+		menuMutex.Unlock()
+	} else {
+		menuMutex.Lock()
+		item := findMenuItem(special.ItemName)
+		var edited MenuItem
+		if item != nil {
+			edited = copyMenuItem(*item)
+		}
+		menuMutex.Unlock()
+
+		if item != nil && edited.Price == special.Price {
+			edited.Price = special.RegularPrice
+			updated, err := activeMenuRepo().UpdateMenuItem(edited)
+			var conflict *VersionConflictError
+			if errors.As(err, &conflict) {
+				return fmt.Errorf("%s sudah diubah pihak lain, harga tidak dikembalikan", special.ItemName)
+			}
+			if err != nil {
+				return fmt.Errorf("Gagal menyimpan %s: %v", special.ItemName, err)
+			}
+			applyMenuItemEdit(updated)
+		}
+	}
+
+	specialsMutex.Lock()
+	defer specialsMutex.Unlock()
+
+	ensureSpecialsLoaded()
+	for i := range specials {
+		if specials[i].ItemName == special.ItemName {
+			specials = append(specials[:i], specials[i+1:]...)
+			break
+		}
+	}
+	saveSpecials()
+	return nil
+}
+
+// Fungsi untuk mengakhiri semua spesial hari ini, dipanggil saat tutup hari
+func expireSpecials() {
+	list := currentSpecials()
+	ended := 0
+	for _, special := range list {
+		if err := endSpecial(special); err != nil {
+			fmt.Println(err)
+			continue
+		}
+		ended++
+	}
+	if ended > 0 {
+		logActivity(fmt.Sprintf("tutup hari: %d spesial berakhir", ended))
+		fmt.Printf("%d spesial hari ini berakhir.\n", ended)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Fungsi untuk membaca metode bayar beserta jumlah yang dibayar dengan metode itu.
+// Tanpa jumlah berarti seluruh sisa tagihan, misalnya "kartu 50000" membayar sebagian
+// dengan kartu dan sisanya ditanyakan lagi.
+func readPaymentPart(reader *bufio.Reader, balance Money) (PaymentMethod, Money, bool) {
+	fmt.Print("Metode bayar (tunai/kartu/qris, kosongkan untuk tunai; tambahkan jumlah untuk bayar sebagian, misal kartu 50000): ")
+	input, _ := reader.ReadString('\n')
+	fields := strings.Fields(input)
+	if len(fields) > 2 {
+		fmt.Println("Format pembayaran: <metode> [jumlah].")
+		return "", 0, false
+	}
+
+	var methodInput string
+	if len(fields) > 0 {
+		methodInput = fields[0]
+	}
+	method, ok := parsePaymentMethod(methodInput)
+	if !ok {
+		fmt.Println("Metode bayar tidak dikenal.")
+		return "", 0, false
+	}
+	if len(fields) < 2 {
+		return method, balance, true
+	}
+
+	amount, err := parseMoneyInput(fields[1])
+	if err != nil || amount <= 0 || amount > balance {
+		fmt.Printf("Jumlah harus positif dan tidak melebihi sisa tagihan %s.\n", formatMoney(balance))
+		return "", 0, false
+	}
+	return method, amount, true
+}
+
+// Fungsi untuk mengambil semua pembayaran pesanan. Pesanan yang dibayar sebagian atau
+// lewat tab memakai catatan pembayarannya, pesanan yang dibayar sekaligus dianggap
+// satu pembayaran sebesar tagihan pada waktu lunas.
+func (order *Order) PaymentEntries() []TabPayment {
+	if len(order.TabPayments) > 0 {
+		return order.TabPayments
+	}
+	if !order.Paid {
+		return nil
+	}
+	return []TabPayment{{Amount: order.AmountDue(), Method: order.Payment(), Time: order.PaidAt}}
+}
+
+// Fungsi untuk memeriksa apakah sebagian atau seluruh tagihan pesanan dibayar dengan metode ini
+func (order *Order) PaidWith(method PaymentMethod) bool {
+	return slices.ContainsFunc(order.PaymentEntries(), func(payment TabPayment) bool {
+		return payment.Method == method
+	})
+}
+
+// Fungsi untuk menentukan metode bayar pesanan yang sudah lunas: metode yang sama
+// untuk semua pembayaran, atau split jika memakai lebih dari satu metode
+func combinedPaymentMethod(payments []TabPayment) PaymentMethod {
+	if len(payments) == 0 {
+		return PaymentCash
+	}
+	method := payments[0].Method
+	for _, payment := range payments[1:] {
+		if payment.Method != method {
+			return PaymentSplit
+		}
+	}
+	return method
+}
+
+// Fungsi untuk menuliskan pembayaran per metode, misalnya "tunai Rp 50.000,00 + kartu Rp 30.000,00"
+func describePayments(payments []TabPayment) string {
+	parts := make([]string, len(payments))
+	for i, payment := range payments {
+		parts[i] = payment.Method.Label() + " " + formatMoney(payment.Amount)
+	}
+	return strings.Join(parts, " + ")
+}
+
+// Fungsi untuk menjumlahkan uang yang diterima per metode bayar dalam rentang [from, to).
+// Pembayaran sebagian dihitung pada waktu masing-masing pembayaran.
+func paymentTotals(from, to time.Time) map[PaymentMethod]Money {
+	totals := map[PaymentMethod]Money{}
+	ordersMutex.Lock()
+	defer ordersMutex.Unlock()
+	for _, order := range orders {
+		if order.Voided || order.MergedInto != 0 {
+			continue
+		}
+		for _, payment := range order.PaymentEntries() {
+			if payment.Time.Before(from) || !payment.Time.Before(to) {
+				continue
+			}
+			totals[payment.Method] += payment.Amount
+		}
+	}
+	return totals
+}
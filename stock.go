@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Kesalahan saat stok tidak cukup untuk dikurangi
+var errInsufficientStock = errors.New("stok tidak cukup")
+
+// Struct untuk satu batch stok yang diterima dengan tanggal kedaluwarsa
+type StockBatch struct {
+	Quantity   int       `json:"quantity"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	ReceivedAt time.Time `json:"received_at"`
+}
+
+// Fungsi untuk menambah stok item, batch baru hanya dibuat jika ada tanggal kedaluwarsa.
+// Pemanggil harus memegang menuMutex.
+func (item *MenuItem) addStock(quantity int, expiresAt time.Time) error {
+	if err := adjustSharedStock(item, quantity); err != nil {
+		return err
+	}
+
+	item.Quantity += quantity
+	if !expiresAt.IsZero() {
+		item.Batches = append(item.Batches, StockBatch{Quantity: quantity, ExpiresAt: expiresAt, ReceivedAt: time.Now()})
+	}
+	invalidateMenuCache()
+	return nil
+}
+
+// Fungsi untuk mengurangi stok item secara FIFO. Stok tanpa batch dianggap stok
+// paling lama sehingga dipakai lebih dulu, baru kemudian batch sesuai urutan diterima.
+// Pemanggil harus memegang menuMutex.
+func (item *MenuItem) removeStock(quantity int) error {
+	if err := adjustSharedStock(item, -quantity); err != nil {
+		return err
+	}
+	if quantity > item.Quantity {
+		return errInsufficientStock
+	}
+
+	item.Quantity -= quantity
+	invalidateMenuCache()
+	if item.Quantity == 0 {
+		notify("Stok habis", item.Name+" sudah habis")
+	}
+
+	untracked := item.Quantity
+	for _, batch := range item.Batches {
+		untracked -= batch.Quantity
+	}
+	if untracked >= 0 {
+		return nil
+	}
+
+	// Sisa yang tidak tertutup stok tanpa batch diambil dari batch terlama
+	remaining := -untracked
+	for len(item.Batches) > 0 && remaining > 0 {
+		batch := &item.Batches[0]
+		if batch.Quantity > remaining {
+			batch.Quantity -= remaining
+			break
+		}
+		remaining -= batch.Quantity
+		item.Batches = item.Batches[1:]
+	}
+	return nil
+}
+
+// Fungsi untuk menerapkan perubahan stok ke backend bersama lebih dulu. Stok lokal
+// disamakan dengan database sebelum perubahan, sehingga perubahan dari terminal lain
+// ikut terbawa. Selama dry-run database tidak disentuh. Pemanggil harus memegang menuMutex.
+func adjustSharedStock(item *MenuItem, delta int) error {
+	if sharedStore == nil || dryRunActive() {
+		return nil
+	}
+
+	remaining, err := sharedStore.AdjustStock(item.Name, delta)
+	if err != nil {
+		return err
+	}
+	item.Quantity = remaining - delta
+	invalidateMenuCache()
+	return nil
+}
+
+// Fungsi untuk menampilkan batch yang akan kedaluwarsa dalam jumlah hari tertentu
+func displayExpiringBatches() {
+	menuMutex.Lock()
+	defer menuMutex.Unlock()
+
+	days := currentConfig().ExpiryWarningDays
+	now := time.Now()
+	limit := now.AddDate(0, 0, days)
+
+	fmt.Printf("\n===== Stok Hampir Kedaluwarsa (%d hari) =====\n", days)
+	found := false
+	for _, item := range stockItems() {
+		for _, batch := range item.Batches {
+			if batch.ExpiresAt.After(limit) {
+				continue
+			}
+			found = true
+			marker := ""
+			if !batch.ExpiresAt.After(now) {
+				marker = " [KEDALUWARSA]"
+			}
+			fmt.Printf("Nama: %s | Jumlah: %d | Kedaluwarsa: %s%s\n", item.Name, batch.Quantity, formatDate(batch.ExpiresAt), marker)
+		}
+	}
+
+	if !found {
+		fmt.Println("Tidak ada stok yang akan kedaluwarsa.")
+	}
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Folder di dalam folder data untuk snapshot stok akhir shift
+const stockSnapshotsDir = "stock_snapshots"
+
+// Struct untuk stok satu item selama satu shift
+type StockSnapshotLine struct {
+	ItemName string `json:"item"`
+	// Stok saat shift dimulai
+	Opening int `json:"opening"`
+	// Porsi terjual dikurangi retur pelanggan
+	Sold int `json:"sold"`
+	// Mutasi lain di buku stok, misalnya restock, waste atau koreksi
+	Other int `json:"other"`
+	// Stok saat shift ditutup
+	Closing int `json:"closing"`
+}
+
+// Fungsi untuk menghitung stok akhir yang seharusnya menurut penjualan dan buku stok
+func (line StockSnapshotLine) Expected() int {
+	return line.Opening - line.Sold + line.Other
+}
+
+// Fungsi untuk menghitung selisih stok akhir dengan stok seharusnya, selain 0 berarti
+// ada perubahan stok yang tidak tercatat
+func (line StockSnapshotLine) Discrepancy() int {
+	return line.Closing - line.Expected()
+}
+
+// Struct untuk snapshot stok saat shift ditutup
+type StockSnapshot struct {
+	ShiftStart time.Time `json:"shift_start"`
+	ClosedAt   time.Time `json:"closed_at"`
+	Cashier    string    `json:"cashier"`
+	// Penyebab shift ditutup, misalnya "ganti kasir" atau "tutup hari"
+	Reason string              `json:"reason"`
+	Lines  []StockSnapshotLine `json:"lines"`
+}
+
+// Stok setiap item saat shift berjalan dimulai
+var shiftOpening map[string]int
+var shiftStart time.Time
+var shiftMutex sync.Mutex
+
+// Fungsi untuk mengambil stok semua item, pemanggil harus memegang menuMutex
+func currentStockLevels() map[string]int {
+	levels := map[string]int{}
+	for _, item := range stockItems() {
+		levels[item.Name] = item.Quantity
+	}
+	return levels
+}
+
+// Fungsi untuk mencatat stok awal shift, dipanggil saat program mulai
+func startShift() {
+	menuMutex.Lock()
+	levels := currentStockLevels()
+	menuMutex.Unlock()
+
+	shiftMutex.Lock()
+	shiftOpening = levels
+	shiftStart = time.Now()
+	shiftMutex.Unlock()
+}
+
+// Fungsi untuk menutup shift berjalan: stok semua item disimpan ke file bertanggal
+// bersama stok awal dan penjualan shift, lalu shift baru dimulai dengan stok sekarang.
+// Selisih yang ditemukan langsung ditampilkan.
+func closeShift(cashier, reason string) {
+	if dryRunActive() {
+		return
+	}
+
+	menuMutex.Lock()
+	closing := currentStockLevels()
+	menuMutex.Unlock()
+
+	shiftMutex.Lock()
+	defer shiftMutex.Unlock()
+
+	now := time.Now()
+	snapshot := StockSnapshot{ShiftStart: shiftStart, ClosedAt: now, Cashier: cashier, Reason: reason}
+	sold, other := shiftMovements(shiftStart, now)
+	names := map[string]bool{}
+	for name := range shiftOpening {
+		names[name] = true
+	}
+	for name := range closing {
+		names[name] = true
+	}
+	for name := range names {
+		snapshot.Lines = append(snapshot.Lines, StockSnapshotLine{
+			ItemName: name,
+			Opening:  shiftOpening[name],
+			Sold:     sold[name],
+			Other:    other[name],
+			Closing:  closing[name],
+		})
+	}
+	slices.SortFunc(snapshot.Lines, func(a, b StockSnapshotLine) int { return strings.Compare(a.ItemName, b.ItemName) })
+
+	shiftOpening = closing
+	shiftStart = now
+
+	path, err := writeStockSnapshot(snapshot)
+	if err != nil {
+		fmt.Println("Gagal menyimpan snapshot stok shift:", err)
+		return
+	}
+	logActivity(fmt.Sprintf("snapshot stok shift %s (%s) disimpan ke %s", cashier, reason, path))
+
+	var mismatched []string
+	for _, line := range snapshot.Lines {
+		if line.Discrepancy() != 0 {
+			mismatched = append(mismatched, fmt.Sprintf("%s %+d", line.ItemName, line.Discrepancy()))
+		}
+	}
+	if len(mismatched) > 0 {
+		fmt.Printf("Peringatan: selisih stok shift %s: %s.\n", cashier, strings.Join(mismatched, ", "))
+	}
+}
+
+// Fungsi untuk menjumlahkan mutasi buku stok dalam rentang waktu per item. Penjualan,
+// reservasi dan retur pelanggan dihitung sebagai terjual, mutasi lain dipisahkan.
+func shiftMovements(from, to time.Time) (sold, other map[string]int) {
+	sold, other = map[string]int{}, map[string]int{}
+	for _, movement := range filterMovements("", from, to.Add(time.Nanosecond)) {
+		switch movement.Kind {
+		case MovementSale, MovementReservation, MovementReturn:
+			sold[movement.ItemName] -= movement.Change
+		default:
+			other[movement.ItemName] += movement.Change
+		}
+	}
+	return sold, other
+}
+
+// Fungsi untuk menulis snapshot ke folder stock_snapshots dengan nama berisi waktu penutupan
+func writeStockSnapshot(snapshot StockSnapshot) (string, error) {
+	dir := filepath.Join(currentConfig().DataDir, stockSnapshotsDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, "stok-"+snapshot.ClosedAt.Format("2006-01-02-150405")+".json")
+	return path, writeJSONFile(path, snapshot)
+}
+
+// Fungsi untuk membaca semua snapshot stok shift, urut dari yang terlama
+func loadStockSnapshots() ([]StockSnapshot, error) {
+	paths, err := filepath.Glob(filepath.Join(currentConfig().DataDir, stockSnapshotsDir, "stok-*.json"))
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(paths)
+
+	var snapshots []StockSnapshot
+	for _, path := range paths {
+		var snapshot StockSnapshot
+		if _, err := readJSONFile(path, &snapshot); err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
+
+// Fungsi untuk menampilkan daftar snapshot stok shift dan rincian salah satunya:
+// stok awal, terjual, mutasi lain, stok akhir dan selisih per item
+func displayStockSnapshots(reader *bufio.Reader) {
+	snapshots, err := loadStockSnapshots()
+	if err != nil {
+		fmt.Println("Gagal membaca snapshot stok:", err)
+		return
+	}
+	if len(snapshots) == 0 {
+		fmt.Println("Belum ada snapshot stok shift. Snapshot dibuat saat ganti kasir dan tutup hari.")
+		return
+	}
+
+	fmt.Println("\n===== Snapshot Stok Shift =====")
+	for i, snapshot := range snapshots {
+		mismatched := 0
+		for _, line := range snapshot.Lines {
+			if line.Discrepancy() != 0 {
+				mismatched++
+			}
+		}
+		fmt.Printf("%d. %s - %s | Kasir: %s | %s | Item selisih: %d\n", i+1, formatDateTime(snapshot.ShiftStart), formatDateTime(snapshot.ClosedAt), snapshot.Cashier, snapshot.Reason, mismatched)
+	}
+
+	fmt.Print("Nomor snapshot untuk rincian (kosongkan untuk kembali): ")
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return
+	}
+	number, err := strconv.Atoi(input)
+	if err != nil || number < 1 || number > len(snapshots) {
+		fmt.Println("Nomor snapshot tidak valid.")
+		return
+	}
+
+	snapshot := snapshots[number-1]
+	fmt.Printf("\n===== Stok Shift %s (%s) =====\n", snapshot.Cashier, formatDateTime(snapshot.ClosedAt))
+	for _, line := range snapshot.Lines {
+		marker := ""
+		if line.Discrepancy() != 0 {
+			marker = fmt.Sprintf(" | SELISIH %+d", line.Discrepancy())
+		}
+		fmt.Printf("%s | Awal: %d | Terjual: %d | Mutasi lain: %+d | Akhir: %d (seharusnya %d)%s\n", line.ItemName, line.Opening, line.Sold, line.Other, line.Closing, line.Expected(), marker)
+	}
+}
@@ -0,0 +1,579 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Jenis penyimpanan yang bisa dipilih lewat config.json
+const (
+	StorageMemory   = "memory"
+	StorageJSON     = "json"
+	StorageSQLite   = "sqlite"
+	StoragePostgres = "postgres"
+	StorageEvents   = "events"
+)
+
+// Interface untuk menyimpan dan memuat item menu
+type MenuRepository interface {
+	// LoadMenu mengembalikan nil jika belum ada menu tersimpan
+	LoadMenu() ([]MenuItem, error)
+	// SaveMenu tidak menimpa item yang versinya di penyimpanan lebih baru
+	SaveMenu(items []MenuItem) error
+	// DeleteMenuItem menghapus item dari penyimpanan
+	DeleteMenuItem(name string) error
+	// UpdateMenuItem menyimpan harga, stasiun, pembatasan dan batas jumlah item hanya jika versi tersimpan
+	// sama dengan item.Version, lalu mengembalikan item dengan versi baru.
+	// Jika versi berbeda dikembalikan *VersionConflictError.
+	UpdateMenuItem(item MenuItem) (MenuItem, error)
+}
+
+// Kesalahan saat item menu sudah diubah proses lain sejak terakhir dibaca
+type VersionConflictError struct {
+	Current MenuItem
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("item %s sudah diubah pihak lain (versi %d)", e.Current.Name, e.Current.Version)
+}
+
+// Fungsi untuk menerapkan perubahan harga, stasiun, pembatasan jenis pesanan, batas jumlah dan musim pada item tersimpan dengan
+// pemeriksaan versi, dipakai oleh penyimpanan memori dan JSON
+func applyMenuItemUpdate(items []MenuItem, item MenuItem) (MenuItem, error) {
+	stored := findMenuItemIn(items, item.Name)
+	if stored == nil {
+		return MenuItem{}, fmt.Errorf("item %s tidak ditemukan di penyimpanan", item.Name)
+	}
+	if stored.Version != item.Version {
+		return MenuItem{}, &VersionConflictError{Current: copyMenuItem(*stored)}
+	}
+	stored.Price = item.Price
+	stored.Station = item.Station
+	stored.Restricted = item.Restricted
+	stored.MaxQuantity = item.MaxQuantity
+	stored.SeasonStart = item.SeasonStart
+	stored.SeasonEnd = item.SeasonEnd
+	stored.ForceShow = item.ForceShow
+	stored.Unavailable = item.Unavailable
+	stored.Course = item.Course
+	stored.UnavailableReason = item.UnavailableReason
+	stored.Version++
+	return copyMenuItem(*stored), nil
+}
+
+// Fungsi untuk membuang item atau varian dengan nama tertentu dari daftar menu
+func removeMenuItem(items []MenuItem, name string) []MenuItem {
+	result := items[:0]
+	for _, item := range items {
+		if item.Name != name {
+			if item.Variants != nil {
+				item.Variants = removeMenuItem(item.Variants, name)
+			}
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// Fungsi untuk mengganti item di snapshot dengan item tersimpan yang versinya lebih baru
+func keepNewerVersions(items, stored []MenuItem) []MenuItem {
+	newer := map[string]MenuItem{}
+	for _, item := range stored {
+		newer[item.Name] = item
+	}
+
+	result := make([]MenuItem, len(items))
+	for i, item := range items {
+		if current, ok := newer[item.Name]; ok && current.Version > item.Version {
+			item = current
+		}
+		result[i] = copyMenuItem(item)
+	}
+	return result
+}
+
+// Interface untuk menyimpan dan memuat pesanan
+type OrderRepository interface {
+	LoadOrders() ([]Order, error)
+	SaveOrders(orders []Order) error
+	// DeleteOrders menghapus pesanan yang sudah dipindahkan ke arsip
+	DeleteOrders(ids []int) error
+}
+
+// Interface untuk backend yang dipakai bersama beberapa terminal kasir.
+// Stok dan ID pesanan selalu diambil dari database, bukan dari salinan lokal.
+type SharedStore interface {
+	AdjustStock(name string, delta int) (int, error)
+	StockLevels() (map[string]int, error)
+	NextOrderID() (int, error)
+}
+
+// Repository yang sedang dipakai
+var menuRepo MenuRepository
+var orderRepo OrderRepository
+
+// Backend bersama, nil jika penyimpanan hanya dipakai satu terminal
+var sharedStore SharedStore
+
+// Fungsi untuk memeriksa apakah jenis penyimpanan dikenal
+func validStorage(storage string) bool {
+	switch storage {
+	case StorageMemory, StorageJSON, StorageSQLite, StoragePostgres, StorageEvents:
+		return true
+	}
+	return false
+}
+
+// Fungsi untuk memilih implementasi repository sesuai konfigurasi
+func openStorage(cfg Config) error {
+	switch cfg.Storage {
+	case StorageMemory:
+		store := &memoryStore{}
+		menuRepo, orderRepo = store, store
+	case StorageJSON:
+		if err := os.MkdirAll(cfg.DataDir, 0755); err != nil {
+			return err
+		}
+		store := &jsonStore{dir: cfg.DataDir}
+		menuRepo, orderRepo = store, store
+	case StorageSQLite:
+		if err := os.MkdirAll(cfg.DataDir, 0755); err != nil {
+			return err
+		}
+		store, err := openSQLStore("sqlite", filepath.Join(cfg.DataDir, "restoran.db"), false)
+		if err != nil {
+			return err
+		}
+		menuRepo, orderRepo = store, store
+	case StoragePostgres:
+		if cfg.DatabaseURL == "" {
+			return errors.New("database_url wajib diisi untuk storage postgres")
+		}
+		store, err := openSQLStore("postgres", cfg.DatabaseURL, true)
+		if err != nil {
+			return err
+		}
+		menuRepo, orderRepo, sharedStore = store, store, store
+	case StorageEvents:
+		if err := os.MkdirAll(cfg.DataDir, 0755); err != nil {
+			return err
+		}
+		store, err := openEventStore(filepath.Join(cfg.DataDir, "events.jsonl"))
+		if err != nil {
+			return err
+		}
+		menuRepo, orderRepo = store, store
+	default:
+		return fmt.Errorf("storage %q tidak dikenal", cfg.Storage)
+	}
+	// Perubahan item selalu dicatat lewat pembungkus ini, antrian sinkronisasi hanya
+	// diisi jika sync.url diatur sehingga bisa diaktifkan lewat muat ulang konfigurasi
+	menuRepo = &syncMenuRepo{MenuRepository: menuRepo}
+	return nil
+}
+
+// Fungsi untuk memuat menu dan pesanan dari repository saat program dimulai
+func loadState() error {
+	moneyNormalized.Store(0)
+	items, err := menuRepo.LoadMenu()
+	if err != nil {
+		return err
+	}
+	loadedOrders, err := orderRepo.LoadOrders()
+	if err != nil {
+		return err
+	}
+	if err := loadDayCloses(); err != nil {
+		return err
+	}
+
+	// Menu bawaan tetap dipakai jika belum ada menu tersimpan
+	if items != nil {
+		menuMutex.Lock()
+		menu = items
+		invalidateMenuCache()
+		menuMutex.Unlock()
+	}
+
+	ordersMutex.Lock()
+	orders = nil
+	lastOrderID = 0
+	pickupCache.orderCount = -1
+	var total Money
+	for i := range loadedOrders {
+		order := &loadedOrders[i]
+		orders = append(orders, order)
+		lastOrderID = max(lastOrderID, order.ID)
+		total += orderRevenue(order)
+	}
+	ordersMutex.Unlock()
+
+	totalMutex.Lock()
+	totalAllOrders = total
+	totalMutex.Unlock()
+	return nil
+}
+
+// Fungsi untuk menghitung pendapatan pesanan yang sudah masuk dapur dan dikonfirmasi, dikurangi refund
+func orderRevenue(order *Order) Money {
+	if order.Voided || order.PendingAck {
+		return 0
+	}
+
+	var revenue Money
+	for _, line := range order.Lines {
+		if line.Status != LinePreparing && line.Status != LineDone {
+			continue
+		}
+		revenue += line.TotalPrice
+		// Tagihan yang belum dibayar sudah dikurangi saat retur, yang sudah dibayar lewat refund
+		if order.Paid {
+			revenue -= line.Price.Times(line.Returned)
+		}
+	}
+	return revenue - order.Discount
+}
+
+// Mencegah dua penyimpanan (misalnya dari CLI dan API) menulis bersamaan
+var saveMutex sync.Mutex
+
+// Bernilai true jika penyimpanan terakhir gagal, dilindungi saveMutex
+var saveFailed bool
+
+// Waktu simpan otomatis terakhir, hanya dipakai goroutine penjadwal
+var lastAutoSave time.Time
+
+// Fungsi untuk menyimpan menu dan pesanan ke repository
+func saveState() {
+	saveMutex.Lock()
+	defer saveMutex.Unlock()
+	saveStateLocked(false)
+}
+
+// Fungsi untuk menyimpan menu dan pesanan dari goroutine latar belakang seperti handler
+// API, pesan kegagalan lewat asyncPrintf agar tidak memotong prompt kasir
+func saveStateInBackground() {
+	saveMutex.Lock()
+	defer saveMutex.Unlock()
+	saveStateLocked(true)
+}
+
+// Fungsi untuk menyimpan menu dan pesanan secara berkala dari penjadwal sesuai
+// auto_save_seconds. Tidak berjalan selama dry-run, dan dilewati jika menu.json atau
+// config.json diubah dari luar agar perubahan itu diterapkan dulu di perintah berikutnya.
+func autoSave(now time.Time) {
+	settings := currentConfig()
+	if settings.AutoSaveSeconds <= 0 || settings.Storage == StorageMemory {
+		return
+	}
+	if now.Sub(lastAutoSave) < time.Duration(settings.AutoSaveSeconds)*time.Second {
+		return
+	}
+	lastAutoSave = now
+
+	dryRunMutex.Lock()
+	defer dryRunMutex.Unlock()
+	saveMutex.Lock()
+	defer saveMutex.Unlock()
+	if watchedFilesChanged() {
+		return
+	}
+	saveStateLocked(true)
+}
+
+// Fungsi untuk menyimpan menu dan pesanan, pemanggil harus memegang saveMutex. Jika
+// background bernilai true kegagalan ditulis lewat asyncPrintf.
+func saveStateLocked(background bool) {
+	printf := func(format string, args ...any) { fmt.Printf(format, args...) }
+	if background {
+		printf = asyncPrintf
+	}
+
+	menuMutex.Lock()
+	items := make([]MenuItem, len(menu))
+	for i, item := range menu {
+		items[i] = copyMenuItem(item)
+	}
+	menuMutex.Unlock()
+
+	ordersMutex.Lock()
+	snapshot := make([]Order, len(orders))
+	for i, order := range orders {
+		snapshot[i] = copyOrder(*order)
+	}
+	ordersMutex.Unlock()
+
+	saveFailed = false
+	if err := menuRepo.SaveMenu(items); err != nil {
+		printf("Gagal menyimpan menu: %v\n", err)
+		saveFailed = true
+	}
+	if err := orderRepo.SaveOrders(snapshot); err != nil {
+		printf("Gagal menyimpan pesanan: %v\n", err)
+		saveFailed = true
+	}
+	queueOrderSync(snapshot, background)
+	rememberWatchedFiles()
+}
+
+// Fungsi untuk mengirim ulang baris yang masih antri saat program terakhir berhenti.
+// Pada backend bersama antrian milik terminal lain tidak ikut dikirim ulang.
+func resumeQueuedOrders() {
+	if sharedStore != nil {
+		return
+	}
+
+	ordersMutex.Lock()
+	type pending struct {
+		id    int
+		lines []OrderLine
+	}
+	var queued []pending
+	for _, order := range orders {
+		var lines []OrderLine
+		for _, line := range order.Lines {
+			if line.Status == LineQueued {
+				lines = append(lines, line)
+			}
+		}
+		if len(lines) > 0 {
+			queued = append(queued, pending{id: order.ID, lines: lines})
+		}
+	}
+	ordersMutex.Unlock()
+
+	for _, p := range queued {
+		sendToKitchen(p.id, p.lines)
+	}
+}
+
+// Fungsi untuk menyegarkan stok lokal dengan stok di backend bersama,
+// supaya tampilan menu ikut berubah saat terminal lain menjual item
+func refreshSharedStock() {
+	if sharedStore == nil {
+		return
+	}
+
+	levels, err := sharedStore.StockLevels()
+	if err != nil {
+		fmt.Println("Gagal menyegarkan stok:", err)
+		return
+	}
+
+	menuMutex.Lock()
+	defer menuMutex.Unlock()
+
+	for _, item := range stockItems() {
+		if quantity, ok := levels[item.Name]; ok && item.Quantity != quantity {
+			item.Quantity = quantity
+			invalidateMenuCache()
+		}
+	}
+}
+
+// Fungsi untuk menyalin item menu beserta batch-nya
+func copyMenuItem(item MenuItem) MenuItem {
+	item.Batches = append([]StockBatch(nil), item.Batches...)
+	item.Restricted = append([]OrderType(nil), item.Restricted...)
+	if item.Variants != nil {
+		variants := make([]MenuItem, len(item.Variants))
+		for i, variant := range item.Variants {
+			variants[i] = copyMenuItem(variant)
+		}
+		item.Variants = variants
+	}
+	return item
+}
+
+// Fungsi untuk menyalin pesanan beserta baris-barisnya
+func copyOrder(order Order) Order {
+	order.Lines = append([]OrderLine(nil), order.Lines...)
+	order.MergedFrom = append([]int(nil), order.MergedFrom...)
+	order.TabPayments = append([]TabPayment(nil), order.TabPayments...)
+	order.Fields = maps.Clone(order.Fields)
+	return order
+}
+
+// Fungsi untuk membuang pesanan dengan ID tertentu dari daftar pesanan
+func removeOrders(orders []Order, ids []int) []Order {
+	removed := map[int]bool{}
+	for _, id := range ids {
+		removed[id] = true
+	}
+	result := orders[:0]
+	for _, order := range orders {
+		if !removed[order.ID] {
+			result = append(result, order)
+		}
+	}
+	return result
+}
+
+// Penyimpanan di memori, data hilang saat program berhenti
+type memoryStore struct {
+	mu     sync.Mutex
+	menu   []MenuItem
+	orders []Order
+}
+
+func (s *memoryStore) LoadMenu() ([]MenuItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.menu == nil {
+		return nil, nil
+	}
+	items := make([]MenuItem, len(s.menu))
+	for i, item := range s.menu {
+		items[i] = copyMenuItem(item)
+	}
+	return items, nil
+}
+
+func (s *memoryStore) SaveMenu(items []MenuItem) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.menu = keepNewerVersions(items, s.menu)
+	return nil
+}
+
+func (s *memoryStore) DeleteMenuItem(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.menu = removeMenuItem(s.menu, name)
+	return nil
+}
+
+func (s *memoryStore) UpdateMenuItem(item MenuItem) (MenuItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return applyMenuItemUpdate(s.menu, item)
+}
+
+func (s *memoryStore) LoadOrders() ([]Order, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]Order, len(s.orders))
+	for i, order := range s.orders {
+		result[i] = copyOrder(order)
+	}
+	return result, nil
+}
+
+func (s *memoryStore) SaveOrders(orders []Order) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.orders = make([]Order, len(orders))
+	for i, order := range orders {
+		s.orders[i] = copyOrder(order)
+	}
+	return nil
+}
+
+func (s *memoryStore) DeleteOrders(ids []int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.orders = removeOrders(s.orders, ids)
+	return nil
+}
+
+// Penyimpanan berbasis file JSON untuk restoran kecil
+type jsonStore struct {
+	dir string
+}
+
+func (s *jsonStore) LoadMenu() ([]MenuItem, error) {
+	var items []MenuItem
+	found, err := readJSONFile(filepath.Join(s.dir, "menu.json"), &items)
+	if err != nil || !found {
+		return nil, err
+	}
+	if items == nil {
+		items = []MenuItem{}
+	}
+	return items, nil
+}
+
+func (s *jsonStore) SaveMenu(items []MenuItem) error {
+	var stored []MenuItem
+	if _, err := readJSONFile(filepath.Join(s.dir, "menu.json"), &stored); err != nil {
+		return err
+	}
+	return writeJSONFile(filepath.Join(s.dir, "menu.json"), keepNewerVersions(items, stored))
+}
+
+func (s *jsonStore) DeleteMenuItem(name string) error {
+	var stored []MenuItem
+	if _, err := readJSONFile(filepath.Join(s.dir, "menu.json"), &stored); err != nil {
+		return err
+	}
+	return writeJSONFile(filepath.Join(s.dir, "menu.json"), removeMenuItem(stored, name))
+}
+
+func (s *jsonStore) UpdateMenuItem(item MenuItem) (MenuItem, error) {
+	var stored []MenuItem
+	if _, err := readJSONFile(filepath.Join(s.dir, "menu.json"), &stored); err != nil {
+		return MenuItem{}, err
+	}
+
+	updated, err := applyMenuItemUpdate(stored, item)
+	if err != nil {
+		return MenuItem{}, err
+	}
+	return updated, writeJSONFile(filepath.Join(s.dir, "menu.json"), stored)
+}
+
+func (s *jsonStore) LoadOrders() ([]Order, error) {
+	var result []Order
+	_, err := readJSONFile(filepath.Join(s.dir, "orders.json"), &result)
+	return result, err
+}
+
+func (s *jsonStore) SaveOrders(orders []Order) error {
+	return writeJSONFile(filepath.Join(s.dir, "orders.json"), orders)
+}
+
+func (s *jsonStore) DeleteOrders(ids []int) error {
+	stored, err := s.LoadOrders()
+	if err != nil {
+		return err
+	}
+	return writeJSONFile(filepath.Join(s.dir, "orders.json"), removeOrders(stored, ids))
+}
+
+// Fungsi untuk membaca file JSON, mengembalikan false jika file belum ada
+func readJSONFile(path string, target any) (bool, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return false, describeJSONError(path, data, err)
+	}
+	return true, nil
+}
+
+// Fungsi untuk menulis data sebagai file JSON, isi lama disimpan sebagai cadangan
+func writeJSONFile(path string, data any) error {
+	encoded, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileSafely(path, encoded)
+}
@@ -0,0 +1,456 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Jenis event di log penyimpanan event sourcing
+const (
+	LogMenuItemAdded   = "MenuItemAdded"
+	LogMenuItemUpdated = "MenuItemUpdated"
+	LogMenuItemDeleted = "MenuItemDeleted"
+	LogPriceChanged    = "PriceChanged"
+	LogStockAdjusted   = "StockAdjusted"
+	LogOrderPlaced     = "OrderPlaced"
+	LogOrderUpdated    = "OrderUpdated"
+	LogOrdersArchived  = "OrdersArchived"
+)
+
+// Struct untuk satu event di log penyimpanan. Log hanya ditambah, tidak pernah diubah,
+// dan seluruh menu serta pesanan dibentuk ulang dengan memutar ulang event dari awal.
+type StoredEvent struct {
+	Seq     int       `json:"seq"`
+	Time    time.Time `json:"time"`
+	Type    string    `json:"type"`
+	Cashier string    `json:"cashier,omitempty"`
+	// Item lengkap tanpa varian untuk MenuItemAdded dan MenuItemUpdated
+	Item   *MenuItem `json:"item,omitempty"`
+	Parent string    `json:"parent,omitempty"`
+	// Nama item untuk MenuItemDeleted, PriceChanged dan StockAdjusted
+	Name     string `json:"name,omitempty"`
+	OldPrice Money  `json:"old_price,omitempty"`
+	Price    Money  `json:"price,omitempty"`
+	// Perubahan stok dan batch setelah perubahan untuk StockAdjusted
+	Delta   int          `json:"delta,omitempty"`
+	Batches []StockBatch `json:"batches,omitempty"`
+	// Pesanan lengkap untuk OrderPlaced dan OrderUpdated
+	Order    *Order `json:"order,omitempty"`
+	OrderIDs []int  `json:"order_ids,omitempty"`
+}
+
+// Penyimpanan event sourcing: hanya log event yang ditulis ke disk, menu dan pesanan
+// adalah proyeksi log di memori. Setiap penyimpanan dibandingkan dengan proyeksi dan
+// selisihnya dicatat sebagai event.
+type eventStore struct {
+	mu      sync.Mutex
+	path    string
+	lastSeq int
+	// Bernilai true setelah ada event menu, sebelum itu menu bawaan dipakai
+	hasMenu bool
+	menu    []MenuItem
+	orders  []Order
+}
+
+// Fungsi untuk membuka log event dan membentuk proyeksi menu dan pesanan
+func openEventStore(path string) (*eventStore, error) {
+	store := &eventStore{path: path}
+	events, err := readEventLog(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		store.apply(event)
+	}
+	return store, nil
+}
+
+// Fungsi untuk membaca semua event dari file log, log kosong jika file belum ada
+func readEventLog(path string) ([]StoredEvent, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	// Satu event per baris sehingga event yang rusak bisa ditunjuk nomor barisnya
+	var events []StoredEvent
+	lines := bufio.NewReader(file)
+	for lineNo := 1; ; lineNo++ {
+		line, err := lines.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			var event StoredEvent
+			if decodeErr := json.Unmarshal(line, &event); decodeErr != nil {
+				fileErr := describeJSONError(path, line, decodeErr)
+				fileErr.Line = lineNo
+				return nil, fileErr
+			}
+			events = append(events, event)
+		}
+		if errors.Is(err, io.EOF) {
+			return events, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Fungsi untuk menerapkan satu event ke proyeksi. Dipakai saat memutar ulang log
+// maupun setelah event baru ditulis sehingga keduanya selalu sama.
+func (s *eventStore) apply(event StoredEvent) {
+	s.lastSeq = max(s.lastSeq, event.Seq)
+	switch event.Type {
+	case LogMenuItemAdded:
+		s.hasMenu = true
+		item := copyMenuItem(*event.Item)
+		item.Variants = nil
+		if parent := findMenuItemIn(s.menu, event.Parent); event.Parent != "" && parent != nil {
+			parent.Variants = append(parent.Variants, item)
+			return
+		}
+		s.menu = append(s.menu, item)
+	case LogMenuItemUpdated:
+		if stored := findMenuItemIn(s.menu, event.Item.Name); stored != nil {
+			item := copyMenuItem(*event.Item)
+			item.Quantity, item.Batches, item.Variants = stored.Quantity, stored.Batches, stored.Variants
+			*stored = item
+		}
+	case LogMenuItemDeleted:
+		s.menu = removeMenuItem(s.menu, event.Name)
+	case LogPriceChanged:
+		if stored := findMenuItemIn(s.menu, event.Name); stored != nil {
+			stored.Price = event.Price
+		}
+	case LogStockAdjusted:
+		if stored := findMenuItemIn(s.menu, event.Name); stored != nil {
+			stored.Quantity += event.Delta
+			stored.Batches = append([]StockBatch(nil), event.Batches...)
+		}
+	case LogOrderPlaced, LogOrderUpdated:
+		order := copyOrder(*event.Order)
+		for i := range s.orders {
+			if s.orders[i].ID == order.ID {
+				s.orders[i] = order
+				return
+			}
+		}
+		s.orders = append(s.orders, order)
+	case LogOrdersArchived:
+		s.orders = removeOrders(s.orders, event.OrderIDs)
+	}
+}
+
+// Fungsi untuk menambahkan event ke akhir log lalu menerapkannya ke proyeksi.
+// Pemanggil harus memegang s.mu.
+func (s *eventStore) record(events []StoredEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	activityMutex.Lock()
+	cashier := currentCashier
+	activityMutex.Unlock()
+
+	now := time.Now()
+	var b strings.Builder
+	for i := range events {
+		events[i].Seq = s.lastSeq + i + 1
+		events[i].Time = now
+		events[i].Cashier = cashier
+		data, err := json.Marshal(events[i])
+		if err != nil {
+			return err
+		}
+		b.Write(data)
+		b.WriteString("\n")
+	}
+
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(b.String()); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		s.apply(event)
+	}
+	return nil
+}
+
+// Struct untuk item menu beserta nama item induknya, dipakai saat membandingkan menu
+type menuEntry struct {
+	item   MenuItem
+	parent string
+}
+
+// Fungsi untuk meratakan menu menjadi daftar item dan varian beserta item induknya,
+// urutannya sama dengan tampilan menu
+func menuEntries(items []MenuItem) []menuEntry {
+	var flat []menuEntry
+	for _, item := range items {
+		variants := item.Variants
+		item.Variants = nil
+		flat = append(flat, menuEntry{item: item})
+		for _, variant := range variants {
+			variant.Variants = nil
+			flat = append(flat, menuEntry{item: variant, parent: item.Name})
+		}
+	}
+	return flat
+}
+
+// Fungsi untuk menyusun event dari selisih item tersimpan dan item baru. Perubahan stok
+// dan harga dicatat sebagai event sendiri agar mudah ditelusuri.
+func menuItemChanges(stored, item MenuItem) []StoredEvent {
+	var events []StoredEvent
+	if stored.Quantity != item.Quantity || fingerprint(stored.Batches) != fingerprint(item.Batches) {
+		events = append(events, StoredEvent{Type: LogStockAdjusted, Name: item.Name, Delta: item.Quantity - stored.Quantity, Batches: item.Batches})
+	}
+	if stored.Price != item.Price {
+		events = append(events, StoredEvent{Type: LogPriceChanged, Name: item.Name, OldPrice: stored.Price, Price: item.Price})
+	}
+
+	// Field lain dibandingkan tanpa stok dan harga yang sudah dicatat di atas
+	stored.Quantity, stored.Batches, stored.Price = item.Quantity, item.Batches, item.Price
+	if fingerprint(stored) != fingerprint(item) {
+		updated := copyMenuItem(item)
+		events = append(events, StoredEvent{Type: LogMenuItemUpdated, Item: &updated})
+	}
+	return events
+}
+
+func (s *eventStore) LoadMenu() ([]MenuItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.hasMenu {
+		return nil, nil
+	}
+	items := make([]MenuItem, len(s.menu))
+	for i, item := range s.menu {
+		items[i] = copyMenuItem(item)
+	}
+	return items, nil
+}
+
+func (s *eventStore) SaveMenu(items []MenuItem) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current := menuEntries(keepNewerVersions(items, s.menu))
+	stored := map[string]menuEntry{}
+	for _, entry := range menuEntries(s.menu) {
+		stored[entry.item.Name] = entry
+	}
+
+	var events []StoredEvent
+	seen := map[string]bool{}
+	for _, entry := range current {
+		seen[entry.item.Name] = true
+		previous, ok := stored[entry.item.Name]
+		if !ok {
+			item := copyMenuItem(entry.item)
+			events = append(events, StoredEvent{Type: LogMenuItemAdded, Item: &item, Parent: entry.parent})
+			continue
+		}
+		events = append(events, menuItemChanges(previous.item, entry.item)...)
+	}
+	for _, entry := range menuEntries(s.menu) {
+		if !seen[entry.item.Name] {
+			events = append(events, StoredEvent{Type: LogMenuItemDeleted, Name: entry.item.Name})
+		}
+	}
+	return s.record(events)
+}
+
+func (s *eventStore) DeleteMenuItem(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if findMenuItemIn(s.menu, name) == nil {
+		return nil
+	}
+	return s.record([]StoredEvent{{Type: LogMenuItemDeleted, Name: name}})
+}
+
+func (s *eventStore) UpdateMenuItem(item MenuItem) (MenuItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := findMenuItemIn(s.menu, item.Name)
+	if stored == nil {
+		return MenuItem{}, fmt.Errorf("item %s tidak ditemukan di penyimpanan", item.Name)
+	}
+	if stored.Version != item.Version {
+		return MenuItem{}, &VersionConflictError{Current: copyMenuItem(*stored)}
+	}
+
+	// Hasil pembaruan dibentuk dengan aturan yang sama seperti penyimpanan lain, lalu
+	// dicatat sebagai event tanpa mengubah stok
+	previous := copyMenuItem(*stored)
+	previous.Variants = nil
+	scratch := []MenuItem{previous}
+	updated, err := applyMenuItemUpdate(scratch, item)
+	if err != nil {
+		return MenuItem{}, err
+	}
+	if err := s.record(menuItemChanges(previous, updated)); err != nil {
+		return MenuItem{}, err
+	}
+	return copyMenuItem(*findMenuItemIn(s.menu, item.Name)), nil
+}
+
+func (s *eventStore) LoadOrders() ([]Order, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]Order, len(s.orders))
+	for i, order := range s.orders {
+		result[i] = copyOrder(order)
+	}
+	return result, nil
+}
+
+// SaveOrders hanya mencatat pesanan baru dan pesanan yang berubah. Pesanan yang tidak
+// ada di snapshot tetap tersimpan sampai diarsipkan lewat DeleteOrders.
+func (s *eventStore) SaveOrders(orders []Order) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := map[int]string{}
+	for _, order := range s.orders {
+		stored[order.ID] = fingerprint(order)
+	}
+
+	var events []StoredEvent
+	for _, order := range orders {
+		previous, ok := stored[order.ID]
+		if ok && previous == fingerprint(order) {
+			continue
+		}
+		kind := LogOrderUpdated
+		if !ok {
+			kind = LogOrderPlaced
+		}
+		saved := copyOrder(order)
+		events = append(events, StoredEvent{Type: kind, Order: &saved})
+	}
+	return s.record(events)
+}
+
+func (s *eventStore) DeleteOrders(ids []int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.record([]StoredEvent{{Type: LogOrdersArchived, OrderIDs: ids}})
+}
+
+// Fungsi untuk menjalankan perintah `events [nama item]`: menampilkan log event, atau
+// riwayat stok satu item beserta saldo setelah setiap event untuk menelusuri stok minus
+func runEventLog(args []string) {
+	cfg := currentConfig()
+	if cfg.Storage != StorageEvents {
+		fmt.Println("Log event hanya tersedia untuk storage events.")
+		return
+	}
+	events, err := readEventLog(filepath.Join(cfg.DataDir, "events.jsonl"))
+	if err != nil {
+		fmt.Println("Gagal membaca log event:", err)
+		return
+	}
+
+	name := strings.TrimSpace(strings.Join(args, " "))
+	if name == "" {
+		for _, event := range events {
+			fmt.Printf("#%d %s %s%s\n", event.Seq, formatDateTime(event.Time), describeStoredEvent(event), describeEventCashier(event))
+		}
+		fmt.Printf("%d event.\n", len(events))
+		return
+	}
+
+	fmt.Printf("\n===== Riwayat Stok %s =====\n", name)
+	target := normalizeMenuName(name)
+	found := false
+	balance := 0
+	for _, event := range events {
+		switch {
+		case event.Type == LogMenuItemAdded && normalizeMenuName(event.Item.Name) == target:
+			balance = event.Item.Quantity
+		case event.Type == LogStockAdjusted && normalizeMenuName(event.Name) == target:
+			balance += event.Delta
+		case event.Type == LogMenuItemDeleted && normalizeMenuName(event.Name) == target:
+			balance = 0
+		default:
+			continue
+		}
+		found = true
+		marker := ""
+		if balance < 0 {
+			marker = " <- stok minus"
+		}
+		fmt.Printf("#%d %s %s | Stok: %d%s%s\n", event.Seq, formatDateTime(event.Time), describeStoredEvent(event), balance, describeEventCashier(event), marker)
+	}
+	if !found {
+		fmt.Println("Tidak ada event stok untuk item ini.")
+	}
+}
+
+// Fungsi untuk meringkas satu event log dalam satu baris
+func describeStoredEvent(event StoredEvent) string {
+	switch event.Type {
+	case LogMenuItemAdded:
+		text := fmt.Sprintf("%s %s (stok %d, harga %s)", event.Type, event.Item.Name, event.Item.Quantity, formatMoney(event.Item.Price))
+		if event.Parent != "" {
+			text += " varian " + event.Parent
+		}
+		return text
+	case LogMenuItemUpdated:
+		return fmt.Sprintf("%s %s (versi %d)", event.Type, event.Item.Name, event.Item.Version)
+	case LogMenuItemDeleted:
+		return fmt.Sprintf("%s %s", event.Type, event.Name)
+	case LogPriceChanged:
+		return fmt.Sprintf("%s %s %s -> %s", event.Type, event.Name, formatMoney(event.OldPrice), formatMoney(event.Price))
+	case LogStockAdjusted:
+		return fmt.Sprintf("%s %s %+d", event.Type, event.Name, event.Delta)
+	case LogOrderPlaced, LogOrderUpdated:
+		return fmt.Sprintf("%s pesanan %d: %s", event.Type, event.Order.ID, describeLines(event.Order.Lines))
+	case LogOrdersArchived:
+		return fmt.Sprintf("%s %s", event.Type, joinOrderIDs(event.OrderIDs))
+	}
+	return event.Type
+}
+
+// Fungsi untuk menampilkan kasir yang login saat event dicatat, kosong jika tidak ada
+func describeEventCashier(event StoredEvent) string {
+	if event.Cashier == "" {
+		return ""
+	}
+	return " | Kasir: " + event.Cashier
+}
@@ -0,0 +1,6 @@
+//go:build postgres
+
+package main
+
+// Mendaftarkan driver PostgreSQL, hanya ikut di-build dengan -tags postgres
+import _ "github.com/lib/pq"
@@ -0,0 +1,655 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Penyimpanan berbasis database SQL. SQLite dipakai satu terminal, sedangkan
+// PostgreSQL bisa dipakai bersama beberapa terminal kasir (shared).
+type sqlStore struct {
+	db     *sql.DB
+	driver string
+	shared bool
+
+	// Isi terakhir yang dimuat atau disimpan, agar hanya data yang berubah
+	// di terminal ini yang ditulis ulang ke database
+	savedItems  map[string]string
+	savedOrders map[int]string
+}
+
+// Skema tabel, dibuat otomatis saat database dibuka
+var sqlSchema = []string{
+	`CREATE TABLE IF NOT EXISTS menu_items (
+		name TEXT PRIMARY KEY,
+		position INTEGER NOT NULL,
+		price BIGINT NOT NULL,
+		quantity INTEGER NOT NULL,
+		station TEXT NOT NULL,
+		cost BIGINT NOT NULL,
+		batches TEXT NOT NULL,
+		version INTEGER NOT NULL,
+		parent TEXT NOT NULL,
+		restricted TEXT NOT NULL,
+		max_quantity INTEGER NOT NULL,
+		season_start TEXT NOT NULL,
+		season_end TEXT NOT NULL,
+		force_show INTEGER NOT NULL,
+		unavailable INTEGER NOT NULL,
+		unavailable_reason TEXT NOT NULL,
+		course TEXT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS orders (
+		id INTEGER PRIMARY KEY,
+		table_no INTEGER NOT NULL,
+		total_price BIGINT NOT NULL,
+		paid INTEGER NOT NULL,
+		pickup_at TEXT NOT NULL,
+		discount BIGINT NOT NULL,
+		voided INTEGER NOT NULL,
+		created_at TEXT NOT NULL,
+		customer_email TEXT NOT NULL,
+		note TEXT NOT NULL,
+		paid_at TEXT NOT NULL,
+		pickup_code TEXT NOT NULL,
+		guests INTEGER NOT NULL,
+		payment_method TEXT NOT NULL,
+		cashier TEXT NOT NULL,
+		delivery INTEGER NOT NULL,
+		merged_into INTEGER NOT NULL,
+		merged_from TEXT NOT NULL,
+		pending_ack INTEGER NOT NULL,
+		acknowledged_at TEXT NOT NULL,
+		tab TEXT NOT NULL,
+		tab_payments TEXT NOT NULL,
+		discount_approval TEXT NOT NULL,
+		cover_charge BIGINT NOT NULL,
+		minimum_spend_top_up BIGINT NOT NULL,
+		fields TEXT NOT NULL,
+		source TEXT NOT NULL,
+		price_list TEXT NOT NULL,
+		cash_tendered BIGINT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS order_lines (
+		order_id INTEGER NOT NULL,
+		line_no INTEGER NOT NULL,
+		item_name TEXT NOT NULL,
+		quantity INTEGER NOT NULL,
+		price BIGINT NOT NULL,
+		total_price BIGINT NOT NULL,
+		status TEXT NOT NULL,
+		returned INTEGER NOT NULL,
+		station TEXT NOT NULL,
+		started_at TEXT NOT NULL,
+		ready_at TEXT NOT NULL,
+		estimated_prep BIGINT NOT NULL,
+		list_price BIGINT NOT NULL,
+		price_reason TEXT NOT NULL,
+		fire_at TEXT NOT NULL,
+		modifiers TEXT NOT NULL,
+		PRIMARY KEY (order_id, line_no)
+	)`,
+}
+
+// Kolom uang per tabel. Nilainya sen (lihat Money), database lama yang masih menyimpan
+// rupiah sebagai DOUBLE PRECISION dimigrasi sekali saat dibuka.
+var sqlMoneyColumns = []struct {
+	table   string
+	columns []string
+}{
+	{"menu_items", []string{"price", "cost"}},
+	{"orders", []string{"total_price", "discount", "cover_charge", "minimum_spend_top_up", "cash_tendered"}},
+	{"order_lines", []string{"price", "total_price", "list_price"}},
+}
+
+// Skema tambahan untuk PostgreSQL, ID pesanan dibagikan lewat sequence agar
+// dua terminal tidak memakai ID yang sama
+var postgresSchema = []string{
+	`CREATE SEQUENCE IF NOT EXISTS order_ids`,
+	`SELECT setval('order_ids', GREATEST((SELECT COALESCE(MAX(id), 0) FROM orders), (SELECT last_value FROM order_ids)))`,
+}
+
+// Fungsi untuk membuka database dan menyiapkan skema. Driver harus didaftarkan
+// lewat build tag, misalnya `go build -tags sqlite` atau `go build -tags postgres`.
+func openSQLStore(driver, dsn string, shared bool) (*sqlStore, error) {
+	if !slices.Contains(sql.Drivers(), driver) {
+		return nil, fmt.Errorf("driver %s tidak tersedia, build ulang dengan -tags %s", driver, driver)
+	}
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := sqlSchema
+	if driver == "postgres" {
+		schema = append(slices.Clone(sqlSchema), postgresSchema...)
+	}
+	for _, statement := range schema {
+		if _, err := db.Exec(statement); err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+	if err := migrateMoneyColumns(db, driver); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrasi kolom uang: %w", err)
+	}
+
+	return &sqlStore{
+		db:          db,
+		driver:      driver,
+		shared:      shared,
+		savedItems:  map[string]string{},
+		savedOrders: map[int]string{},
+	}, nil
+}
+
+// Fungsi untuk mengubah kolom uang dari rupiah DOUBLE PRECISION menjadi sen BIGINT, sekali
+// per database. Penanda di schema_migrations ditulis dalam transaksi yang sama, sehingga
+// terminal lain yang membuka database bersamaan menunggu lalu melewati migrasi.
+func migrateMoneyColumns(db *sql.DB, driver string) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (name TEXT PRIMARY KEY)`); err != nil {
+		return err
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	store := &sqlStore{driver: driver}
+	result, err := tx.Exec(store.rebind(`INSERT INTO schema_migrations (name) VALUES (?) ON CONFLICT (name) DO NOTHING`), "money_cents")
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return err
+	}
+
+	for _, money := range sqlMoneyColumns {
+		for _, column := range money.columns {
+			// SQLite tidak bisa mengubah tipe kolom, kolom lama tetap REAL berisi sen bulat
+			statement := fmt.Sprintf(`UPDATE %s SET %s = ROUND(%s * %d)`, money.table, column, column, moneyScale)
+			if driver == "postgres" {
+				statement = fmt.Sprintf(`ALTER TABLE %s ALTER COLUMN %s TYPE BIGINT USING ROUND(%s * %d)`, money.table, column, column, moneyScale)
+			}
+			if _, err := tx.Exec(statement); err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+// Fungsi untuk mengubah placeholder ? menjadi $1, $2, ... untuk PostgreSQL
+func (s *sqlStore) rebind(query string) string {
+	if s.driver != "postgres" {
+		return query
+	}
+
+	var builder strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			builder.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// Fungsi untuk menyimpan bool sebagai 0/1 agar sama di semua database
+func sqlBool(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+// Fungsi untuk menyimpan daftar jenis pesanan sebagai teks dipisah koma
+func joinOrderTypes(types []OrderType) string {
+	parts := make([]string, len(types))
+	for i, orderType := range types {
+		parts[i] = string(orderType)
+	}
+	return strings.Join(parts, ",")
+}
+
+// Fungsi untuk membaca daftar jenis pesanan dari teks dipisah koma
+func splitOrderTypes(text string) []OrderType {
+	if text == "" {
+		return nil
+	}
+	var types []OrderType
+	for _, part := range strings.Split(text, ",") {
+		types = append(types, normalizeLegacy(OrderType(part), legacyOrderTypes))
+	}
+	return types
+}
+
+// Fungsi untuk menyimpan daftar ID pesanan sebagai teks dipisah koma
+func joinOrderIDs(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	return strings.Join(parts, ",")
+}
+
+// Fungsi untuk membaca daftar ID pesanan dari teks dipisah koma
+func splitOrderIDs(text string) ([]int, error) {
+	if text == "" {
+		return nil, nil
+	}
+	var ids []int
+	for _, part := range strings.Split(text, ",") {
+		id, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// Fungsi untuk menyimpan waktu sebagai teks RFC3339, waktu kosong disimpan sebagai ""
+func formatSQLTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+// Fungsi untuk membaca waktu yang disimpan formatSQLTime
+func parseSQLTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, value)
+}
+
+// Fungsi untuk membuat sidik data agar perubahan bisa dideteksi
+func fingerprint(value any) string {
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+func (s *sqlStore) LoadMenu() ([]MenuItem, error) {
+	rows, err := s.db.Query(`SELECT name, parent, price, quantity, station, cost, batches, version, restricted, max_quantity, season_start, season_end, force_show, unavailable, unavailable_reason, course FROM menu_items ORDER BY position`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Varian disimpan sebagai baris sendiri dengan kolom parent berisi nama item induk
+	var items []MenuItem
+	variants := map[string][]MenuItem{}
+	for rows.Next() {
+		var item MenuItem
+		var parent, batches, restricted, seasonStart, seasonEnd string
+		var forceShow, unavailable int
+		if err := rows.Scan(&item.Name, &parent, &item.Price, &item.Quantity, &item.Station, &item.Cost, &batches, &item.Version, &restricted, &item.MaxQuantity, &seasonStart, &seasonEnd, &forceShow, &unavailable, &item.UnavailableReason, &item.Course); err != nil {
+			return nil, err
+		}
+		item.Restricted = splitOrderTypes(restricted)
+		item.ForceShow = forceShow != 0
+		item.Unavailable = unavailable != 0
+		if item.SeasonStart, err = parseSQLTime(seasonStart); err != nil {
+			return nil, fmt.Errorf("season_start %s: %w", item.Name, err)
+		}
+		if item.SeasonEnd, err = parseSQLTime(seasonEnd); err != nil {
+			return nil, fmt.Errorf("season_end %s: %w", item.Name, err)
+		}
+		if err := json.Unmarshal([]byte(batches), &item.Batches); err != nil {
+			return nil, fmt.Errorf("batch %s: %w", item.Name, err)
+		}
+		s.savedItems[item.Name] = fingerprint(item)
+		if parent != "" {
+			variants[parent] = append(variants[parent], item)
+			continue
+		}
+		items = append(items, item)
+	}
+	for i := range items {
+		items[i].Variants = variants[items[i].Name]
+	}
+	return items, rows.Err()
+}
+
+func (s *sqlStore) SaveMenu(items []MenuItem) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Item yang versinya di database lebih baru tidak ditimpa. Terminal bersama hanya
+	// menulis item yang berubah dan tidak pernah menimpa stok (stok diubah lewat AdjustStock).
+	quantityUpdate := "quantity = excluded.quantity, "
+	if s.shared {
+		quantityUpdate = ""
+	}
+	query := s.rebind(`INSERT INTO menu_items (name, position, price, quantity, station, cost, batches, version, parent, restricted, max_quantity, season_start, season_end, force_show, unavailable, unavailable_reason, course) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (name) DO UPDATE SET position = excluded.position, price = excluded.price, ` + quantityUpdate + `
+		station = excluded.station, cost = excluded.cost, batches = excluded.batches, version = excluded.version,
+		parent = excluded.parent, restricted = excluded.restricted,
+		max_quantity = excluded.max_quantity, season_start = excluded.season_start,
+		season_end = excluded.season_end, force_show = excluded.force_show,
+		unavailable = excluded.unavailable, unavailable_reason = excluded.unavailable_reason,
+		course = excluded.course
+		WHERE menu_items.version <= excluded.version`)
+
+	// Varian ditulis sebagai baris tepat setelah item induknya
+	type menuRow struct {
+		item   MenuItem
+		parent string
+	}
+	var menuRows []menuRow
+	for _, item := range items {
+		variants := item.Variants
+		item.Variants = nil
+		menuRows = append(menuRows, menuRow{item: item})
+		for _, variant := range variants {
+			menuRows = append(menuRows, menuRow{item: variant, parent: item.Name})
+		}
+	}
+
+	saved := map[string]string{}
+	for position, row := range menuRows {
+		item := row.item
+		current := fingerprint(item)
+		saved[item.Name] = current
+		if s.shared && s.savedItems[item.Name] == current {
+			continue
+		}
+
+		batches, err := json.Marshal(item.Batches)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(query, item.Name, position, item.Price, item.Quantity, item.Station, item.Cost, string(batches), item.Version, row.parent, joinOrderTypes(item.Restricted), item.MaxQuantity,
+			formatSQLTime(item.SeasonStart), formatSQLTime(item.SeasonEnd), sqlBool(item.ForceShow), sqlBool(item.Unavailable), item.UnavailableReason, item.Course); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.savedItems = saved
+	return nil
+}
+
+func (s *sqlStore) DeleteMenuItem(name string) error {
+	_, err := s.db.Exec(s.rebind(`DELETE FROM menu_items WHERE name = ? OR parent = ?`), name, name)
+	return err
+}
+
+func (s *sqlStore) UpdateMenuItem(item MenuItem) (MenuItem, error) {
+	result, err := s.db.Exec(s.rebind(`UPDATE menu_items SET price = ?, station = ?, restricted = ?, max_quantity = ?, season_start = ?, season_end = ?, force_show = ?, unavailable = ?, unavailable_reason = ?, course = ?, version = version + 1 WHERE name = ? AND version = ?`),
+		item.Price, item.Station, joinOrderTypes(item.Restricted), item.MaxQuantity, formatSQLTime(item.SeasonStart), formatSQLTime(item.SeasonEnd), sqlBool(item.ForceShow), sqlBool(item.Unavailable), item.UnavailableReason, item.Course, item.Name, item.Version)
+	if err != nil {
+		return MenuItem{}, err
+	}
+	if affected, err := result.RowsAffected(); err != nil || affected == 1 {
+		item.Version++
+		return item, err
+	}
+
+	// Tidak ada baris yang berubah, baca item terbaru untuk diselesaikan oleh pemanggil
+	var current MenuItem
+	var batches, restricted, seasonStart, seasonEnd string
+	var forceShow, unavailable int
+	err = s.db.QueryRow(s.rebind(`SELECT name, price, quantity, station, cost, batches, version, restricted, max_quantity, season_start, season_end, force_show, unavailable, unavailable_reason, course FROM menu_items WHERE name = ?`), item.Name).
+		Scan(&current.Name, &current.Price, &current.Quantity, &current.Station, &current.Cost, &batches, &current.Version, &restricted, &current.MaxQuantity, &seasonStart, &seasonEnd, &forceShow, &unavailable, &current.UnavailableReason, &current.Course)
+	if errors.Is(err, sql.ErrNoRows) {
+		return MenuItem{}, fmt.Errorf("item %s tidak ditemukan di penyimpanan", item.Name)
+	}
+	if err != nil {
+		return MenuItem{}, err
+	}
+	if err := json.Unmarshal([]byte(batches), &current.Batches); err != nil {
+		return MenuItem{}, fmt.Errorf("batch %s: %w", current.Name, err)
+	}
+	current.Restricted = splitOrderTypes(restricted)
+	current.ForceShow = forceShow != 0
+	current.Unavailable = unavailable != 0
+	current.SeasonStart, _ = parseSQLTime(seasonStart)
+	current.SeasonEnd, _ = parseSQLTime(seasonEnd)
+	return MenuItem{}, &VersionConflictError{Current: current}
+}
+
+func (s *sqlStore) LoadOrders() ([]Order, error) {
+	rows, err := s.db.Query(`SELECT id, table_no, total_price, paid, pickup_at, discount, voided, created_at, customer_email, note, paid_at, pickup_code, guests, payment_method, cashier, delivery, merged_into, merged_from, pending_ack, acknowledged_at, tab, tab_payments, discount_approval, cover_charge, minimum_spend_top_up, fields, source, price_list, cash_tendered FROM orders ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Order
+	index := map[int]int{}
+	for rows.Next() {
+		var order Order
+		var paid, voided, delivery, pendingAck int
+		var pickupAt, createdAt, paidAt, mergedFrom, acknowledgedAt, tabPayments, discountApproval, fields string
+		if err := rows.Scan(&order.ID, &order.Table, &order.TotalPrice, &paid, &pickupAt, &order.Discount, &voided, &createdAt, &order.CustomerEmail, &order.Note, &paidAt, &order.PickupCode, &order.Guests, &order.PaymentMethod, &order.Cashier, &delivery, &order.MergedInto, &mergedFrom, &pendingAck, &acknowledgedAt, &order.Tab, &tabPayments, &discountApproval, &order.CoverCharge, &order.MinimumSpendTopUp, &fields, &order.Source, &order.PriceList, &order.CashTendered); err != nil {
+			return nil, err
+		}
+		order.Paid = paid != 0
+		order.Voided = voided != 0
+		order.Delivery = delivery != 0
+		order.PendingAck = pendingAck != 0
+		order.PaymentMethod = normalizeLegacy(order.PaymentMethod, legacyPaymentMethods)
+		if order.MergedFrom, err = splitOrderIDs(mergedFrom); err != nil {
+			return nil, fmt.Errorf("merged_from pesanan %d: %w", order.ID, err)
+		}
+		if order.PickupAt, err = parseSQLTime(pickupAt); err != nil {
+			return nil, fmt.Errorf("pesanan %d: %w", order.ID, err)
+		}
+		if order.CreatedAt, err = parseSQLTime(createdAt); err != nil {
+			return nil, fmt.Errorf("pesanan %d: %w", order.ID, err)
+		}
+		if order.PaidAt, err = parseSQLTime(paidAt); err != nil {
+			return nil, fmt.Errorf("pesanan %d: %w", order.ID, err)
+		}
+		if order.AcknowledgedAt, err = parseSQLTime(acknowledgedAt); err != nil {
+			return nil, fmt.Errorf("pesanan %d: %w", order.ID, err)
+		}
+		if err := json.Unmarshal([]byte(tabPayments), &order.TabPayments); err != nil {
+			return nil, fmt.Errorf("pembayaran tab pesanan %d: %w", order.ID, err)
+		}
+		if err := json.Unmarshal([]byte(discountApproval), &order.DiscountApproval); err != nil {
+			return nil, fmt.Errorf("persetujuan diskon pesanan %d: %w", order.ID, err)
+		}
+		if err := json.Unmarshal([]byte(fields), &order.Fields); err != nil {
+			return nil, fmt.Errorf("kolom tambahan pesanan %d: %w", order.ID, err)
+		}
+		index[order.ID] = len(result)
+		result = append(result, order)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	lineRows, err := s.db.Query(`SELECT order_id, line_no, item_name, quantity, price, total_price, status, returned, station, started_at, ready_at, estimated_prep, list_price, price_reason, fire_at, modifiers FROM order_lines ORDER BY order_id, line_no`)
+	if err != nil {
+		return nil, err
+	}
+	defer lineRows.Close()
+
+	for lineRows.Next() {
+		var orderID int
+		var line OrderLine
+		var startedAt, readyAt, fireAt, modifiers string
+		if err := lineRows.Scan(&orderID, &line.No, &line.ItemName, &line.Quantity, &line.Price, &line.TotalPrice, &line.Status, &line.Returned, &line.Station, &startedAt, &readyAt, &line.EstimatedPrep, &line.ListPrice, &line.PriceReason, &fireAt, &modifiers); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(modifiers), &line.Modifiers); err != nil {
+			return nil, err
+		}
+		line.Status = normalizeLegacy(line.Status, legacyLineStatuses)
+		if line.StartedAt, err = parseSQLTime(startedAt); err != nil {
+			return nil, err
+		}
+		if line.ReadyAt, err = parseSQLTime(readyAt); err != nil {
+			return nil, err
+		}
+		if line.FireAt, err = parseSQLTime(fireAt); err != nil {
+			return nil, err
+		}
+		if i, ok := index[orderID]; ok {
+			result[i].Lines = append(result[i].Lines, line)
+		}
+	}
+	if err := lineRows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, order := range result {
+		s.savedOrders[order.ID] = fingerprint(order)
+	}
+	return result, nil
+}
+
+// SaveOrders hanya menulis pesanan yang berubah di terminal ini, sehingga pesanan
+// yang diubah terminal lain tidak tertimpa salinan lama
+func (s *sqlStore) SaveOrders(orders []Order) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	upsertOrder := s.rebind(`INSERT INTO orders (id, table_no, total_price, paid, pickup_at, discount, voided, created_at, customer_email, note, paid_at, pickup_code, guests, payment_method, cashier, delivery, merged_into, merged_from, pending_ack, acknowledged_at, tab, tab_payments, discount_approval, cover_charge, minimum_spend_top_up, fields, source, price_list, cash_tendered) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET table_no = excluded.table_no, total_price = excluded.total_price,
+		paid = excluded.paid, pickup_at = excluded.pickup_at, discount = excluded.discount,
+		voided = excluded.voided, created_at = excluded.created_at, customer_email = excluded.customer_email, note = excluded.note, paid_at = excluded.paid_at, pickup_code = excluded.pickup_code, guests = excluded.guests, payment_method = excluded.payment_method, cashier = excluded.cashier, delivery = excluded.delivery, merged_into = excluded.merged_into, merged_from = excluded.merged_from, pending_ack = excluded.pending_ack, acknowledged_at = excluded.acknowledged_at, tab = excluded.tab, tab_payments = excluded.tab_payments, discount_approval = excluded.discount_approval, cover_charge = excluded.cover_charge, minimum_spend_top_up = excluded.minimum_spend_top_up, fields = excluded.fields, source = excluded.source, price_list = excluded.price_list, cash_tendered = excluded.cash_tendered`)
+	deleteLines := s.rebind(`DELETE FROM order_lines WHERE order_id = ?`)
+	insertLine := s.rebind(`INSERT INTO order_lines (order_id, line_no, item_name, quantity, price, total_price, status, returned, station, started_at, ready_at, estimated_prep, list_price, price_reason, fire_at, modifiers) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+
+	changed := map[int]string{}
+	for _, order := range orders {
+		current := fingerprint(order)
+		if s.savedOrders[order.ID] == current {
+			continue
+		}
+		changed[order.ID] = current
+
+		tabPayments, err := json.Marshal(order.TabPayments)
+		if err != nil {
+			return err
+		}
+		discountApproval, err := json.Marshal(order.DiscountApproval)
+		if err != nil {
+			return err
+		}
+		fields, err := json.Marshal(order.Fields)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(upsertOrder, order.ID, order.Table, order.TotalPrice, sqlBool(order.Paid), formatSQLTime(order.PickupAt),
+			order.Discount, sqlBool(order.Voided), formatSQLTime(order.CreatedAt), order.CustomerEmail, order.Note, formatSQLTime(order.PaidAt), order.PickupCode, order.Guests, order.PaymentMethod, order.Cashier, sqlBool(order.Delivery), order.MergedInto, joinOrderIDs(order.MergedFrom), sqlBool(order.PendingAck), formatSQLTime(order.AcknowledgedAt), order.Tab, string(tabPayments), string(discountApproval), order.CoverCharge, order.MinimumSpendTopUp, string(fields), order.Source, order.PriceList, order.CashTendered); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(deleteLines, order.ID); err != nil {
+			return err
+		}
+		for _, line := range order.Lines {
+			modifiers, err := json.Marshal(line.Modifiers)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.Exec(insertLine, order.ID, line.No, line.ItemName, line.Quantity, line.Price, line.TotalPrice, line.Status, line.Returned, line.Station, formatSQLTime(line.StartedAt), formatSQLTime(line.ReadyAt), int64(line.EstimatedPrep), line.ListPrice, line.PriceReason, formatSQLTime(line.FireAt), string(modifiers)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	for id, current := range changed {
+		s.savedOrders[id] = current
+	}
+	return nil
+}
+
+func (s *sqlStore) DeleteOrders(ids []int) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, id := range ids {
+		if _, err := tx.Exec(s.rebind(`DELETE FROM order_lines WHERE order_id = ?`), id); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(s.rebind(`DELETE FROM orders WHERE id = ?`), id); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		delete(s.savedOrders, id)
+	}
+	return nil
+}
+
+// AdjustStock mengubah stok langsung di database dalam satu statement atomik,
+// sehingga dua terminal tidak bisa menjual porsi terakhir yang sama
+func (s *sqlStore) AdjustStock(name string, delta int) (int, error) {
+	var remaining int
+	err := s.db.QueryRow(s.rebind(`UPDATE menu_items SET quantity = quantity + ? WHERE name = ? AND quantity + ? >= 0 RETURNING quantity`),
+		delta, name, delta).Scan(&remaining)
+	if errors.Is(err, sql.ErrNoRows) {
+		var exists int
+		if err := s.db.QueryRow(s.rebind(`SELECT COUNT(*) FROM menu_items WHERE name = ?`), name).Scan(&exists); err != nil {
+			return 0, err
+		}
+		if exists == 0 {
+			return 0, fmt.Errorf("item %s belum ada di database", name)
+		}
+		return 0, errInsufficientStock
+	}
+	return remaining, err
+}
+
+// StockLevels membaca stok terbaru semua item, termasuk perubahan dari terminal lain
+func (s *sqlStore) StockLevels() (map[string]int, error) {
+	rows, err := s.db.Query(`SELECT name, quantity FROM menu_items`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	levels := map[string]int{}
+	for rows.Next() {
+		var name string
+		var quantity int
+		if err := rows.Scan(&name, &quantity); err != nil {
+			return nil, err
+		}
+		levels[name] = quantity
+	}
+	return levels, rows.Err()
+}
+
+// NextOrderID mengambil ID pesanan dari sequence bersama
+func (s *sqlStore) NextOrderID() (int, error) {
+	var id int
+	err := s.db.QueryRow(`SELECT nextval('order_ids')`).Scan(&id)
+	return id, err
+}
@@ -0,0 +1,6 @@
+//go:build sqlite
+
+package main
+
+// Mendaftarkan driver SQLite murni Go, hanya ikut di-build dengan -tags sqlite
+import _ "modernc.org/sqlite"
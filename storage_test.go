@@ -0,0 +1,206 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// Penyimpanan yang diuji dengan kontrak yang sama. open dipanggil ulang untuk memeriksa
+// bahwa data tetap ada setelah penyimpanan dibuka kembali, penyimpanan memori
+// mengembalikan objek yang sama karena datanya memang hanya ada selama program berjalan.
+type repositoryCase struct {
+	name string
+	open func(t *testing.T) (MenuRepository, OrderRepository)
+}
+
+// Fungsi untuk menyiapkan daftar penyimpanan yang diuji, masing-masing dengan folder sendiri
+func repositoryCases(t *testing.T) []repositoryCase {
+	memory := &memoryStore{}
+	jsonDir := t.TempDir()
+	eventsPath := filepath.Join(t.TempDir(), "events.jsonl")
+	return []repositoryCase{
+		{"memory", func(t *testing.T) (MenuRepository, OrderRepository) {
+			return memory, memory
+		}},
+		{"json", func(t *testing.T) (MenuRepository, OrderRepository) {
+			store := &jsonStore{dir: jsonDir}
+			return store, store
+		}},
+		{"events", func(t *testing.T) (MenuRepository, OrderRepository) {
+			store, err := openEventStore(eventsPath)
+			if err != nil {
+				t.Fatalf("openEventStore: %v", err)
+			}
+			return store, store
+		}},
+	}
+}
+
+// Fungsi untuk membuat menu contoh
+func contractMenu() []MenuItem {
+	return []MenuItem{
+		{Name: "Nasi Goreng", Price: 15000 * moneyScale, Quantity: 10, Station: StationWok},
+		{Name: "Es Teh", Price: 5000 * moneyScale, Quantity: 20, Station: StationBar},
+	}
+}
+
+// Fungsi untuk membuat pesanan contoh
+func contractOrders() []Order {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	var result []Order
+	for id := 1; id <= 3; id++ {
+		result = append(result, Order{
+			ID:         id,
+			Table:      id,
+			Lines:      []OrderLine{{No: 1, ItemName: "Es Teh", Quantity: id, Price: 5000 * moneyScale, TotalPrice: Money(id) * 5000 * moneyScale, Status: LineDone}},
+			TotalPrice: Money(id) * 5000 * moneyScale,
+			CreatedAt:  created,
+		})
+	}
+	return result
+}
+
+func TestRepositoryEmpty(t *testing.T) {
+	for _, tc := range repositoryCases(t) {
+		t.Run(tc.name, func(t *testing.T) {
+			menuStore, orderStore := tc.open(t)
+			items, err := menuStore.LoadMenu()
+			if err != nil || items != nil {
+				t.Fatalf("LoadMenu pada penyimpanan kosong = %v, %v; ingin nil, nil", items, err)
+			}
+			loaded, err := orderStore.LoadOrders()
+			if err != nil || len(loaded) != 0 {
+				t.Fatalf("LoadOrders pada penyimpanan kosong = %v, %v; ingin kosong", loaded, err)
+			}
+		})
+	}
+}
+
+func TestRepositoryRoundTrip(t *testing.T) {
+	for _, tc := range repositoryCases(t) {
+		t.Run(tc.name, func(t *testing.T) {
+			menuStore, orderStore := tc.open(t)
+			if err := menuStore.SaveMenu(contractMenu()); err != nil {
+				t.Fatalf("SaveMenu: %v", err)
+			}
+			if err := orderStore.SaveOrders(contractOrders()); err != nil {
+				t.Fatalf("SaveOrders: %v", err)
+			}
+
+			menuStore, orderStore = tc.open(t)
+			items, err := menuStore.LoadMenu()
+			if err != nil {
+				t.Fatalf("LoadMenu: %v", err)
+			}
+			want := contractMenu()
+			if len(items) != len(want) {
+				t.Fatalf("LoadMenu mengembalikan %d item, ingin %d", len(items), len(want))
+			}
+			for i := range want {
+				got := items[i]
+				if got.Name != want[i].Name || got.Price != want[i].Price || got.Quantity != want[i].Quantity || got.Station != want[i].Station {
+					t.Errorf("item %d = %+v, ingin %+v", i, got, want[i])
+				}
+			}
+
+			loaded, err := orderStore.LoadOrders()
+			if err != nil {
+				t.Fatalf("LoadOrders: %v", err)
+			}
+			wantOrders := contractOrders()
+			if len(loaded) != len(wantOrders) {
+				t.Fatalf("LoadOrders mengembalikan %d pesanan, ingin %d", len(loaded), len(wantOrders))
+			}
+			for i := range wantOrders {
+				got := loaded[i]
+				if got.ID != wantOrders[i].ID || got.TotalPrice != wantOrders[i].TotalPrice || !got.CreatedAt.Equal(wantOrders[i].CreatedAt) || len(got.Lines) != 1 || got.Lines[0].Quantity != wantOrders[i].Lines[0].Quantity {
+					t.Errorf("pesanan %d = %+v, ingin %+v", i, got, wantOrders[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRepositoryVersionConflict(t *testing.T) {
+	for _, tc := range repositoryCases(t) {
+		t.Run(tc.name, func(t *testing.T) {
+			menuStore, _ := tc.open(t)
+			if err := menuStore.SaveMenu(contractMenu()); err != nil {
+				t.Fatalf("SaveMenu: %v", err)
+			}
+			items, err := menuStore.LoadMenu()
+			if err != nil {
+				t.Fatalf("LoadMenu: %v", err)
+			}
+			stale := items[0]
+
+			edit := stale
+			edit.Price = 17000 * moneyScale
+			updated, err := menuStore.UpdateMenuItem(edit)
+			if err != nil {
+				t.Fatalf("UpdateMenuItem: %v", err)
+			}
+			if updated.Version != stale.Version+1 || updated.Price != edit.Price {
+				t.Fatalf("UpdateMenuItem = versi %d harga %v, ingin versi %d harga %v", updated.Version, updated.Price, stale.Version+1, edit.Price)
+			}
+
+			// Terminal lain yang masih memegang versi lama harus ditolak
+			other := stale
+			other.Price = 16000 * moneyScale
+			_, err = menuStore.UpdateMenuItem(other)
+			var conflict *VersionConflictError
+			if !errors.As(err, &conflict) {
+				t.Fatalf("UpdateMenuItem dengan versi lama = %v, ingin VersionConflictError", err)
+			}
+			if conflict.Current.Version != updated.Version || conflict.Current.Price != edit.Price {
+				t.Errorf("VersionConflictError.Current = versi %d harga %v, ingin versi %d harga %v", conflict.Current.Version, conflict.Current.Price, updated.Version, edit.Price)
+			}
+
+			// SaveMenu dengan snapshot lama tidak boleh menimpa item yang lebih baru
+			if err := menuStore.SaveMenu(items); err != nil {
+				t.Fatalf("SaveMenu: %v", err)
+			}
+			menuStore, _ = tc.open(t)
+			reloaded, err := menuStore.LoadMenu()
+			if err != nil {
+				t.Fatalf("LoadMenu: %v", err)
+			}
+			if got := findMenuItemIn(reloaded, stale.Name); got == nil || got.Price != edit.Price || got.Version != updated.Version {
+				t.Errorf("setelah SaveMenu snapshot lama item = %+v, ingin harga %v versi %d", got, edit.Price, updated.Version)
+			}
+		})
+	}
+}
+
+func TestRepositoryDeleteOrders(t *testing.T) {
+	for _, tc := range repositoryCases(t) {
+		t.Run(tc.name, func(t *testing.T) {
+			_, orderStore := tc.open(t)
+			if err := orderStore.SaveOrders(contractOrders()); err != nil {
+				t.Fatalf("SaveOrders: %v", err)
+			}
+			if err := orderStore.DeleteOrders([]int{2}); err != nil {
+				t.Fatalf("DeleteOrders: %v", err)
+			}
+			if err := orderStore.DeleteOrders(nil); err != nil {
+				t.Fatalf("DeleteOrders tanpa ID: %v", err)
+			}
+
+			_, orderStore = tc.open(t)
+			loaded, err := orderStore.LoadOrders()
+			if err != nil {
+				t.Fatalf("LoadOrders: %v", err)
+			}
+			var ids []int
+			for _, order := range loaded {
+				ids = append(ids, order.ID)
+			}
+			if !slices.Equal(ids, []int{1, 3}) {
+				t.Errorf("ID pesanan setelah DeleteOrders = %v, ingin [1 3]", ids)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Struct untuk barang yang dikembalikan ke pemasok, misalnya kiriman yang rusak
+type SupplierReturn struct {
+	Time            time.Time `json:"time"`
+	PurchaseOrderID int       `json:"purchase_order_id"`
+	SupplierID      int       `json:"supplier_id"`
+	ItemName        string    `json:"item_name"`
+	Quantity        int       `json:"quantity"`
+	// Harga beli per unit dari purchase order, menjadi kredit dari pemasok
+	UnitCost Money  `json:"unit_cost"`
+	Reason   string `json:"reason"`
+}
+
+// Catatan retur ke pemasok, dijaga purchasingMutex dan disimpan bersama purchase order
+var supplierReturns []SupplierReturn
+
+// Fungsi untuk menghitung jumlah item purchase order yang sudah dikembalikan ke pemasok,
+// pemanggil harus memegang purchasingMutex
+func returnedToSupplier(purchaseOrderID int, itemName string) int {
+	total := 0
+	for _, record := range supplierReturns {
+		if record.PurchaseOrderID == purchaseOrderID && strings.EqualFold(record.ItemName, itemName) {
+			total += record.Quantity
+		}
+	}
+	return total
+}
+
+// Fungsi untuk mencatat barang dari purchase order yang sudah diterima tetapi dikembalikan
+// ke pemasok. Stok berkurang, mutasinya dicatat di buku stok dengan harga beli PO, dan
+// harga pokok rata-rata dihitung ulang tanpa barang yang dikembalikan.
+func returnToSupplier(reader *bufio.Reader) {
+	fmt.Print("Masukkan ID purchase order: ")
+	idInput, _ := reader.ReadString('\n')
+	id, err := strconv.Atoi(strings.TrimSpace(idInput))
+	if err != nil {
+		fmt.Println("ID purchase order harus berupa angka.")
+		return
+	}
+
+	purchasingMutex.Lock()
+	ensurePurchasingLoaded()
+	po := findPurchaseOrder(id)
+	if po == nil {
+		purchasingMutex.Unlock()
+		fmt.Println("Purchase order tidak ditemukan.")
+		return
+	}
+	if po.Status != PurchaseReceived {
+		purchasingMutex.Unlock()
+		fmt.Println("Hanya purchase order yang sudah diterima yang bisa diretur.")
+		return
+	}
+	supplierID := po.SupplierID
+	lines := append([]PurchaseOrderLine(nil), po.Lines...)
+	for _, line := range lines {
+		fmt.Printf("  %s x%d @ %s | Sudah diretur: %d\n", line.ItemName, line.Quantity, formatMoney(line.UnitCost), returnedToSupplier(id, line.ItemName))
+	}
+	purchasingMutex.Unlock()
+
+	fmt.Print("Masukkan nama item yang diretur: ")
+	name, _ := reader.ReadString('\n')
+	name = strings.TrimSpace(name)
+	var line *PurchaseOrderLine
+	for i := range lines {
+		if strings.EqualFold(lines[i].ItemName, name) {
+			line = &lines[i]
+		}
+	}
+	if line == nil {
+		fmt.Println("Item tidak ada di purchase order ini.")
+		return
+	}
+
+	fmt.Print("Masukkan jumlah yang diretur: ")
+	quantityInput, _ := reader.ReadString('\n')
+	quantity, err := parseQuantityInput(quantityInput)
+	if err != nil || quantity <= 0 {
+		fmt.Println("Jumlah harus berupa angka positif.")
+		return
+	}
+
+	fmt.Print("Alasan retur (misal rusak saat pengiriman, kedaluwarsa): ")
+	reason, _ := reader.ReadString('\n')
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		fmt.Println("Alasan retur wajib diisi.")
+		return
+	}
+
+	menuMutex.Lock()
+	defer menuMutex.Unlock()
+	purchasingMutex.Lock()
+	defer purchasingMutex.Unlock()
+
+	if returned := returnedToSupplier(id, line.ItemName); quantity > line.Quantity-returned {
+		fmt.Printf("Jumlah retur melebihi jumlah yang diterima, tersisa %d yang bisa diretur.\n", line.Quantity-returned)
+		return
+	}
+	item := findMenuItem(line.ItemName)
+	if item == nil {
+		fmt.Printf("%s sudah tidak ada di menu.\n", line.ItemName)
+		return
+	}
+	if quantity > item.Quantity {
+		fmt.Printf("Jumlah retur melebihi stok %s yang tersedia (%d).\n", item.Name, item.Quantity)
+		return
+	}
+
+	// Nilai barang yang dikembalikan dikeluarkan dengan harga beli PO, sisa stok memakai
+	// rata-rata dari nilai yang tersisa
+	remaining := item.Quantity - quantity
+	value := item.Cost.Times(item.Quantity) - line.UnitCost.Times(quantity)
+	if err := item.removeStock(quantity); err != nil {
+		fmt.Println("Gagal mengurangi stok:", err)
+		return
+	}
+	if remaining > 0 && value > 0 {
+		item.Cost = value.Div(remaining)
+	}
+	recordCostedMovement(item.Name, -quantity, MovementSupplierReturn, fmt.Sprintf("PO %d: %s", id, reason), line.UnitCost)
+
+	record := SupplierReturn{
+		Time:            time.Now(),
+		PurchaseOrderID: id,
+		SupplierID:      supplierID,
+		ItemName:        item.Name,
+		Quantity:        quantity,
+		UnitCost:        line.UnitCost,
+		Reason:          reason,
+	}
+	supplierReturns = append(supplierReturns, record)
+	savePurchasing()
+	fmt.Printf("Retur %s x%d ke pemasok dicatat, kredit %s. Stok sekarang %d (harga pokok %s).\n", item.Name, quantity, formatMoney(line.UnitCost.Times(quantity)), item.Quantity, formatMoney(item.Cost))
+}
+
+// Fungsi untuk menjumlahkan nilai retur ke pemasok dalam rentang waktu (to bersifat eksklusif)
+func supplierReturnValue(from, to time.Time) Money {
+	purchasingMutex.Lock()
+	defer purchasingMutex.Unlock()
+	ensurePurchasingLoaded()
+
+	var total Money
+	for _, record := range supplierReturns {
+		if !record.Time.Before(from) && record.Time.Before(to) {
+			total += record.UnitCost.Times(record.Quantity)
+		}
+	}
+	return total
+}
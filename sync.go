@@ -0,0 +1,574 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Sinkronisasi offline-first ke server pusat, yaitu instance lain yang berjalan dengan
+// -serve. Pesanan dan perubahan item menu dicatat dulu di antrian lokal, lalu dikirim
+// berkala oleh penjadwal. Selama server pusat tidak bisa dihubungi antrian tetap
+// disimpan dan dikirim lagi setelah koneksi pulih.
+//
+// Aturan konflik:
+//  1. Pesanan milik terminal pembuatnya. Salinan di pusat selalu ditimpa versi terminal.
+//  2. Perubahan item menu hanya diterapkan di pusat jika item pusat belum berubah sejak
+//     terakhir disinkronkan. Jika sudah berubah, data pusat menang dan perubahan
+//     terminal dibuang serta dilaporkan sebagai konflik.
+//  3. Setelah mengirim, menu pusat ditarik dan menimpa harga, stasiun, pembatasan, batas
+//     jumlah dan musim item lokal yang tidak punya perubahan tertunda. Stok tidak
+//     disinkronkan karena setiap terminal memegang stoknya sendiri.
+
+// Nama file antrian sinkronisasi di folder data terminal
+const syncQueueFile = "sync_queue.json"
+
+// Folder di server pusat untuk salinan pesanan dari setiap terminal
+const syncDir = "sync"
+
+// Jeda antar sinkronisasi otomatis, juga jeda mencoba lagi saat pusat tidak bisa dihubungi
+const syncInterval = 30 * time.Second
+
+// Struct untuk pengaturan sinkronisasi ke server pusat
+type SyncConfig struct {
+	// Alamat server pusat, misalnya http://pusat:8080; kosongkan untuk menonaktifkan
+	URL string `json:"url"`
+	// Kunci API terminal ini, terdaftar di api_keys dan sync_clients server pusat
+	APIKey string `json:"api_key"`
+}
+
+// Struct untuk satu perubahan item menu yang menunggu dikirim ke pusat
+type SyncMenuChange struct {
+	Item MenuItem `json:"item"`
+	// Versi item di pusat yang menjadi dasar perubahan, 0 jika belum pernah disinkronkan
+	BaseVersion int `json:"base_version"`
+}
+
+// Body permintaan POST /sync dari terminal
+type SyncRequest struct {
+	Orders []Order          `json:"orders"`
+	Menu   []SyncMenuChange `json:"menu"`
+}
+
+// Struct untuk perubahan item dari terminal yang ditolak server pusat
+type SyncConflict struct {
+	Item   string `json:"item"`
+	Reason string `json:"reason"`
+}
+
+func (c SyncConflict) String() string {
+	return c.Item + ": " + c.Reason
+}
+
+// Body jawaban POST /sync berisi menu pusat terbaru dan perubahan yang ditolak
+type SyncResponse struct {
+	Menu      []MenuItem     `json:"menu"`
+	Conflicts []SyncConflict `json:"conflicts,omitempty"`
+}
+
+// Struct untuk antrian dan status sinkronisasi yang disimpan di folder data
+type syncState struct {
+	Orders map[int]Order             `json:"orders"`
+	Menu   map[string]SyncMenuChange `json:"menu"`
+	// Sidik pesanan yang terakhir masuk antrian, agar pesanan yang tidak berubah tidak dikirim ulang
+	OrderPrints map[int]string `json:"order_prints"`
+	// Versi setiap item di pusat saat terakhir disinkronkan
+	CentralVersions map[string]int `json:"central_versions"`
+	LastSync        time.Time      `json:"last_sync,omitzero"`
+	LastAttempt     time.Time      `json:"last_attempt,omitzero"`
+	LastError       string         `json:"last_error,omitempty"`
+	Conflicts       []SyncConflict `json:"conflicts,omitempty"`
+}
+
+var syncData syncState
+var syncLoaded bool
+var syncMutex sync.Mutex
+
+// Hanya satu sinkronisasi berjalan pada satu waktu, dari penjadwal atau dari menu
+var syncRunMutex sync.Mutex
+
+// Salinan pesanan dari terminal di server pusat ditulis satu per satu
+var syncedOrdersMutex sync.Mutex
+
+// Fungsi untuk memeriksa apakah sinkronisasi ke server pusat diaktifkan
+func syncEnabled() bool {
+	return currentConfig().Sync.URL != ""
+}
+
+// Fungsi untuk memuat antrian sinkronisasi dari file sekali saja, pemanggil harus memegang
+// syncMutex. Jika background bernilai true kegagalan ditulis lewat asyncPrintf.
+func ensureSyncLoaded(background bool) {
+	if !syncLoaded {
+		syncLoaded = true
+		if _, err := readJSONFile(filepath.Join(currentConfig().DataDir, syncQueueFile), &syncData); err != nil {
+			syncPrintf(background)("Gagal membaca antrian sinkronisasi: %v\n", err)
+		}
+	}
+	if syncData.Orders == nil {
+		syncData.Orders = map[int]Order{}
+	}
+	if syncData.Menu == nil {
+		syncData.Menu = map[string]SyncMenuChange{}
+	}
+	if syncData.OrderPrints == nil {
+		syncData.OrderPrints = map[int]string{}
+	}
+	if syncData.CentralVersions == nil {
+		syncData.CentralVersions = map[string]int{}
+	}
+}
+
+// Fungsi untuk menyimpan antrian sinkronisasi ke file, pemanggil harus memegang syncMutex.
+// Jika background bernilai true kegagalan ditulis lewat asyncPrintf.
+func saveSyncState(background bool) {
+	printf := syncPrintf(background)
+	dir := currentConfig().DataDir
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		printf("Gagal menyimpan antrian sinkronisasi: %v\n", err)
+		return
+	}
+	if err := writeJSONFile(filepath.Join(dir, syncQueueFile), syncData); err != nil {
+		printf("Gagal menyimpan antrian sinkronisasi: %v\n", err)
+	}
+}
+
+// Fungsi untuk memilih penulis pesan sinkronisasi: langsung ke layar untuk perintah kasir,
+// lewat asyncPrintf untuk penjadwal dan penyimpanan latar belakang
+func syncPrintf(background bool) func(format string, args ...any) {
+	if background {
+		return asyncPrintf
+	}
+	return func(format string, args ...any) { fmt.Printf(format, args...) }
+}
+
+// Fungsi untuk memasukkan pesanan yang berubah sejak terakhir diantrikan, dipanggil
+// setiap kali data disimpan. background diteruskan dari penyimpanan yang memanggilnya.
+func queueOrderSync(snapshot []Order, background bool) {
+	if !syncEnabled() {
+		return
+	}
+
+	syncMutex.Lock()
+	defer syncMutex.Unlock()
+
+	ensureSyncLoaded(background)
+	changed := false
+	present := map[int]bool{}
+	for _, order := range snapshot {
+		present[order.ID] = true
+		current := fingerprint(order)
+		if syncData.OrderPrints[order.ID] == current {
+			continue
+		}
+		syncData.Orders[order.ID] = order
+		syncData.OrderPrints[order.ID] = current
+		changed = true
+	}
+	// Pesanan yang sudah diarsipkan tidak perlu diingat lagi
+	for id := range syncData.OrderPrints {
+		if !present[id] {
+			delete(syncData.OrderPrints, id)
+			changed = true
+		}
+	}
+	if changed {
+		saveSyncState(background)
+	}
+}
+
+// Fungsi untuk memasukkan perubahan item menu ke antrian. Perubahan berikutnya untuk
+// item yang sama menggantikan isi antrian tetapi tetap memakai versi dasar yang pertama.
+func queueMenuSync(item MenuItem) {
+	if !syncEnabled() {
+		return
+	}
+
+	syncMutex.Lock()
+	defer syncMutex.Unlock()
+
+	ensureSyncLoaded(false)
+	change, ok := syncData.Menu[item.Name]
+	if !ok {
+		change.BaseVersion = syncData.CentralVersions[item.Name]
+	}
+	change.Item = item
+	syncData.Menu[item.Name] = change
+	saveSyncState(false)
+}
+
+// Repository menu yang mencatat setiap perubahan item ke antrian sinkronisasi
+type syncMenuRepo struct {
+	MenuRepository
+}
+
+func (r *syncMenuRepo) UpdateMenuItem(item MenuItem) (MenuItem, error) {
+	updated, err := r.MenuRepository.UpdateMenuItem(item)
+	if err == nil {
+		queueMenuSync(updated)
+	}
+	return updated, err
+}
+
+// Fungsi untuk mengambil repository menu tanpa pencatatan sinkronisasi, dipakai saat
+// menerapkan data dari pusat agar tidak dikirim balik
+func localMenuRepo() MenuRepository {
+	if repo, ok := menuRepo.(*syncMenuRepo); ok {
+		return repo.MenuRepository
+	}
+	return menuRepo
+}
+
+// Fungsi untuk mengirim permintaan sinkronisasi ke server pusat
+func postSync(cfg SyncConfig, request SyncRequest) (SyncResponse, error) {
+	var response SyncResponse
+	body, err := json.Marshal(request)
+	if err != nil {
+		return response, err
+	}
+
+	httpRequest, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(cfg.URL, "/")+"/sync", bytes.NewReader(body))
+	if err != nil {
+		return response, err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("X-API-Key", cfg.APIKey)
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	httpResponse, err := client.Do(httpRequest)
+	if err != nil {
+		return response, err
+	}
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode != http.StatusOK {
+		var apiErr apiError
+		json.NewDecoder(httpResponse.Body).Decode(&apiErr)
+		return response, fmt.Errorf("server pusat menjawab %s %s", httpResponse.Status, apiErr.Error)
+	}
+	err = json.NewDecoder(httpResponse.Body).Decode(&response)
+	return response, err
+}
+
+// Fungsi untuk mengirim antrian ke server pusat lalu menyamakan menu lokal dengan menu
+// pusat. Dipanggil berkala oleh penjadwal; force mengabaikan jeda antar sinkronisasi dan
+// background bernilai true jika dipanggil dari penjadwal sehingga pesan lewat asyncPrintf.
+func syncWithCentral(now time.Time, force, background bool) {
+	cfg := currentConfig().Sync
+	if cfg.URL == "" {
+		return
+	}
+
+	printf := syncPrintf(background)
+	syncRunMutex.Lock()
+	defer syncRunMutex.Unlock()
+
+	syncMutex.Lock()
+	ensureSyncLoaded(background)
+	if !force && now.Sub(syncData.LastAttempt) < syncInterval {
+		syncMutex.Unlock()
+		return
+	}
+	var request SyncRequest
+	for _, order := range syncData.Orders {
+		request.Orders = append(request.Orders, copyOrder(order))
+	}
+	for _, change := range syncData.Menu {
+		change.Item = copyMenuItem(change.Item)
+		request.Menu = append(request.Menu, change)
+	}
+	wasOffline := syncData.LastError != ""
+	syncData.LastAttempt = now
+	syncMutex.Unlock()
+
+	response, err := postSync(cfg, request)
+
+	syncMutex.Lock()
+	if err != nil {
+		if !wasOffline {
+			printf("\nServer pusat tidak bisa dihubungi (%v), perubahan disimpan di antrian lokal.\n", err)
+		}
+		syncData.LastError = err.Error()
+		saveSyncState(background)
+		syncMutex.Unlock()
+		return
+	}
+
+	// Isi antrian yang berubah lagi selama pengiriman tetap menunggu sinkronisasi berikutnya
+	for _, sent := range request.Orders {
+		if fingerprint(syncData.Orders[sent.ID]) == fingerprint(sent) {
+			delete(syncData.Orders, sent.ID)
+		}
+	}
+	conflicted := map[string]bool{}
+	for _, conflict := range response.Conflicts {
+		conflicted[conflict.Item] = true
+	}
+	for _, sent := range request.Menu {
+		current := syncData.Menu[sent.Item.Name]
+		if conflicted[sent.Item.Name] || fingerprint(current.Item) == fingerprint(sent.Item) {
+			delete(syncData.Menu, sent.Item.Name)
+		}
+	}
+
+	var central []MenuItem
+	for _, item := range response.Menu {
+		central = append(central, item)
+		central = append(central, item.Variants...)
+	}
+	var incoming []MenuItem
+	for _, item := range central {
+		syncData.CentralVersions[item.Name] = item.Version
+		if change, pending := syncData.Menu[item.Name]; pending {
+			// Perubahan yang menyusul tadi sekarang didasarkan pada versi pusat yang baru
+			change.BaseVersion = item.Version
+			syncData.Menu[item.Name] = change
+			continue
+		}
+		incoming = append(incoming, item)
+	}
+
+	sent := len(request.Orders) + len(request.Menu)
+	if wasOffline {
+		printf("\nTersambung lagi ke server pusat, %d perubahan tertunda disinkronkan.\n", sent)
+	}
+	for _, conflict := range response.Conflicts {
+		printf("\nKonflik sinkronisasi: %v\n", conflict)
+		logActivity("konflik sinkronisasi: " + conflict.String())
+	}
+	syncData.Conflicts = response.Conflicts
+	syncData.LastSync = now
+	syncData.LastError = ""
+	saveSyncState(background)
+	syncMutex.Unlock()
+
+	// Dry-run memakai salinan menu, jadi data pusat baru diterapkan setelah dry-run selesai
+	dryRunMutex.Lock()
+	applyCentralMenu(incoming, background)
+	dryRunMutex.Unlock()
+}
+
+// Fungsi untuk mengambil kolom item menu yang ikut disinkronkan
+func syncedMenuFields(item MenuItem) MenuItem {
+	return MenuItem{
+		Name:        item.Name,
+		Price:       item.Price,
+		Station:     item.Station,
+		Restricted:  item.Restricted,
+		MaxQuantity: item.MaxQuantity,
+		SeasonStart: item.SeasonStart,
+		SeasonEnd:   item.SeasonEnd,
+		ForceShow:   item.ForceShow,
+		// Item yang di-86 di satu terminal ikut tidak tersedia di terminal lain
+		Unavailable:       item.Unavailable,
+		UnavailableReason: item.UnavailableReason,
+		Course:            item.Course,
+	}
+}
+
+// Fungsi untuk menimpa item lokal dengan data pusat yang berbeda. Item yang hanya ada
+// di pusat tidak ditambahkan ke menu lokal. Jika background bernilai true pesan ditulis
+// lewat asyncPrintf.
+func applyCentralMenu(central []MenuItem, background bool) {
+	for _, item := range central {
+		menuMutex.Lock()
+		local := findMenuItem(item.Name)
+		var edited MenuItem
+		if local != nil {
+			edited = copyMenuItem(*local)
+		}
+		menuMutex.Unlock()
+		if local == nil || fingerprint(syncedMenuFields(edited)) == fingerprint(syncedMenuFields(item)) {
+			continue
+		}
+
+		edited.Price = item.Price
+		edited.Station = item.Station
+		edited.Restricted = item.Restricted
+		edited.MaxQuantity = item.MaxQuantity
+		edited.SeasonStart = item.SeasonStart
+		edited.SeasonEnd = item.SeasonEnd
+		edited.ForceShow = item.ForceShow
+		edited.Unavailable = item.Unavailable
+		edited.UnavailableReason = item.UnavailableReason
+		edited.Course = item.Course
+		updated, err := localMenuRepo().UpdateMenuItem(edited)
+		if err != nil {
+			// Item sedang diubah di terminal ini, perubahan itu akan dikirim ke pusat
+			continue
+		}
+		applyMenuItemEdit(updated)
+		syncPrintf(background)("\nItem %s diperbarui dari server pusat.\n", item.Name)
+	}
+}
+
+// Fungsi untuk menampilkan status sinkronisasi dan menawarkan sinkronisasi sekarang
+func syncStatus(reader *bufio.Reader) {
+	cfg := currentConfig().Sync
+	if cfg.URL == "" {
+		fmt.Println("Sinkronisasi belum diatur, isi sync.url dan sync.api_key di config.json.")
+		return
+	}
+
+	syncMutex.Lock()
+	ensureSyncLoaded(false)
+	fmt.Println("\n===== Status Sinkronisasi =====")
+	fmt.Printf("Server pusat: %s\n", cfg.URL)
+	if syncData.LastError != "" {
+		fmt.Printf("Status: OFFLINE (%s)\n", syncData.LastError)
+	} else if !syncData.LastAttempt.IsZero() {
+		fmt.Println("Status: online")
+	}
+	if syncData.LastSync.IsZero() {
+		fmt.Println("Sinkronisasi terakhir: belum pernah")
+	} else {
+		fmt.Printf("Sinkronisasi terakhir: %s\n", formatTimestamp(syncData.LastSync, time.Now()))
+	}
+	fmt.Printf("Antrian: %d pesanan, %d perubahan menu\n", len(syncData.Orders), len(syncData.Menu))
+	for _, conflict := range syncData.Conflicts {
+		fmt.Println("Konflik terakhir:", conflict)
+	}
+	syncMutex.Unlock()
+
+	fmt.Print("Sinkronkan sekarang? (y/n): ")
+	confirm, _ := reader.ReadString('\n')
+	if !strings.EqualFold(strings.TrimSpace(confirm), "y") {
+		return
+	}
+	syncWithCentral(time.Now(), true, false)
+
+	syncMutex.Lock()
+	defer syncMutex.Unlock()
+	if syncData.LastError != "" {
+		fmt.Println("Sinkronisasi gagal:", syncData.LastError)
+		return
+	}
+	fmt.Printf("Sinkronisasi selesai, antrian tersisa %d pesanan dan %d perubahan menu.\n", len(syncData.Orders), len(syncData.Menu))
+}
+
+// Karakter yang boleh dipakai di nama file salinan pesanan terminal
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// Fungsi untuk menyimpan salinan pesanan terminal di server pusat. Pesanan dengan ID
+// yang sama ditimpa karena terminal pembuatnya selalu memegang versi terbaru.
+func storeSyncedOrders(terminal string, incoming []Order) error {
+	if len(incoming) == 0 {
+		return nil
+	}
+
+	syncedOrdersMutex.Lock()
+	defer syncedOrdersMutex.Unlock()
+
+	dir := filepath.Join(currentConfig().DataDir, syncDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	path := filepath.Join(dir, unsafeFileChars.ReplaceAllString(terminal, "_")+".json")
+	var stored []Order
+	if _, err := readJSONFile(path, &stored); err != nil {
+		return err
+	}
+	for _, order := range incoming {
+		replaced := false
+		for i := range stored {
+			if stored[i].ID == order.ID {
+				stored[i] = order
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			stored = append(stored, order)
+		}
+	}
+	return writeJSONFile(path, stored)
+}
+
+// Fungsi untuk menerapkan perubahan item dari terminal di server pusat. Mengembalikan
+// false beserta alasannya jika perubahan ditolak, misalnya karena item pusat sudah
+// berubah sejak dasar perubahan terminal.
+func applyTerminalMenuChange(change SyncMenuChange) (string, bool) {
+	menuMutex.Lock()
+	item := findMenuItem(change.Item.Name)
+	var edited MenuItem
+	if item != nil {
+		edited = copyMenuItem(*item)
+	}
+	menuMutex.Unlock()
+	if item == nil {
+		return "tidak ada di menu pusat, perubahan terminal dibuang", false
+	}
+	if edited.Version != change.BaseVersion {
+		return fmt.Sprintf("sudah diubah di pusat (versi %d, dasar perubahan %d), data pusat dipakai", edited.Version, change.BaseVersion), false
+	}
+
+	edited.Price = change.Item.Price
+	edited.Station = change.Item.Station
+	edited.Restricted = change.Item.Restricted
+	edited.MaxQuantity = change.Item.MaxQuantity
+	edited.SeasonStart = change.Item.SeasonStart
+	edited.SeasonEnd = change.Item.SeasonEnd
+	edited.ForceShow = change.Item.ForceShow
+	edited.Unavailable = change.Item.Unavailable
+	edited.UnavailableReason = change.Item.UnavailableReason
+	edited.Course = change.Item.Course
+	updated, err := activeMenuRepo().UpdateMenuItem(edited)
+	var conflict *VersionConflictError
+	if errors.As(err, &conflict) {
+		return fmt.Sprintf("sudah diubah di pusat (versi %d, dasar perubahan %d), data pusat dipakai", conflict.Current.Version, change.BaseVersion), false
+	}
+	if err != nil {
+		return fmt.Sprintf("gagal disimpan di pusat (%v)", err), false
+	}
+	applyMenuItemEdit(updated)
+	return "", true
+}
+
+// POST /sync menerima pesanan dan perubahan menu dari terminal, lalu mengembalikan menu pusat.
+// Hanya klien yang terdaftar di sync_clients yang boleh memakainya.
+func handleSync(w http.ResponseWriter, r *http.Request) {
+	terminal := apiClientName(r)
+	if !slices.Contains(currentConfig().SyncClients, terminal) {
+		writeAPIError(w, http.StatusForbidden, "klien "+terminal+" tidak terdaftar di sync_clients")
+		return
+	}
+
+	var request SyncRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "body JSON tidak valid: "+err.Error())
+		return
+	}
+
+	if err := storeSyncedOrders(terminal, request.Orders); err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "gagal menyimpan pesanan: "+err.Error())
+		return
+	}
+
+	var response SyncResponse
+	for _, change := range request.Menu {
+		if reason, ok := applyTerminalMenuChange(change); !ok {
+			response.Conflicts = append(response.Conflicts, SyncConflict{Item: change.Item.Name, Reason: reason})
+		}
+	}
+
+	menuMutex.Lock()
+	for _, item := range menu {
+		response.Menu = append(response.Menu, copyMenuItem(item))
+	}
+	menuMutex.Unlock()
+
+	if len(request.Orders) > 0 || len(request.Menu) > 0 {
+		logActivity(fmt.Sprintf("sinkronisasi dari %s: %d pesanan, %d perubahan menu, %d konflik",
+			terminal, len(request.Orders), len(request.Menu), len(response.Conflicts)))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSyncRequiresSyncClient(t *testing.T) {
+	useJSONStorage(t)
+	configMutex.Lock()
+	config.APIKeys = map[string]string{"kiosk": "kunci-kiosk", "kasir2": "kunci-kasir2"}
+	config.APIClientSources = map[string]OrderSource{"kiosk": SourceKiosk}
+	config.SyncClients = []string{"kasir2"}
+	configMutex.Unlock()
+
+	price := Money(15000) * moneyScale
+	items := []MenuItem{{Name: "Nasi Goreng", Price: price, Quantity: 10, Version: 1}}
+	useOrders(t, items, nil, 0)
+	if err := menuRepo.SaveMenu(items); err != nil {
+		t.Fatalf("SaveMenu: %v", err)
+	}
+	body := `{"menu": [{"item": {"name": "Nasi Goreng", "price": 1}, "base_version": 1}]}`
+
+	tests := []struct {
+		key    string
+		status int
+		price  Money
+	}{
+		// Kunci kiosk valid untuk API tetapi tidak boleh menimpa harga di pusat
+		{"kunci-kiosk", http.StatusForbidden, price},
+		{"kunci-kasir2", http.StatusOK, 1 * moneyScale},
+	}
+	handler := requireAPIKey(http.HandlerFunc(handleSync))
+	for _, tt := range tests {
+		request := httptest.NewRequest(http.MethodPost, "/sync", strings.NewReader(body))
+		request.Header.Set("X-API-Key", tt.key)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		if recorder.Code != tt.status {
+			t.Errorf("POST /sync dengan %s = %d, ingin %d: %s", tt.key, recorder.Code, tt.status, recorder.Body)
+		}
+
+		menuMutex.Lock()
+		got := menu[0].Price
+		menuMutex.Unlock()
+		if got != tt.price {
+			t.Errorf("harga setelah POST /sync dengan %s = %s, ingin %s", tt.key, formatMoney(got), formatMoney(tt.price))
+		}
+	}
+}
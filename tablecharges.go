@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Fungsi untuk menghitung ulang biaya cover dan top-up minimum belanja pesanan meja
+// sesuai konfigurasi saat ini. Dipanggil saat meja dibayar; pesanan yang sudah dibayar
+// tidak diubah agar laporan lama tetap sama walaupun konfigurasi berubah.
+// Pemanggil harus memegang ordersMutex.
+func applyTableCharges(order *Order) {
+	if order.Paid || order.Voided {
+		return
+	}
+	order.CoverCharge = 0
+	order.MinimumSpendTopUp = 0
+	if order.Table == 0 || order.Delivery {
+		return
+	}
+	cfg := currentConfig()
+	order.CoverCharge = cfg.CoverCharge.Times(order.Guests)
+	if food := order.TotalPrice - order.Discount; food < cfg.TableMinimumSpend {
+		order.MinimumSpendTopUp = cfg.TableMinimumSpend - food
+	}
+}
+
+// Fungsi untuk menjumlahkan biaya meja yang tidak termasuk pendapatan makanan
+func (order *Order) TableCharges() Money {
+	return order.CoverCharge + order.MinimumSpendTopUp
+}
+
+// Fungsi untuk menampilkan rincian biaya meja di tagihan, kosong jika tidak ada
+func describeTableCharges(order *Order) string {
+	var parts []string
+	if order.CoverCharge > 0 {
+		parts = append(parts, fmt.Sprintf("cover %d tamu %s", order.Guests, formatMoney(order.CoverCharge)))
+	}
+	if order.MinimumSpendTopUp > 0 {
+		parts = append(parts, "top-up minimum belanja "+formatMoney(order.MinimumSpendTopUp))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (termasuk " + strings.Join(parts, ", ") + ")"
+}
+
+// Fungsi untuk menulis biaya meja ke struk atau laporan, baris nol tidak ditulis
+func writeTableCharges(l *textLayout, cover, topUp Money) {
+	if cover > 0 {
+		l.Pair("Cover Charge", formatMoney(cover))
+	}
+	if topUp > 0 {
+		l.Pair("Top-up Minimum Belanja", formatMoney(topUp))
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Fungsi untuk mencari pesanan yang masih menempati sebuah meja, yaitu pesanan
+// yang belum selesai (belum dibayar atau masih dimasak). Pemanggil harus memegang ordersMutex.
+func tableOccupant(table int) *Order {
+	for _, order := range orders {
+		if order.Table == table && !orderClosed(order) {
+			return order
+		}
+	}
+	return nil
+}
+
+// Fungsi untuk memindahkan pesanan makan di tempat ke meja lain saat tamu pindah
+// tempat duduk. Tiket dapur membaca meja dari pesanan sehingga ikut berpindah.
+func transferTable(reader *bufio.Reader) {
+	fmt.Print("Pesanan yang dipindah (ID atau \"meja <nomor>\"): ")
+	orderInput, _ := reader.ReadString('\n')
+	fmt.Print("Pindah ke meja: ")
+	tableInput, _ := reader.ReadString('\n')
+	table, ok := parseTableNumber(tableInput)
+	if !ok || table == 0 {
+		fmt.Println("Nomor meja harus berupa angka positif.")
+		return
+	}
+
+	ordersMutex.Lock()
+	order, err := findOrderRef(orderInput)
+	if err != nil {
+		ordersMutex.Unlock()
+		fmt.Println(err)
+		return
+	}
+	order = followMergedOrder(order)
+
+	var problem string
+	switch {
+	case order.Table == 0:
+		problem = "Hanya pesanan makan di tempat yang bisa dipindah meja."
+	case orderClosed(order):
+		problem = fmt.Sprintf("Pesanan ID %d sudah selesai.", order.ID)
+	case order.Table == table:
+		problem = fmt.Sprintf("Pesanan ID %d sudah di meja %d.", order.ID, table)
+	}
+	if occupant := tableOccupant(table); problem == "" && occupant != nil {
+		problem = fmt.Sprintf("Meja %d masih dipakai pesanan ID %d, gunakan Gabung Pesanan / Meja untuk menggabungkan tagihan.", table, occupant.ID)
+	}
+	if problem != "" {
+		ordersMutex.Unlock()
+		fmt.Println(problem)
+		return
+	}
+
+	previous := order.Table
+	order.Table = table
+	publishOrderEvent(OrderEvent{Type: EventOrderMoved, OrderID: order.ID, PickupCode: order.PickupCode, Table: table})
+	id := order.ID
+	ordersMutex.Unlock()
+
+	logActivity(fmt.Sprintf("pindah pesanan %d dari meja %d ke meja %d", id, previous, table))
+	fmt.Printf("Pesanan ID %d dipindah dari meja %d ke meja %d.\n", id, previous, table)
+}
+
+// Fungsi untuk menampilkan meja yang sedang terisi beserta pesanannya
+func displayOccupiedTables() {
+	ordersMutex.Lock()
+	defer ordersMutex.Unlock()
+
+	now := time.Now()
+	var occupied []string
+	for _, order := range orders {
+		if order.Table > 0 && !orderClosed(order) {
+			occupied = append(occupied, fmt.Sprintf("meja %d (pesanan ID %d, %s)", order.Table, order.ID, formatRelative(order.CreatedAt, now)))
+		}
+	}
+	if len(occupied) == 0 {
+		fmt.Println("Meja terisi: -")
+		return
+	}
+	fmt.Println("Meja terisi:", strings.Join(occupied, ", "))
+}
@@ -0,0 +1,321 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Struct untuk satu pembayaran sebagian pesanan: pembayaran tab yang dialokasikan ke
+// pesanan ini, atau satu bagian tagihan yang dibayar dengan metode berbeda
+type TabPayment struct {
+	Amount Money         `json:"amount"`
+	Method PaymentMethod `json:"method"`
+	Time   time.Time     `json:"time"`
+}
+
+// Fungsi untuk menjumlahkan pembayaran sebagian yang sudah masuk ke pesanan
+func (order *Order) TabPaid() Money {
+	var paid Money
+	for _, payment := range order.TabPayments {
+		paid += payment.Amount
+	}
+	return paid
+}
+
+// Fungsi untuk menghitung sisa tagihan pesanan setelah pembayaran sebagian
+func (order *Order) TabBalance() Money {
+	return order.AmountDue() - order.TabPaid()
+}
+
+// Fungsi untuk mengambil pesanan tab yang masih terbuka, urut dari yang paling lama.
+// Pemanggil harus memegang ordersMutex.
+func tabOrders(name string) []*Order {
+	var result []*Order
+	for _, order := range orders {
+		if strings.EqualFold(order.Tab, name) && !order.Paid && !order.Voided && order.MergedInto == 0 {
+			result = append(result, order)
+		}
+	}
+	return result
+}
+
+// Fungsi untuk mengelola tab pelanggan bar: beberapa pesanan ditagih sekaligus,
+// bisa dibayar sebagian, lalu ditutup dengan satu tagihan gabungan
+func tabMenu(reader *bufio.Reader) {
+	fmt.Println("\n===== Tab Pelanggan =====")
+	fmt.Println("1. Lihat Tab Terbuka")
+	fmt.Println("2. Masukkan Pesanan ke Tab")
+	fmt.Println("3. Bayar Sebagian")
+	fmt.Println("4. Tutup Tab")
+	fmt.Print("Pilih opsi: ")
+
+	choice, _ := reader.ReadString('\n')
+	switch strings.TrimSpace(choice) {
+	case "1":
+		displayOpenTabs()
+	case "2":
+		addOrderToTab(reader)
+	case "3":
+		payTabPartially(reader)
+	case "4":
+		closeTab(reader)
+	default:
+		fmt.Println("Opsi tidak valid.")
+	}
+}
+
+// Fungsi untuk menampilkan semua tab yang masih terbuka beserta sisa tagihannya
+func displayOpenTabs() {
+	ordersMutex.Lock()
+	defer ordersMutex.Unlock()
+
+	type tabSummary struct {
+		name            string
+		orders          int
+		due, paid, left Money
+		opened          time.Time
+	}
+	tabs := map[string]*tabSummary{}
+	for _, order := range orders {
+		if order.Tab == "" || order.Paid || order.Voided || order.MergedInto != 0 {
+			continue
+		}
+		key := strings.ToLower(order.Tab)
+		summary := tabs[key]
+		if summary == nil {
+			summary = &tabSummary{name: order.Tab}
+			tabs[key] = summary
+		}
+		if summary.opened.IsZero() || order.CreatedAt.Before(summary.opened) {
+			summary.opened = order.CreatedAt
+		}
+		summary.orders++
+		summary.due += order.AmountDue()
+		summary.paid += order.TabPaid()
+		summary.left += order.TabBalance()
+	}
+
+	fmt.Println("\n===== Tab Terbuka =====")
+	if len(tabs) == 0 {
+		fmt.Println("Tidak ada tab terbuka.")
+		return
+	}
+	keys := make([]string, 0, len(tabs))
+	for key := range tabs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	now := time.Now()
+	for _, key := range keys {
+		summary := tabs[key]
+		fmt.Printf("%s | Pesanan: %d | Tagihan: %s | Dibayar: %s | Sisa: %s | Dibuka: %s\n",
+			summary.name, summary.orders, formatMoney(summary.due), formatMoney(summary.paid), formatMoney(summary.left), formatTimestamp(summary.opened, now))
+	}
+}
+
+// Fungsi untuk membaca nama pelanggan tab
+func readTabName(reader *bufio.Reader) (string, bool) {
+	fmt.Print("Nama pelanggan tab: ")
+	name, _ := reader.ReadString('\n')
+	name = strings.TrimSpace(name)
+	if name == "" {
+		fmt.Println("Nama pelanggan wajib diisi.")
+		return "", false
+	}
+	return name, true
+}
+
+// Fungsi untuk memasukkan pesanan yang belum dibayar ke tab pelanggan, tab baru
+// otomatis dibuka jika nama belum punya tab
+func addOrderToTab(reader *bufio.Reader) {
+	fmt.Print("Pesanan (ID atau \"meja <nomor>\"): ")
+	orderInput, _ := reader.ReadString('\n')
+	name, ok := readTabName(reader)
+	if !ok {
+		return
+	}
+
+	ordersMutex.Lock()
+	defer ordersMutex.Unlock()
+
+	order, err := findOrderRef(orderInput)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	order = followMergedOrder(order)
+	if order.Paid || order.Voided {
+		fmt.Println("Hanya pesanan yang belum dibayar yang bisa masuk tab.")
+		return
+	}
+	if order.Tab != "" && !strings.EqualFold(order.Tab, name) {
+		fmt.Printf("Pesanan ID %d sudah ada di tab %s.\n", order.ID, order.Tab)
+		return
+	}
+	// Nama ditulis sama dengan tab yang sudah terbuka
+	if existing := tabOrders(name); len(existing) > 0 {
+		name = existing[0].Tab
+	}
+	order.Tab = name
+
+	var balance Money
+	for _, tabOrder := range tabOrders(name) {
+		balance += tabOrder.TabBalance()
+	}
+	fmt.Printf("Pesanan ID %d masuk tab %s. Sisa tagihan tab: %s\n", order.ID, name, formatMoney(balance))
+}
+
+// Fungsi untuk mencatat pembayaran sebagian tab. Pembayaran dialokasikan ke
+// pesanan paling lama lebih dulu.
+func payTabPartially(reader *bufio.Reader) {
+	name, ok := readTabName(reader)
+	if !ok {
+		return
+	}
+
+	ordersMutex.Lock()
+	var balance Money
+	for _, order := range tabOrders(name) {
+		applyTableCharges(order)
+		balance += order.TabBalance()
+	}
+	ordersMutex.Unlock()
+	if balance <= 0 {
+		fmt.Println("Tab tidak ditemukan atau sudah lunas.")
+		return
+	}
+
+	fmt.Printf("Sisa tagihan tab %s: %s. Jumlah dibayar: ", name, formatMoney(balance))
+	input, _ := reader.ReadString('\n')
+	amount, err := parseMoneyInput(strings.TrimSpace(input))
+	if err != nil || amount <= 0 || amount > balance {
+		fmt.Println("Jumlah harus positif dan tidak melebihi sisa tagihan.")
+		return
+	}
+	method, ok := readPaymentMethod(reader)
+	if !ok {
+		return
+	}
+	var tendered Money
+	if method == PaymentCash {
+		if tendered, ok = readCashTendered(reader, amount); !ok {
+			return
+		}
+	}
+
+	ordersMutex.Lock()
+	now := time.Now()
+	remaining := amount
+	for _, order := range tabOrders(name) {
+		applyTableCharges(order)
+		part := min(remaining, order.TabBalance())
+		if part <= 0 {
+			continue
+		}
+		order.TabPayments = append(order.TabPayments, TabPayment{Amount: part, Method: method, Time: now})
+		remaining -= part
+	}
+	ordersMutex.Unlock()
+
+	logActivity(fmt.Sprintf("bayar sebagian tab %s %s%s", name, formatMoney(amount-remaining), describeTendered(tendered, amount-remaining)))
+	fmt.Printf("Pembayaran %s %s dicatat untuk tab %s. Sisa: %s\n", method.Label(), formatMoney(amount-remaining), name, formatMoney(balance-amount+remaining))
+	if tendered > 0 {
+		displayCashChange(tendered, amount-remaining)
+	}
+}
+
+// Fungsi untuk menutup tab: menampilkan tagihan gabungan semua pesanan, menerima
+// pelunasan sisa tagihan lalu menandai semua pesanan tab sudah dibayar
+func closeTab(reader *bufio.Reader) {
+	name, ok := readTabName(reader)
+	if !ok {
+		return
+	}
+
+	ordersMutex.Lock()
+	tab := tabOrders(name)
+	if len(tab) == 0 {
+		ordersMutex.Unlock()
+		fmt.Println("Tab tidak ditemukan.")
+		return
+	}
+	for _, order := range tab {
+		applyTableCharges(order)
+	}
+	bill, balance := formatTabBill(tab)
+	ordersMutex.Unlock()
+	fmt.Print(bill)
+
+	method := PaymentCash
+	var tendered Money
+	if balance > 0 {
+		if method, ok = readPaymentMethod(reader); !ok {
+			return
+		}
+		if method == PaymentCash {
+			if tendered, ok = readCashTendered(reader, balance); !ok {
+				return
+			}
+		}
+	}
+
+	ordersMutex.Lock()
+	now := time.Now()
+	tab = tabOrders(name)
+	for _, order := range tab {
+		applyTableCharges(order)
+		if left := order.TabBalance(); left > 0 {
+			order.TabPayments = append(order.TabPayments, TabPayment{Amount: left, Method: method, Time: now})
+		}
+		order.Paid = true
+		order.PaidAt = now
+		order.PaymentMethod = method
+		if len(order.TabPayments) > 0 {
+			order.PaymentMethod = combinedPaymentMethod(order.TabPayments)
+		}
+		publishOrderEvent(OrderEvent{Type: EventOrderPaid, OrderID: order.ID, PickupCode: order.PickupCode, Table: order.Table})
+		completeOrderIfDone(order)
+	}
+	ordersMutex.Unlock()
+
+	logActivity(fmt.Sprintf("tutup tab %s (%d pesanan)%s", name, len(tab), describeTendered(tendered, balance)))
+	fmt.Printf("Tab %s ditutup, %d pesanan lunas.\n", name, len(tab))
+	if tendered > 0 {
+		displayCashChange(tendered, balance)
+	}
+}
+
+// Fungsi untuk menyusun tagihan gabungan tab, mengembalikan teks tagihan dan sisa
+// yang harus dibayar. Pemanggil harus memegang ordersMutex.
+func formatTabBill(tab []*Order) (string, Money) {
+	var b strings.Builder
+	var due, paid Money
+	fmt.Fprintf(&b, "\n===== Tagihan Tab %s =====\n", tab[0].Tab)
+	for _, order := range tab {
+		fmt.Fprintf(&b, "Pesanan ID %d%s | %s\n", order.ID, describeTable(order.Table, order.Delivery), formatDateTime(order.CreatedAt))
+		for _, line := range order.Lines {
+			if line.Status == LineCancelled {
+				continue
+			}
+			fmt.Fprintf(&b, "  %s x%d @ %s = %s\n", line.ItemName, line.Quantity, formatMoney(line.Price), formatMoney(line.TotalPrice))
+		}
+		if order.Discount > 0 {
+			fmt.Fprintf(&b, "  Diskon: -%s\n", formatMoney(order.Discount))
+		}
+		if order.CoverCharge > 0 {
+			fmt.Fprintf(&b, "  Cover Charge: %s\n", formatMoney(order.CoverCharge))
+		}
+		if order.MinimumSpendTopUp > 0 {
+			fmt.Fprintf(&b, "  Top-up Minimum Belanja: %s\n", formatMoney(order.MinimumSpendTopUp))
+		}
+		due += order.AmountDue()
+		paid += order.TabPaid()
+	}
+	fmt.Fprintf(&b, "Total Tagihan: %s\n", formatMoney(due))
+	fmt.Fprintf(&b, "Sudah Dibayar: %s\n", formatMoney(paid))
+	fmt.Fprintf(&b, "Sisa: %s\n", formatMoney(due-paid))
+	return b.String(), due - paid
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Jumlah karakter per baris untuk setiap lebar kertas printer thermal (font standar)
+var paperWidths = map[string]int{
+	"80mm": 48,
+	"58mm": 32,
+}
+
+// Penyusun teks struk dan laporan singkat. Dengan lebar 0 teks ditulis apa adanya
+// seperti di layar, dengan lebar kertas thermal setiap baris dipotong, ditengahkan
+// dan nilai uang dirata kanan agar pas di kertas.
+type textLayout struct {
+	b     strings.Builder
+	width int
+}
+
+// Fungsi untuk membuat penyusun teks sesuai receipt_paper di konfigurasi
+func newReceiptLayout() *textLayout {
+	return &textLayout{width: paperWidths[currentConfig().ReceiptPaper]}
+}
+
+// Fungsi untuk mengambil teks yang sudah disusun
+func (l *textLayout) String() string {
+	return l.b.String()
+}
+
+// Fungsi untuk menulis judul di antara karakter pengisi, misalnya "===== Judul ====="
+func (l *textLayout) Banner(text string, fill string) {
+	if l.width == 0 {
+		fmt.Fprintf(&l.b, "%s %s %s\n", strings.Repeat(fill, 5), text, strings.Repeat(fill, 5))
+		return
+	}
+	if utf8.RuneCountInString(text)+4 > l.width {
+		l.Separator(fill)
+		for _, line := range wrapText(text, l.width) {
+			l.writeCentered(line)
+		}
+		l.Separator(fill)
+		return
+	}
+	padding := l.width - utf8.RuneCountInString(text) - 2
+	left := padding / 2
+	fmt.Fprintf(&l.b, "%s %s %s\n", strings.Repeat(fill, left), text, strings.Repeat(fill, padding-left))
+}
+
+// Fungsi untuk menulis judul bagian
+func (l *textLayout) Title(text string) {
+	l.Banner(text, "=")
+}
+
+// Fungsi untuk menulis garis pemisah selebar kertas, tidak ditulis tanpa lebar kertas
+func (l *textLayout) Separator(fill string) {
+	if l.width > 0 {
+		l.b.WriteString(strings.Repeat(fill, l.width) + "\n")
+	}
+}
+
+// Fungsi untuk menulis baris kosong
+func (l *textLayout) Blank() {
+	l.b.WriteString("\n")
+}
+
+// Fungsi untuk menulis teks bebas, dipotong per kata jika melebihi lebar kertas.
+// Indentasi di awal teks dipertahankan di baris lanjutan.
+func (l *textLayout) Line(format string, args ...any) {
+	text := fmt.Sprintf(format, args...)
+	if l.width == 0 {
+		l.b.WriteString(text + "\n")
+		return
+	}
+	indent := text[:len(text)-len(strings.TrimLeft(text, " "))]
+	for _, line := range wrapText(strings.TrimLeft(text, " "), l.width-len(indent)) {
+		l.b.WriteString(indent + line + "\n")
+	}
+}
+
+// Fungsi untuk menulis label dan nilai, misalnya "Total: Rp 10.000". Di kertas thermal
+// nilai dirata kanan, dan ditulis di baris sendiri jika tidak muat di samping label.
+func (l *textLayout) Pair(label, value string) {
+	if l.width == 0 {
+		fmt.Fprintf(&l.b, "%s: %s\n", label, value)
+		return
+	}
+	l.columns(label, value)
+}
+
+// Fungsi untuk menulis baris item, misalnya "Nasi Goreng x2 @ Rp 15.000 = Rp 30.000".
+// Di kertas thermal nama item ditulis di baris sendiri jika rinciannya tidak muat.
+func (l *textLayout) Item(name, detail, amount string) {
+	if l.width == 0 {
+		fmt.Fprintf(&l.b, "%s %s = %s\n", name, detail, amount)
+		return
+	}
+	if utf8.RuneCountInString(name+" "+detail+" "+amount) <= l.width {
+		l.columns(name+" "+detail, amount)
+		return
+	}
+	l.Line("%s", name)
+	l.columns("  "+detail, amount)
+}
+
+// Fungsi untuk menulis teks kiri dan teks kanan dalam satu baris selebar kertas
+func (l *textLayout) columns(left, right string) {
+	gap := l.width - utf8.RuneCountInString(left) - utf8.RuneCountInString(right)
+	if gap >= 1 {
+		l.b.WriteString(left + strings.Repeat(" ", gap) + right + "\n")
+		return
+	}
+	l.Line("%s", left)
+	l.b.WriteString(strings.Repeat(" ", max(l.width-utf8.RuneCountInString(right), 0)) + right + "\n")
+}
+
+// Fungsi untuk menulis kode QR dari karakter blok di tengah kertas. Jika kode tidak muat
+// di lebar kertas atau isinya terlalu panjang, hanya isinya yang ditulis sebagai teks.
+func (l *textLayout) QRCode(payload string) {
+	qr, err := encodeQR(payload)
+	if err == nil {
+		quiet := 2
+		if l.width > 0 && qr.size+quiet*2 > l.width {
+			quiet = 1
+		}
+		if l.width == 0 || qr.size+quiet*2 <= l.width {
+			for _, line := range qr.Lines(quiet) {
+				if l.width == 0 {
+					l.b.WriteString(line + "\n")
+					continue
+				}
+				l.writeCentered(line)
+			}
+		}
+	}
+	l.Line("%s", payload)
+}
+
+// Fungsi untuk menulis teks di tengah baris
+func (l *textLayout) writeCentered(text string) {
+	padding := max(l.width-utf8.RuneCountInString(text), 0) / 2
+	l.b.WriteString(strings.Repeat(" ", padding) + text + "\n")
+}
+
+// Fungsi untuk memotong teks per kata menjadi baris selebar width. Kata yang lebih
+// panjang dari satu baris dipotong paksa.
+func wrapText(text string, width int) []string {
+	width = max(width, 1)
+	var lines []string
+	current := ""
+	for _, word := range strings.Fields(text) {
+		for utf8.RuneCountInString(word) > width {
+			if current != "" {
+				lines = append(lines, current)
+				current = ""
+			}
+			runes := []rune(word)
+			lines = append(lines, string(runes[:width]))
+			word = string(runes[width:])
+		}
+		switch {
+		case current == "":
+			current = word
+		case utf8.RuneCountInString(current)+1+utf8.RuneCountInString(word) <= width:
+			current += " " + word
+		default:
+			lines = append(lines, current)
+			current = word
+		}
+	}
+	if current != "" || len(lines) == 0 {
+		lines = append(lines, current)
+	}
+	return lines
+}
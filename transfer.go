@@ -0,0 +1,382 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Nama file transfer di folder data cabang tujuan. Setiap transfer hanya disimpan di
+// cabang tujuan, cabang asal membaca file cabang lain untuk melihat transfer keluarnya.
+const transfersFile = "transfers.json"
+
+// Status transfer stok antar cabang
+type TransferStatus string
+
+const (
+	TransferInTransit TransferStatus = "in_transit"
+	TransferReceived  TransferStatus = "received"
+)
+
+// Fungsi untuk menampilkan status transfer di layar
+func (s TransferStatus) Label() string {
+	switch s {
+	case TransferInTransit:
+		return "dalam perjalanan"
+	case TransferReceived:
+		return "diterima"
+	}
+	return string(s)
+}
+
+// Struct untuk satu baris transfer stok, harga pokok ikut dibawa agar cabang tujuan
+// bisa menghitung harga pokok rata-rata
+type TransferLine struct {
+	ItemName string `json:"item"`
+	Quantity int    `json:"quantity"`
+	UnitCost Money  `json:"unit_cost"`
+}
+
+// Struct untuk transfer stok dari satu cabang ke cabang lain
+type StockTransfer struct {
+	ID         int            `json:"id"`
+	From       string         `json:"from"`
+	To         string         `json:"to"`
+	Lines      []TransferLine `json:"lines"`
+	Status     TransferStatus `json:"status"`
+	CreatedAt  time.Time      `json:"created_at"`
+	CreatedBy  string         `json:"created_by"`
+	ReceivedAt time.Time      `json:"received_at,omitzero"`
+	ReceivedBy string         `json:"received_by,omitempty"`
+}
+
+// Fungsi untuk membuat referensi mutasi dari transfer, misalnya "transfer 3 Pusat->Selatan"
+func (t *StockTransfer) Reference() string {
+	return fmt.Sprintf("transfer %d %s->%s", t.ID, t.From, t.To)
+}
+
+// Mengurutkan baca-ubah-tulis file transfer di proses ini, file kunci menjaga antar proses
+var transferMutex sync.Mutex
+
+// Fungsi untuk membaca semua transfer yang disimpan di folder data cabang
+func loadTransfers(dir string) ([]StockTransfer, error) {
+	var transfers []StockTransfer
+	_, err := readJSONFile(filepath.Join(dir, transfersFile), &transfers)
+	return transfers, err
+}
+
+// Fungsi untuk mengubah file transfer sebuah cabang sambil memegang file kunci, supaya
+// cabang asal dan cabang tujuan tidak saling menimpa. Kunci yang tertinggal lebih dari
+// 30 detik dianggap sisa proses yang mati.
+func updateTransfers(dir string, update func([]StockTransfer) ([]StockTransfer, error)) error {
+	transferMutex.Lock()
+	defer transferMutex.Unlock()
+
+	lockPath := filepath.Join(dir, transfersFile+".lock")
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		lock, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			lock.Close()
+			break
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return err
+		}
+		if info, err := os.Stat(lockPath); err == nil && time.Since(info.ModTime()) > 30*time.Second {
+			os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("file transfer di %s sedang dipakai", dir)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	defer os.Remove(lockPath)
+
+	transfers, err := loadTransfers(dir)
+	if err != nil {
+		return err
+	}
+	if transfers, err = update(transfers); err != nil {
+		return err
+	}
+	return writeJSONFile(filepath.Join(dir, transfersFile), transfers)
+}
+
+// Fungsi untuk menampilkan submenu transfer stok antar cabang
+func transferMenu(reader *bufio.Reader) {
+	cfg := currentConfig()
+	if cfg.Branch == "" || len(cfg.Branches) == 0 {
+		fmt.Println("Cabang belum diatur, isi branch dan branches di config.json.")
+		return
+	}
+
+	fmt.Printf("\n===== Transfer Stok Cabang %s =====\n", cfg.Branch)
+	fmt.Println("1. Kirim Stok ke Cabang Lain")
+	fmt.Println("2. Daftar Transfer")
+	fmt.Println("3. Terima Transfer")
+	fmt.Print("Pilih opsi: ")
+
+	input, _ := reader.ReadString('\n')
+	switch strings.TrimSpace(input) {
+	case "1":
+		createTransfer(reader, cfg)
+	case "2":
+		displayTransfers(cfg)
+	case "3":
+		receiveTransfer(reader, cfg)
+	default:
+		fmt.Println("Opsi tidak valid.")
+	}
+}
+
+// Fungsi untuk membaca satu baris transfer. Stok diperiksa di sini agar kasir langsung
+// tahu, lalu diperiksa lagi saat stok benar-benar dikurangi.
+func readTransferLine(reader *bufio.Reader) (TransferLine, bool) {
+	fmt.Print("Masukkan nama item: ")
+	name, _ := reader.ReadString('\n')
+	name = strings.TrimSpace(name)
+
+	menuMutex.Lock()
+	item := findMenuItem(name)
+	var stock int
+	var cost Money
+	hasVariants := false
+	if item != nil {
+		name, stock, cost, hasVariants = item.Name, item.Quantity, item.Cost, len(item.Variants) > 0
+	}
+	menuMutex.Unlock()
+	if item == nil {
+		fmt.Println("Item tidak ditemukan.")
+		return TransferLine{}, false
+	}
+	if hasVariants {
+		fmt.Println("Item punya varian, tulis nama variannya.")
+		return TransferLine{}, false
+	}
+
+	fmt.Printf("Jumlah yang dikirim (stok %s): ", formatQuantity(stock))
+	quantityInput, _ := reader.ReadString('\n')
+	quantity, err := parseQuantityInput(quantityInput)
+	if err != nil || quantity <= 0 {
+		fmt.Println("Jumlah harus berupa angka positif.")
+		return TransferLine{}, false
+	}
+	if quantity > stock {
+		fmt.Printf("Stok %s tidak cukup (tersisa %d).\n", name, stock)
+		return TransferLine{}, false
+	}
+	return TransferLine{ItemName: name, Quantity: quantity, UnitCost: cost}, true
+}
+
+// Fungsi untuk mengirim stok ke cabang lain. Stok cabang ini langsung berkurang,
+// sedangkan stok cabang tujuan baru bertambah setelah transfer diterima di sana.
+func createTransfer(reader *bufio.Reader, cfg Config) {
+	names := make([]string, 0, len(cfg.Branches))
+	for name := range cfg.Branches {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	fmt.Printf("Cabang tujuan (%s): ", strings.Join(names, ", "))
+	destination, _ := reader.ReadString('\n')
+	destination = strings.TrimSpace(destination)
+	dir, ok := cfg.Branches[destination]
+	if !ok || destination == cfg.Branch {
+		fmt.Println("Cabang tujuan tidak dikenal.")
+		return
+	}
+
+	transfer := StockTransfer{From: cfg.Branch, To: destination, Status: TransferInTransit}
+	for {
+		if line, ok := readTransferLine(reader); ok {
+			transfer.Lines = append(transfer.Lines, line)
+		}
+
+		fmt.Print("Tambah item lain? (y/n): ")
+		more, _ := reader.ReadString('\n')
+		if !strings.EqualFold(strings.TrimSpace(more), "y") {
+			break
+		}
+	}
+	if len(transfer.Lines) == 0 {
+		fmt.Println("Transfer tidak memiliki item.")
+		return
+	}
+
+	fmt.Printf("Kirim %d item ke cabang %s? (y/n): ", len(transfer.Lines), destination)
+	confirm, _ := reader.ReadString('\n')
+	if !strings.EqualFold(strings.TrimSpace(confirm), "y") {
+		fmt.Println("Transfer dibatalkan.")
+		return
+	}
+
+	activityMutex.Lock()
+	transfer.CreatedBy = currentCashier
+	activityMutex.Unlock()
+
+	menuMutex.Lock()
+	defer menuMutex.Unlock()
+
+	// Stok dikurangi lebih dulu, lalu dikembalikan jika transfer gagal dicatat di cabang tujuan
+	var removed []*MenuItem
+	restore := func() {
+		for i, item := range removed {
+			item.addStock(transfer.Lines[i].Quantity, time.Time{})
+		}
+	}
+	for _, line := range transfer.Lines {
+		item := findMenuItem(line.ItemName)
+		if item == nil {
+			fmt.Printf("%s sudah tidak ada di menu, transfer dibatalkan.\n", line.ItemName)
+			restore()
+			return
+		}
+		if err := item.removeStock(line.Quantity); err != nil {
+			fmt.Printf("Gagal mengurangi stok %s: %v. Transfer dibatalkan.\n", item.Name, err)
+			restore()
+			return
+		}
+		removed = append(removed, item)
+	}
+
+	transfer.CreatedAt = time.Now()
+	err := updateTransfers(dir, func(transfers []StockTransfer) ([]StockTransfer, error) {
+		transfer.ID = len(transfers) + 1
+		return append(transfers, transfer), nil
+	})
+	if err != nil {
+		fmt.Printf("Gagal mencatat transfer di cabang %s: %v. Stok dikembalikan.\n", destination, err)
+		restore()
+		return
+	}
+
+	for _, line := range transfer.Lines {
+		recordCostedMovement(line.ItemName, -line.Quantity, MovementTransferOut, transfer.Reference(), line.UnitCost)
+	}
+	logActivity(fmt.Sprintf("%s: %d item dikirim", transfer.Reference(), len(transfer.Lines)))
+	fmt.Printf("Transfer ID %d ke cabang %s dibuat, menunggu diterima.\n", transfer.ID, destination)
+}
+
+// Fungsi untuk menampilkan transfer masuk ke cabang ini dan transfer keluar yang
+// tercatat di cabang lain
+func displayTransfers(cfg Config) {
+	var shown int
+	show := func(transfer StockTransfer) {
+		shown++
+		fmt.Printf("Transfer ID %d | %s -> %s | Status: %s | Dikirim: %s oleh %s", transfer.ID, transfer.From, transfer.To, transfer.Status.Label(), formatDateTime(transfer.CreatedAt), transfer.CreatedBy)
+		if transfer.Status == TransferReceived {
+			fmt.Printf(" | Diterima: %s oleh %s", formatDateTime(transfer.ReceivedAt), transfer.ReceivedBy)
+		}
+		fmt.Println()
+		for _, line := range transfer.Lines {
+			fmt.Printf("  %s x%d\n", line.ItemName, line.Quantity)
+		}
+	}
+
+	incoming, err := loadTransfers(cfg.DataDir)
+	if err != nil {
+		fmt.Println("Gagal membaca transfer masuk:", err)
+	}
+	fmt.Println("\n===== Transfer Masuk =====")
+	for _, transfer := range incoming {
+		if transfer.To == cfg.Branch {
+			show(transfer)
+		}
+	}
+
+	names := make([]string, 0, len(cfg.Branches))
+	for name := range cfg.Branches {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	fmt.Println("\n===== Transfer Keluar =====")
+	for _, name := range names {
+		outgoing, err := loadTransfers(cfg.Branches[name])
+		if err != nil {
+			fmt.Printf("Gagal membaca transfer cabang %s: %v\n", name, err)
+			continue
+		}
+		for _, transfer := range outgoing {
+			if transfer.From == cfg.Branch {
+				show(transfer)
+			}
+		}
+	}
+
+	if shown == 0 {
+		fmt.Println("Belum ada transfer.")
+	}
+}
+
+// Fungsi untuk menerima transfer yang masih dalam perjalanan, menambah stok cabang ini
+// dan memperbarui harga pokok seperti penerimaan purchase order
+func receiveTransfer(reader *bufio.Reader, cfg Config) {
+	fmt.Print("Masukkan ID transfer: ")
+	idInput, _ := reader.ReadString('\n')
+	id, err := strconv.Atoi(strings.TrimSpace(idInput))
+	if err != nil {
+		fmt.Println("ID transfer harus berupa angka.")
+		return
+	}
+
+	activityMutex.Lock()
+	cashier := currentCashier
+	activityMutex.Unlock()
+
+	menuMutex.Lock()
+	defer menuMutex.Unlock()
+
+	// Status diubah di file lebih dulu agar transfer yang sama tidak diterima dua kali
+	var received StockTransfer
+	err = updateTransfers(cfg.DataDir, func(transfers []StockTransfer) ([]StockTransfer, error) {
+		index := slices.IndexFunc(transfers, func(t StockTransfer) bool { return t.ID == id && t.To == cfg.Branch })
+		if index < 0 {
+			return nil, errors.New("transfer tidak ditemukan")
+		}
+		transfer := &transfers[index]
+		if transfer.Status == TransferReceived {
+			return nil, errors.New("transfer sudah diterima")
+		}
+		for _, line := range transfer.Lines {
+			if findMenuItem(line.ItemName) == nil {
+				return nil, fmt.Errorf("%s tidak ada di menu cabang ini", line.ItemName)
+			}
+		}
+		transfer.Status = TransferReceived
+		transfer.ReceivedAt = time.Now()
+		transfer.ReceivedBy = cashier
+		received = *transfer
+		return transfers, nil
+	})
+	if err != nil {
+		fmt.Println("Gagal menerima transfer:", err)
+		return
+	}
+
+	for _, line := range received.Lines {
+		item := findMenuItem(line.ItemName)
+		cost := line.UnitCost
+		if line.UnitCost == 0 {
+			cost = item.Cost
+		} else if item.Cost != 0 && item.Quantity > 0 {
+			cost = (item.Cost.Times(item.Quantity) + line.UnitCost.Times(line.Quantity)).Div(item.Quantity + line.Quantity)
+		}
+		if err := item.addStock(line.Quantity, time.Time{}); err != nil {
+			fmt.Printf("Gagal menambah stok %s: %v\n", item.Name, err)
+			continue
+		}
+		item.Cost = cost
+		recordCostedMovement(item.Name, line.Quantity, MovementTransferIn, received.Reference(), line.UnitCost)
+		fmt.Printf("Stok %s bertambah %d menjadi %s.\n", item.Name, line.Quantity, formatQuantity(item.Quantity))
+	}
+	logActivity(fmt.Sprintf("%s diterima", received.Reference()))
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Fungsi untuk menjalankan mode tutorial bagi kasir baru. Semua data disimpan di
+// memori dan folder sementara sehingga data restoran yang asli tidak tersentuh.
+func runTutorial(reader *bufio.Reader) {
+	sandboxDir, err := os.MkdirTemp("", "restoran-tutorial-")
+	if err != nil {
+		fmt.Println("Gagal menyiapkan folder tutorial:", err)
+		return
+	}
+	defer os.RemoveAll(sandboxDir)
+
+	configMutex.Lock()
+	config.Storage = StorageMemory
+	config.DataDir = sandboxDir
+	config.DatabaseURL = ""
+	config.SMTP = SMTPConfig{}
+	config.AdminPIN = ""
+	configMutex.Unlock()
+
+	if err := openStorage(currentConfig()); err != nil {
+		fmt.Println("Gagal membuka penyimpanan tutorial:", err)
+		return
+	}
+	menu, _ = loadDefaultMenu("")
+
+	go processOrders()
+	stopScheduler := startScheduler()
+	defer shutdown(stopScheduler)
+
+	fmt.Println("\n===== Mode Tutorial =====")
+	fmt.Println("Selamat datang! Tutorial ini memakai data contoh, tidak ada data asli yang berubah.")
+
+	tutorialStep(reader, 1, "Lihat menu",
+		"Ini daftar menu beserta harga dan stoknya. Kasir memakai nomor atau nama item ini saat membuat pesanan.")
+	displayMenu()
+
+	for {
+		tutorialStep(reader, 2, "Buat pesanan contoh",
+			"Isi nomor meja (misal 5), ketik 1x1 untuk satu Nasi Goreng, jawab n untuk menahan item,\n"+
+				"n untuk menambah item, lalu y untuk menyimpan pesanan.")
+		orderID, _ := newOrderID()
+		order := createOrder(reader, orderID)
+		if order != nil {
+			recordOrder(order)
+			dispatchOrder(order, false)
+			break
+		}
+		if !tutorialRetry(reader) {
+			break
+		}
+	}
+
+	tutorialStep(reader, 3, "Restock",
+		"Pilih 1 untuk restock, ketik Nasi Goreng, jumlah 5, keterangan bebas, dan kosongkan tanggal kedaluwarsa.")
+	adjustStock(reader)
+	displayMenu()
+
+	tutorialStep(reader, 4, "Lihat laporan",
+		"Setelah dapur selesai memproses, total penjualan dan antrian dapur bisa dilihat di laporan.")
+	fmt.Println("Menunggu dapur memproses pesanan...")
+	wg.Wait()
+	// Pendapatan baru masuk total setelah dapur mengonfirmasi pesanan
+	for _, id := range pendingAckOrders() {
+		acknowledgeOrderByID(id)
+	}
+	displayTotalAllOrders()
+	displayKitchenQueue()
+
+	fmt.Println("\nTutorial selesai. Jalankan program tanpa -tutorial untuk mulai bekerja.")
+}
+
+// Fungsi untuk menampilkan judul dan petunjuk satu langkah tutorial, lalu menunggu Enter
+func tutorialStep(reader *bufio.Reader, number int, title, hint string) {
+	fmt.Printf("\n--- Langkah %d: %s ---\n%s\n", number, title, hint)
+	fmt.Print("Tekan Enter untuk lanjut...")
+	reader.ReadString('\n')
+}
+
+// Fungsi untuk menanyakan apakah langkah yang gagal ingin diulang
+func tutorialRetry(reader *bufio.Reader) bool {
+	fmt.Print("Langkah belum berhasil. Coba lagi? (y/n): ")
+	input, _ := reader.ReadString('\n')
+	return strings.EqualFold(strings.TrimSpace(input), "y")
+}
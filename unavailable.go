@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Fungsi untuk mengambil item yang membuat item ini tidak tersedia. Varian ikut tidak
+// tersedia jika item induknya di-86. Mengembalikan nil jika item tersedia.
+// Pemanggil harus memegang menuMutex.
+func unavailableSource(item *MenuItem) *MenuItem {
+	if item.Unavailable {
+		return item
+	}
+	if parent := variantParent(item); parent != nil && parent.Unavailable {
+		return parent
+	}
+	return nil
+}
+
+// Fungsi untuk memeriksa apakah item bisa dipesan saat ini: tidak di-86 dan sedang
+// dalam musimnya. Pemanggil harus memegang menuMutex.
+func checkAvailable(item *MenuItem, now time.Time) error {
+	if source := unavailableSource(item); source != nil {
+		reason := ""
+		if source.UnavailableReason != "" {
+			reason = ": " + source.UnavailableReason
+		}
+		return fmt.Errorf("%s sedang tidak tersedia (86%s).", item.Name, reason)
+	}
+	return checkSeason(item, now)
+}
+
+// Fungsi untuk menampilkan tanda 86 di daftar menu, kosong jika item tersedia
+func describeUnavailable(item *MenuItem) string {
+	if !item.Unavailable {
+		return ""
+	}
+	if item.UnavailableReason == "" {
+		return " [86]"
+	}
+	return " [86: " + item.UnavailableReason + "]"
+}
+
+// Fungsi untuk menandai item tidak tersedia (86) atau membatalkannya tanpa mengubah stok.
+// Nama item boleh ditulis setelah perintah, misalnya "86 nasi goreng".
+func toggleUnavailable(reader *bufio.Reader, args string) {
+	name := strings.TrimSpace(args)
+	if name == "" {
+		menuMutex.Lock()
+		var marked []string
+		var walk func(items []MenuItem)
+		walk = func(items []MenuItem) {
+			for i := range items {
+				if items[i].Unavailable {
+					marked = append(marked, items[i].Name+describeUnavailable(&items[i]))
+				}
+				walk(items[i].Variants)
+			}
+		}
+		walk(menu)
+		menuMutex.Unlock()
+
+		if len(marked) > 0 {
+			fmt.Println("Item yang sedang di-86:", strings.Join(marked, ", "))
+		}
+		fmt.Print("Masukkan nama item: ")
+		input, _ := reader.ReadString('\n')
+		name = strings.TrimSpace(input)
+	}
+
+	if name = findMenuItemOrSuggest(reader, name); name == "" {
+		return
+	}
+
+	menuMutex.Lock()
+	item := findMenuItem(name)
+	var edited MenuItem
+	if item != nil {
+		edited = copyMenuItem(*item)
+	}
+	menuMutex.Unlock()
+	if item == nil {
+		return
+	}
+
+	if edited.Unavailable {
+		edited.Unavailable = false
+		edited.UnavailableReason = ""
+	} else {
+		fmt.Print("Alasan (opsional, misal wajan rusak): ")
+		reason, _ := reader.ReadString('\n')
+		edited.Unavailable = true
+		edited.UnavailableReason = strings.TrimSpace(reason)
+	}
+
+	updated, err := activeMenuRepo().UpdateMenuItem(edited)
+	var conflict *VersionConflictError
+	if errors.As(err, &conflict) {
+		fmt.Printf("%s sudah diubah pihak lain, coba lagi.\n", edited.Name)
+		return
+	}
+	if err != nil {
+		fmt.Printf("Gagal menyimpan %s: %v\n", edited.Name, err)
+		return
+	}
+	applyMenuItemEdit(updated)
+
+	if updated.Unavailable {
+		logActivity(fmt.Sprintf("86 item %s%s", updated.Name, describeUnavailable(&updated)))
+		fmt.Printf("%s ditandai tidak tersedia, stok tetap %s.\n", updated.Name, formatQuantity(updated.Quantity))
+		return
+	}
+	logActivity("batal 86 item " + updated.Name)
+	fmt.Printf("%s tersedia lagi.\n", updated.Name)
+}
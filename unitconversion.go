@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Struct untuk satuan stok satu item di konfigurasi. Stok, buku stok dan harga pokok
+// selalu dihitung dalam satuan pakai (unit), satuan beli hanya dipakai saat mengetik
+// jumlah dan harga beli lalu langsung dikonversi.
+type StockUnitConfig struct {
+	// Satuan pakai, misalnya gram, ml atau botol
+	Unit string `json:"unit"`
+	// Satuan beli dari pemasok, misalnya kg, liter atau dus
+	PurchaseUnit string `json:"purchase_unit"`
+	// Jumlah satuan pakai dalam satu satuan beli, misalnya 24 untuk dus isi 24.
+	// Boleh dikosongkan untuk pasangan baku seperti kg ke gram atau liter ke ml.
+	PerPurchaseUnit int `json:"per_purchase_unit"`
+}
+
+// Konversi baku dari satuan beli ke satuan pakai
+var standardUnitFactors = map[string]map[string]int{
+	"kg":    {"gram": 1000, "g": 1000},
+	"liter": {"ml": 1000},
+	"l":     {"ml": 1000},
+	"lusin": {"pcs": 12, "buah": 12},
+}
+
+// Fungsi untuk mengambil jumlah satuan pakai dalam satu satuan beli, 0 jika tidak diketahui
+func (units StockUnitConfig) factor() int {
+	if units.PerPurchaseUnit > 0 {
+		return units.PerPurchaseUnit
+	}
+	return standardUnitFactors[strings.ToLower(units.PurchaseUnit)][strings.ToLower(units.Unit)]
+}
+
+// Fungsi untuk memeriksa satuan stok di konfigurasi
+func validateStockUnits(stockUnits map[string]StockUnitConfig) error {
+	for name, units := range stockUnits {
+		if units.Unit == "" {
+			return fmt.Errorf("stock_units %s: unit wajib diisi", name)
+		}
+		if units.PerPurchaseUnit < 0 {
+			return fmt.Errorf("stock_units %s: per_purchase_unit tidak boleh negatif", name)
+		}
+		if units.PurchaseUnit == "" {
+			continue
+		}
+		if strings.EqualFold(units.PurchaseUnit, units.Unit) {
+			return fmt.Errorf("stock_units %s: purchase_unit harus berbeda dari unit", name)
+		}
+		if units.factor() == 0 {
+			return fmt.Errorf("stock_units %s: per_purchase_unit wajib diisi untuk %s ke %s", name, units.PurchaseUnit, units.Unit)
+		}
+	}
+	return nil
+}
+
+// Fungsi untuk mencari satuan stok item tanpa membedakan huruf besar kecil
+func stockUnitsFor(name string) (StockUnitConfig, bool) {
+	for key, units := range currentConfig().StockUnits {
+		if strings.EqualFold(key, name) {
+			return units, true
+		}
+	}
+	return StockUnitConfig{}, false
+}
+
+// Fungsi untuk menampilkan jumlah stok beserta satuannya, misalnya "48 botol (2 dus)".
+// Item tanpa satuan di konfigurasi ditampilkan sebagai angka saja.
+func describeStockQuantity(name string, quantity int) string {
+	units, ok := stockUnitsFor(name)
+	if !ok {
+		return formatQuantity(quantity)
+	}
+	text := formatQuantity(quantity) + " " + units.Unit
+	if factor := units.factor(); units.PurchaseUnit != "" && factor > 0 && quantity != 0 {
+		text += fmt.Sprintf(" (%s %s)", formatNumber(float64(quantity)/float64(factor), decimalsFor(quantity, factor)), units.PurchaseUnit)
+	}
+	return text
+}
+
+// Fungsi untuk menentukan jumlah desimal satuan beli, 0 jika jumlahnya pas
+func decimalsFor(quantity, factor int) int {
+	if quantity%factor == 0 {
+		return 0
+	}
+	return 2
+}
+
+// Fungsi untuk menjelaskan satuan yang bisa diketik untuk item, dipakai di prompt
+// jumlah, misalnya " (dus isi 24 botol, atau tulis 5 botol)"
+func describeUnitInput(name string, purchase bool) string {
+	units, ok := stockUnitsFor(name)
+	if !ok {
+		return ""
+	}
+	if units.PurchaseUnit == "" || units.factor() == 0 {
+		return " (" + units.Unit + ")"
+	}
+	if purchase {
+		return fmt.Sprintf(" (%s isi %d %s, atau tulis misal 5 %s)", units.PurchaseUnit, units.factor(), units.Unit, units.Unit)
+	}
+	return fmt.Sprintf(" (%s, atau tulis misal 2 %s)", units.Unit, units.PurchaseUnit)
+}
+
+// Fungsi untuk membaca jumlah yang boleh diberi satuan, misalnya "2 dus", "1,5 kg" atau
+// "48". Hasilnya selalu dalam satuan pakai. Angka tanpa satuan dianggap satuan beli jika
+// purchase true (purchase order), selain itu satuan pakai.
+func parseUnitQuantity(name, input string, purchase bool) (int, error) {
+	text := strings.TrimSpace(input)
+	split := strings.LastIndexAny(text, "0123456789.,") + 1
+	number, unit := text[:split], strings.ToLower(strings.TrimSpace(text[split:]))
+
+	units, ok := stockUnitsFor(name)
+	if !ok {
+		if unit != "" {
+			return 0, fmt.Errorf("%s belum punya satuan di stock_units", name)
+		}
+		return parseQuantityInput(number)
+	}
+
+	factor := units.factor()
+	hasPurchaseUnit := units.PurchaseUnit != "" && factor > 0
+	switch {
+	case unit == "" && purchase && hasPurchaseUnit:
+		// angka tanpa satuan di purchase order memakai satuan beli
+	case unit == "" || unit == strings.ToLower(units.Unit):
+		factor = 1
+	case hasPurchaseUnit && unit == strings.ToLower(units.PurchaseUnit):
+	default:
+		return 0, fmt.Errorf("satuan %q tidak dikenal untuk %s", unit, name)
+	}
+
+	normalized, err := normalizeNumberInput(number)
+	if err != nil {
+		return 0, err
+	}
+	value, err := strconv.ParseFloat(normalized, 64)
+	if err != nil {
+		return 0, err
+	}
+	quantity := value * float64(factor)
+	if math.Abs(quantity-math.Round(quantity)) > 1e-9 {
+		return 0, fmt.Errorf("%s %s harus menjadi jumlah %s yang bulat", strings.TrimSpace(number), unit, units.Unit)
+	}
+	return int(math.Round(quantity)), nil
+}
+
+// Fungsi untuk mengubah harga beli per satuan beli menjadi harga per satuan pakai,
+// dipakai untuk harga pokok dan buku stok
+func unitCostFromPurchase(name string, cost Money) Money {
+	units, ok := stockUnitsFor(name)
+	if !ok || units.PurchaseUnit == "" || units.factor() == 0 {
+		return cost
+	}
+	return cost.Div(units.factor())
+}
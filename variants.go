@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Fungsi untuk membuat nama lengkap varian, misalnya "Es Teh (Besar)"
+func variantName(parent, label string) string {
+	return fmt.Sprintf("%s (%s)", parent, label)
+}
+
+// Fungsi untuk menyamakan penulisan nama agar "es teh besar" cocok dengan "Es Teh (Besar)"
+func normalizeMenuName(name string) string {
+	name = strings.NewReplacer("(", " ", ")", " ").Replace(strings.ToLower(name))
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// Fungsi untuk mencari item atau varian berdasarkan nama di daftar menu tertentu
+func findMenuItemIn(items []MenuItem, name string) *MenuItem {
+	target := normalizeMenuName(name)
+	for i := range items {
+		if normalizeMenuName(items[i].Name) == target {
+			return &items[i]
+		}
+		if variant := findMenuItemIn(items[i].Variants, name); variant != nil {
+			return variant
+		}
+	}
+	return nil
+}
+
+// Fungsi untuk mengambil semua item yang punya stok sendiri, yaitu varian dan item
+// tanpa varian. Pemanggil harus memegang menuMutex.
+func stockItems() []*MenuItem {
+	var result []*MenuItem
+	for i := range menu {
+		if len(menu[i].Variants) == 0 {
+			result = append(result, &menu[i])
+			continue
+		}
+		for j := range menu[i].Variants {
+			result = append(result, &menu[i].Variants[j])
+		}
+	}
+	return result
+}
+
+// Fungsi untuk meminta kasir memilih varian jika item punya varian. Mengembalikan nama
+// item atau varian yang dipilih, kosong jika batal. Pemanggil tidak boleh memegang
+// menuMutex karena pilihan dibaca dari kasir.
+func selectVariant(reader *bufio.Reader, name string) string {
+	menuMutex.Lock()
+	var variants []MenuItem
+	if item := findMenuItem(name); item != nil {
+		variants = append(variants, item.Variants...)
+	}
+	menuMutex.Unlock()
+	if len(variants) == 0 {
+		return name
+	}
+
+	fmt.Printf("Pilih varian %s:\n", name)
+	for i, variant := range variants {
+		fmt.Printf("%d. %s | Harga: %s | Stok: %d\n", i+1, variant.Name, formatMoney(variant.Price), variant.Quantity)
+	}
+	fmt.Print("Nomor varian: ")
+	input, _ := reader.ReadString('\n')
+	index, err := strconv.Atoi(strings.TrimSpace(input))
+	if err != nil || index < 1 || index > len(variants) {
+		fmt.Println("Varian tidak valid.")
+		return ""
+	}
+	return variants[index-1].Name
+}
+
+// Fungsi untuk menampilkan daftar varian yang bisa dipilih pada pesan kesalahan
+func describeVariants(item *MenuItem) string {
+	names := make([]string, len(item.Variants))
+	for i, variant := range item.Variants {
+		names[i] = variant.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// Fungsi untuk menambah atau menghapus varian (misalnya kecil/besar) pada item menu
+func manageVariants(reader *bufio.Reader) {
+	fmt.Print("Masukkan nama item induk: ")
+	name, _ := reader.ReadString('\n')
+	name = strings.TrimSpace(name)
+
+	menuMutex.Lock()
+	parent := findMenuItem(name)
+	if parent == nil || findMenuParent(parent.Name) == nil {
+		menuMutex.Unlock()
+		fmt.Println("Item induk tidak ditemukan.")
+		return
+	}
+	fmt.Printf("Varian %s: ", parent.Name)
+	if len(parent.Variants) == 0 {
+		fmt.Println("belum ada")
+	} else {
+		fmt.Println(describeVariants(parent))
+	}
+	parentName := parent.Name
+	menuMutex.Unlock()
+
+	fmt.Print("Aksi (tambah/hapus): ")
+	action, _ := reader.ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(action)) {
+	case "tambah":
+		addVariant(reader, parentName)
+	case "hapus":
+		removeVariant(reader, parentName)
+	default:
+		fmt.Println("Aksi tidak dikenal.")
+	}
+}
+
+// Fungsi untuk mencari item induk (bukan varian) berdasarkan nama persis,
+// pemanggil harus memegang menuMutex
+func findMenuParent(name string) *MenuItem {
+	for i := range menu {
+		if menu[i].Name == name {
+			return &menu[i]
+		}
+	}
+	return nil
+}
+
+// Fungsi untuk menambah varian baru dengan harga dan stok sendiri
+func addVariant(reader *bufio.Reader, parentName string) {
+	fmt.Print("Nama varian (misal Kecil, Besar): ")
+	label, _ := reader.ReadString('\n')
+	label = strings.TrimSpace(label)
+	if label == "" {
+		fmt.Println("Nama varian wajib diisi.")
+		return
+	}
+
+	fmt.Print("Harga varian: ")
+	priceInput, _ := reader.ReadString('\n')
+	price, err := parseMoneyInput(strings.TrimSpace(priceInput))
+	if err != nil || price <= 0 {
+		fmt.Println("Harga harus berupa angka positif.")
+		return
+	}
+
+	fmt.Print("Stok awal varian: ")
+	quantityInput, _ := reader.ReadString('\n')
+	quantity, err := parseQuantityInput(quantityInput)
+	if err != nil || quantity < 0 {
+		fmt.Println("Stok harus berupa angka positif.")
+		return
+	}
+
+	menuMutex.Lock()
+	defer menuMutex.Unlock()
+
+	parent := findMenuParent(parentName)
+	if parent == nil {
+		fmt.Println("Item induk tidak ditemukan.")
+		return
+	}
+	fullName := variantName(parent.Name, label)
+	if findMenuItem(fullName) != nil {
+		fmt.Printf("%s sudah ada di menu.\n", fullName)
+		return
+	}
+
+	parent.Variants = append(parent.Variants, MenuItem{
+		Name:     fullName,
+		Price:    price,
+		Quantity: quantity,
+		Station:  parent.Station,
+		Cost:     parent.Cost,
+	})
+	parent.Version++
+	invalidateMenuCache()
+	if quantity > 0 {
+		recordMovement(fullName, quantity, MovementRestock, "varian baru")
+	}
+	fmt.Printf("Varian %s ditambahkan.\n", fullName)
+}
+
+// Fungsi untuk menghapus varian dari item induk
+func removeVariant(reader *bufio.Reader, parentName string) {
+	fmt.Print("Nama varian yang dihapus: ")
+	label, _ := reader.ReadString('\n')
+	label = strings.TrimSpace(label)
+
+	menuMutex.Lock()
+	parent := findMenuParent(parentName)
+	var variant *MenuItem
+	if parent != nil {
+		variant = findMenuItemIn(parent.Variants, label)
+		if variant == nil {
+			variant = findMenuItemIn(parent.Variants, variantName(parent.Name, label))
+		}
+	}
+	var fullName string
+	if variant != nil {
+		fullName = variant.Name
+	}
+	menuMutex.Unlock()
+	if variant == nil {
+		fmt.Println("Varian tidak ditemukan.")
+		return
+	}
+
+	if !requireAdminPIN(reader, "hapus varian "+fullName) {
+		return
+	}
+	if err := activeMenuRepo().DeleteMenuItem(fullName); err != nil {
+		fmt.Println("Gagal menghapus varian:", err)
+		return
+	}
+
+	menuMutex.Lock()
+	menu = removeMenuItem(menu, fullName)
+	invalidateMenuCache()
+	menuMutex.Unlock()
+	fmt.Printf("Varian %s dihapus.\n", fullName)
+}
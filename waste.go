@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Struct untuk mencatat item yang terbuang atau rusak
+type WasteRecord struct {
+	Time     time.Time `json:"time"`
+	ItemName string    `json:"item"`
+	Quantity int       `json:"quantity"`
+	Reason   string    `json:"reason"`
+	UnitCost Money     `json:"unit_cost"`
+}
+
+// Nama file catatan waste di folder data
+const wasteFile = "waste.json"
+
+// Catatan waste untuk laporan penyusutan, dibaca dari file saat pertama kali dipakai
+var wasteLog []WasteRecord
+var wasteLoaded bool
+var wasteMutex sync.Mutex
+
+// Fungsi untuk membaca catatan waste dari file jika belum dibaca. Pada storage memory
+// catatan waste hanya ada selama program berjalan. Pemanggil harus memegang wasteMutex.
+func ensureWasteLoaded() {
+	if wasteLoaded {
+		return
+	}
+	wasteLoaded = true
+	if currentConfig().Storage == StorageMemory {
+		return
+	}
+	if _, err := readJSONFile(filepath.Join(currentConfig().DataDir, wasteFile), &wasteLog); err != nil {
+		fmt.Println("Gagal membaca catatan waste:", err)
+	}
+}
+
+// Fungsi untuk menyimpan catatan waste ke file, pemanggil harus memegang wasteMutex
+func saveWasteLog() {
+	if currentConfig().Storage == StorageMemory {
+		return
+	}
+	if err := writeJSONFile(filepath.Join(currentConfig().DataDir, wasteFile), wasteLog); err != nil {
+		fmt.Println("Gagal menyimpan catatan waste:", err)
+	}
+}
+
+// Fungsi untuk mencatat item yang terbuang dan mengurangi stoknya
+func logWaste(reader *bufio.Reader) {
+	fmt.Print("Masukkan nama item: ")
+	name, _ := reader.ReadString('\n')
+	name = strings.TrimSpace(name)
+
+	fmt.Print("Masukkan jumlah yang terbuang: ")
+	quantityInput, _ := reader.ReadString('\n')
+	quantity, err := parseQuantityInput(quantityInput)
+	if err != nil || quantity <= 0 {
+		fmt.Println("Jumlah harus berupa angka positif.")
+		return
+	}
+
+	fmt.Print("Alasan (misal basi, jatuh, salah masak): ")
+	reason, _ := reader.ReadString('\n')
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		fmt.Println("Alasan wajib diisi.")
+		return
+	}
+
+	menuMutex.Lock()
+	item := findMenuItem(name)
+	if item == nil {
+		menuMutex.Unlock()
+		fmt.Println("Item tidak ditemukan.")
+		return
+	}
+	if quantity > item.Quantity {
+		menuMutex.Unlock()
+		fmt.Println("Jumlah melebihi stok yang tersedia.")
+		return
+	}
+
+	if err := item.removeStock(quantity); err != nil {
+		menuMutex.Unlock()
+		fmt.Println("Gagal mengurangi stok:", err)
+		return
+	}
+	recordCostedMovement(item.Name, -quantity, MovementWaste, reason, item.Cost)
+	record := WasteRecord{Time: time.Now(), ItemName: item.Name, Quantity: quantity, Reason: reason, UnitCost: item.Cost}
+	menuMutex.Unlock()
+
+	wasteMutex.Lock()
+	ensureWasteLoaded()
+	wasteLog = append(wasteLog, record)
+	saveWasteLog()
+	wasteMutex.Unlock()
+
+	fmt.Printf("Waste %s x%d dicatat dengan nilai %s.\n", record.ItemName, quantity, formatMoney(record.UnitCost.Times(quantity)))
+}
+
+// Fungsi untuk menampilkan nilai waste per hari dan per item dalam rentang tanggal
+func displayWasteReport(reader *bufio.Reader) {
+	from, ok := readOptionalDate(reader, "Dari tanggal (YYYY-MM-DD, kosongkan untuk semua): ")
+	if !ok {
+		return
+	}
+	to, ok := readOptionalDate(reader, "Sampai tanggal (YYYY-MM-DD, kosongkan untuk semua): ")
+	if !ok {
+		return
+	}
+	if !to.IsZero() {
+		to = to.AddDate(0, 0, 1)
+	}
+
+	wasteMutex.Lock()
+	defer wasteMutex.Unlock()
+
+	ensureWasteLoaded()
+	perDay := map[string]Money{}
+	perItem := map[string]Money{}
+	var total Money
+	for _, record := range wasteLog {
+		if !from.IsZero() && record.Time.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !record.Time.Before(to) {
+			continue
+		}
+		cost := record.UnitCost.Times(record.Quantity)
+		perDay[record.Time.Format("2006-01-02")] += cost
+		perItem[record.ItemName] += cost
+		total += cost
+	}
+
+	fmt.Println("\n===== Laporan Waste =====")
+	if len(perDay) == 0 {
+		fmt.Println("Tidak ada waste pada periode ini.")
+		return
+	}
+
+	days := make([]string, 0, len(perDay))
+	for day := range perDay {
+		days = append(days, day)
+	}
+	sort.Strings(days)
+	for _, day := range days {
+		date, _ := time.ParseInLocation("2006-01-02", day, time.Local)
+		fmt.Printf("Tanggal: %s | Nilai Waste: %s\n", formatDate(date), formatMoney(perDay[day]))
+	}
+
+	fmt.Println("--- Per Item ---")
+	items := make([]string, 0, len(perItem))
+	for name := range perItem {
+		items = append(items, name)
+	}
+	sort.Strings(items)
+	for _, name := range items {
+		fmt.Printf("Nama: %s | Nilai Waste: %s\n", name, formatMoney(perItem[name]))
+	}
+
+	fmt.Printf("Total Nilai Waste: %s\n", formatMoney(total))
+}